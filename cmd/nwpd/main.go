@@ -30,6 +30,9 @@ var (
 
 func main() {
 	rootCmd.AddCommand(agent.CreateRunAgentCmd(Version))
+	rootCmd.AddCommand(agent.CreateRunCanaryCmd())
+	rootCmd.AddCommand(agent.CreateJobSchemaCmd())
+	rootCmd.AddCommand(agent.CreateValidateConfigCmd())
 	rootCmd.AddCommand(controller.CreateRunControllerCmd())
 	rootCmd.AddCommand(deploy.CreateDeployCmd(ImageTag))
 	rootCmd.AddCommand(collect.CreateCollectCmd())
@@ -37,6 +40,8 @@ func main() {
 	rootCmd.AddCommand(aggregate.CreateAggregateCmd())
 	rootCmd.AddCommand(query.CreateQueryCmd())
 	rootCmd.AddCommand(list.CreateListCmd())
+	rootCmd.AddCommand(list.CreateProbeCmd())
+	rootCmd.AddCommand(list.CreateStatusCmd())
 	err := rootCmd.Execute()
 	if err != nil {
 		panic(err)
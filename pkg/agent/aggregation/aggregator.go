@@ -37,6 +37,8 @@ type ObsAggregationOptions struct {
 	HostNetwork bool
 	// K8sExporterConfig configuration for patching conditions in node status and creating events
 	K8sExporterConfig config.K8sExporterConfig
+	// MaxClockOffset is the limit of the median clock offset to the peers measured by `checkClockSkew` jobs (disabled if 0)
+	MaxClockOffset time.Duration
 }
 
 type obsAggr struct {
@@ -49,6 +51,7 @@ type obsAggr struct {
 	timeWindow        time.Duration
 	logDirectory      string
 	hostNetwork       bool
+	maxClockOffset    time.Duration
 	validEdges        ValidEdges
 	lastReport        time.Time
 }
@@ -70,6 +73,7 @@ type ObservationListenerExtended interface {
 	nwpd.ObservationListener
 
 	UpdateValidEdges(edges ValidEdges)
+	GetValidEdges() ValidEdges
 }
 
 func (je jobEdge) String() string {
@@ -82,12 +86,14 @@ type jobEdgeAggregation struct {
 	reportStart        time.Time
 	reportOkCount      int
 	reportFailureCount int
+	reportRetriedCount int
 	okLast             time.Time
 	okStrikeFirst      time.Time
 	okStrike           int
 	failedLast         time.Time
 	failedStrikeFirst  time.Time
 	failedStrike       int
+	failedLastClass    nwpd.FailureClass
 	lastObs            *nwpd.Observation
 }
 
@@ -95,6 +101,11 @@ func (jea *jobEdgeAggregation) IsOKSinceLastReport() bool {
 	return jea.reportFailureCount == 0
 }
 
+// IsRetriedSinceLastReport returns true if checks only succeeded on retry since the last report.
+func (jea *jobEdgeAggregation) IsRetriedSinceLastReport() bool {
+	return jea.reportRetriedCount > 0
+}
+
 func (jea *jobEdgeAggregation) IsLastOK() bool {
 	return jea.okStrike > 0
 }
@@ -114,6 +125,12 @@ func (jea *jobEdgeAggregation) Report(je jobEdge, start time.Time) string {
 			d := jea.lastObs.Duration.AsDuration().Milliseconds()
 			msg += fmt.Sprintf(" (%d ms)", d)
 		}
+		if jea.lastObs != nil && jea.lastObs.ClockOffset != nil {
+			msg += fmt.Sprintf(" (clock offset %s)", jea.lastObs.ClockOffset.AsDuration())
+		}
+		if jea.IsRetriedSinceLastReport() {
+			msg += fmt.Sprintf(" (%d/%d checks succeeded on retry)", jea.reportRetriedCount, jea.reportOkCount)
+		}
 		if jea.lastObs != nil && jea.lastObs.Timestamp.AsTime().Before(start) {
 			msg += fmt.Sprintf(" last observed: %s", common.FormatAsUTC(jea.lastObs.Timestamp.AsTime()))
 		}
@@ -135,12 +152,16 @@ func (jea *jobEdgeAggregation) add(obs *nwpd.Observation) {
 		jea.okLast = obs.Timestamp.AsTime()
 		jea.okStrike++
 		jea.reportOkCount++
+		if obs.Attempts > 1 {
+			jea.reportRetriedCount++
+		}
 	} else {
 		if jea.failedLast.Before(jea.okLast) {
 			jea.failedStrike = 0
 			jea.failedStrikeFirst = obs.Timestamp.AsTime()
 		}
 		jea.failedLast = obs.Timestamp.AsTime()
+		jea.failedLastClass = obs.FailureClass
 		jea.failedStrike++
 		jea.reportFailureCount++
 	}
@@ -160,6 +181,7 @@ type groupCounter struct {
 	ok      map[string]int
 	unknown map[string]int
 	failed  map[string]int
+	retried map[string]int
 }
 
 func newGroupCounter() *groupCounter {
@@ -167,6 +189,7 @@ func newGroupCounter() *groupCounter {
 		ok:      map[string]int{},
 		unknown: map[string]int{},
 		failed:  map[string]int{},
+		retried: map[string]int{},
 	}
 }
 
@@ -181,19 +204,31 @@ func (c *groupCounter) inc(key string, ok *bool) {
 	}
 }
 
+// incRetried counts a key with checks only succeeding on retry. These keys are also counted as ok.
+func (c *groupCounter) incRetried(key string) {
+	c.retried[key]++
+}
+
 func (c *groupCounter) summary() string {
-	var failedNames []string
-	for key := range c.failed {
-		failedNames = append(failedNames, key)
-	}
-	sort.Strings(failedNames)
 	suffix := ""
-	if len(failedNames) > 0 {
-		suffix = fmt.Sprintf(" (failed items: %s)", strings.Join(failedNames, ","))
+	if names := sortedKeys(c.failed); len(names) > 0 {
+		suffix = fmt.Sprintf(" (failed items: %s)", strings.Join(names, ","))
+	}
+	if names := sortedKeys(c.retried); len(names) > 0 {
+		suffix += fmt.Sprintf(" (succeeded on retry: %s)", strings.Join(names, ","))
 	}
 	return fmt.Sprintf("ok/unknown/failed: %d/%d/%d%s", len(c.ok), len(c.unknown), len(c.failed), suffix)
 }
 
+func sortedKeys(m map[string]int) []string {
+	var keys []string
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 type conditionStatus struct {
 	conditionType           string
 	source                  string
@@ -201,6 +236,8 @@ type conditionStatus struct {
 	minFailingPeerNodeShare float64
 	alerts                  map[jobEdge]time.Time
 	nodeRelated             map[string]struct{}
+	notBlocked              map[jobEdge]struct{}
+	clockSkews              []string
 	lastChange              time.Time
 }
 
@@ -220,6 +257,7 @@ func newConditionStatus(hostNetwork bool, minFailingPeerNodeShare float64) *cond
 		minFailingPeerNodeShare: minFailingPeerNodeShare,
 		alerts:                  map[jobEdge]time.Time{},
 		nodeRelated:             map[string]struct{}{},
+		notBlocked:              map[jobEdge]struct{}{},
 	}
 }
 
@@ -251,10 +289,12 @@ func (cs *conditionStatus) report(peerNodeCount int) types.Condition {
 		Source:     cs.source,
 	}
 	if len(cs.alerts) == 0 {
-		return okCondition
+		return cs.withClockSkews(okCondition)
 	}
 
 	condition := okCondition
+	policyJobIDSet := common.StringSet{}
+	policyDestHostSet := common.StringSet{}
 	condition.Status = types.True
 	condition.Reason = "FailedNetworkChecks"
 	jobIDSet := common.StringSet{}
@@ -267,6 +307,11 @@ func (cs *conditionStatus) report(peerNodeCount int) types.Condition {
 		if firstTime.Before(condition.Transition) {
 			condition.Transition = firstTime
 		}
+		if _, ok := cs.notBlocked[je]; ok {
+			policyJobIDSet.Add(je.jobID)
+			policyDestHostSet.Add(je.destHost)
+			continue
+		}
 		if _, ok := cs.nodeRelated[je.jobID]; ok {
 			nodeRelatedJobIDSet.Add(je.jobID)
 			nodeRelatedDestHostSet.Add(je.destHost)
@@ -287,7 +332,7 @@ func (cs *conditionStatus) report(peerNodeCount int) types.Condition {
 	}
 	if count == 0 {
 		// only ignored checks
-		return okCondition
+		return cs.withClockSkews(cs.withPolicyNotEnforced(okCondition, policyJobIDSet, policyDestHostSet))
 	}
 	var details string
 	if jobIDSet.Len() == 1 || destHostSet.Len() == 1 {
@@ -296,6 +341,38 @@ func (cs *conditionStatus) report(peerNodeCount int) types.Condition {
 		details = fmt.Sprintf("%d pairs of jobIDs %s and destinations %s", count, toRestrictedList(jobIDSet, 5), toRestrictedList(destHostSet, 3))
 	}
 	condition.Message = fmt.Sprintf("%s network problems for %s", cs.network, details)
+	return cs.withClockSkews(cs.withPolicyNotEnforced(condition, policyJobIDSet, policyDestHostSet))
+}
+
+// withPolicyNotEnforced adds the failed negative checks to the condition, i.e. destinations which are expected to be blocked but are reachable.
+func (cs *conditionStatus) withPolicyNotEnforced(condition types.Condition, jobIDSet, destHostSet common.StringSet) types.Condition {
+	if jobIDSet.Len() == 0 {
+		return condition
+	}
+	msg := fmt.Sprintf("network policy not enforced for jobIDs %s and destinations %s", toRestrictedList(jobIDSet, 5), toRestrictedList(destHostSet, 3))
+	if condition.Status == types.True {
+		condition.Message += "; " + msg
+		return condition
+	}
+	condition.Status = types.True
+	condition.Reason = "NetworkPolicyNotEnforced"
+	condition.Message = msg
+	return condition
+}
+
+// withClockSkews adds the clock skew alerts to the condition.
+func (cs *conditionStatus) withClockSkews(condition types.Condition) types.Condition {
+	if len(cs.clockSkews) == 0 {
+		return condition
+	}
+	msg := "clock skew: " + strings.Join(cs.clockSkews, ", ")
+	if condition.Status == types.True {
+		condition.Message += "; " + msg
+		return condition
+	}
+	condition.Status = types.True
+	condition.Reason = "ClockSkew"
+	condition.Message = msg
 	return condition
 }
 
@@ -343,6 +420,7 @@ func NewObsAggregator(options *ObsAggregationOptions) (ObservationListenerExtend
 		timeWindow:        options.TimeWindow,
 		logDirectory:      options.LogDirectory,
 		hostNetwork:       options.HostNetwork,
+		maxClockOffset:    options.MaxClockOffset,
 		k8sExporter:       k8sExporter,
 		k8sExporterConfig: options.K8sExporterConfig,
 	}, nil
@@ -355,7 +433,19 @@ func (a *obsAggr) UpdateValidEdges(edges ValidEdges) {
 	a.validEdges = edges
 }
 
+func (a *obsAggr) GetValidEdges() ValidEdges {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	return a.validEdges
+}
+
 func (a *obsAggr) Add(obs *nwpd.Observation) {
+	if obs.AdHoc {
+		// results of on-demand probes must not influence the conditions
+		return
+	}
+
 	a.lock.Lock()
 	defer a.lock.Unlock()
 
@@ -383,29 +473,33 @@ type reportOptions struct {
 	conditionMinFailureCount int
 	conditionMinTimeWindow   time.Duration
 	minFailingPeerNodeShare  float64
+	maxClockOffset           time.Duration
 }
 
 type reportData struct {
-	options     *reportOptions
-	start       time.Time
-	end         time.Time
-	jobCounter  *groupCounter
-	srcCounter  *groupCounter
-	destCounter *groupCounter
-	noissues    []string
-	issues      []string
-	status      *conditionStatus
+	options      *reportOptions
+	start        time.Time
+	end          time.Time
+	jobCounter   *groupCounter
+	srcCounter   *groupCounter
+	destCounter  *groupCounter
+	noissues     []string
+	retried      []string
+	issues       []string
+	status       *conditionStatus
+	clockOffsets map[jobEdge][]time.Duration
 }
 
 func newReportData(start, end time.Time, options *reportOptions) *reportData {
 	return &reportData{
-		options:     options,
-		start:       start,
-		end:         end,
-		jobCounter:  newGroupCounter(),
-		srcCounter:  newGroupCounter(),
-		destCounter: newGroupCounter(),
-		status:      newConditionStatus(options.hostNetwork, options.minFailingPeerNodeShare),
+		options:      options,
+		start:        start,
+		end:          end,
+		jobCounter:   newGroupCounter(),
+		srcCounter:   newGroupCounter(),
+		destCounter:  newGroupCounter(),
+		status:       newConditionStatus(options.hostNetwork, options.minFailingPeerNodeShare),
+		clockOffsets: map[jobEdge][]time.Duration{},
 	}
 }
 
@@ -416,12 +510,27 @@ func (r *reportData) add(je jobEdge, aggr *jobEdgeAggregation) {
 		ok = &good
 		r.updateStatus(je, aggr)
 	}
+	if aggr.lastObs != nil && aggr.lastObs.Ok && aggr.lastObs.ClockOffset != nil {
+		// clock offsets are collected per job and source host
+		key := jobEdge{jobID: je.jobID, srcHost: je.srcHost}
+		r.clockOffsets[key] = append(r.clockOffsets[key], aggr.lastObs.ClockOffset.AsDuration())
+	}
 	r.jobCounter.inc(je.jobID, ok)
 	r.srcCounter.inc(je.srcHost, ok)
 	r.destCounter.inc(je.destHost, ok)
-	if ok != nil && !*ok {
+	retried := ok != nil && *ok && aggr.IsRetriedSinceLastReport()
+	if retried {
+		r.jobCounter.incRetried(je.jobID)
+		r.srcCounter.incRetried(je.srcHost)
+		r.destCounter.incRetried(je.destHost)
+	}
+	switch {
+	case ok != nil && !*ok:
 		r.issues = append(r.issues, aggr.Report(je, r.start))
-	} else if r.options.fullReport || ok == nil {
+	case retried:
+		// succeeded on retry is reported with lower severity and does not affect the condition
+		r.retried = append(r.retried, aggr.Report(je, r.start))
+	case r.options.fullReport || ok == nil:
 		if r.options.fullReport || aggr.IsOverdue() {
 			r.noissues = append(r.noissues, aggr.Report(je, r.start))
 		}
@@ -432,11 +541,46 @@ func (r *reportData) updateStatus(je jobEdge, aggr *jobEdgeAggregation) {
 	alerting := aggr.reportFailureCount > 0 &&
 		aggr.failedStrike >= r.options.conditionMinFailureCount &&
 		aggr.failedLast.Sub(aggr.failedStrikeFirst) > r.options.conditionMinTimeWindow
+	// only negative checks failing because the destination is reachable indicate a not enforced network policy,
+	// other failures like DNS problems are reported as network problems.
+	if aggr.lastObs != nil && aggr.lastObs.ExpectBlocked && aggr.failedLastClass == nwpd.FailureClass_FAILURE_CLASS_NOT_BLOCKED {
+		r.status.notBlocked[je] = struct{}{}
+	} else {
+		delete(r.status.notBlocked, je)
+	}
 	r.status.update(je, alerting, aggr.failedStrikeFirst)
 }
 
+// updateClockSkews raises clock skew alerts if the median clock offset of a node to its peers exceeds the limit.
+func (r *reportData) updateClockSkews() {
+	if r.options.maxClockOffset <= 0 {
+		return
+	}
+	for key, offsets := range r.clockOffsets {
+		median := medianDuration(offsets)
+		if median.Abs() <= r.options.maxClockOffset {
+			continue
+		}
+		msg := fmt.Sprintf("median offset %s to %d peers exceeds %s [%s]", median, len(offsets), r.options.maxClockOffset, key.jobID)
+		r.issues = append(r.issues, fmt.Sprintf("%s: %s", key.srcHost, msg))
+		r.status.clockSkews = append(r.status.clockSkews, msg)
+	}
+	sort.Strings(r.status.clockSkews)
+}
+
+func medianDuration(values []time.Duration) time.Duration {
+	sorted := append([]time.Duration{}, values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
 func (r *reportData) sort() {
 	sort.Strings(r.issues)
+	sort.Strings(r.retried)
 	sort.Strings(r.noissues)
 }
 
@@ -455,6 +599,7 @@ func (a *obsAggr) report() {
 		conditionMinFailureCount: 2,
 		conditionMinTimeWindow:   3 * time.Minute,
 		minFailingPeerNodeShare:  a.k8sExporterConfig.MinFailingPeerNodeShare,
+		maxClockOffset:           a.maxClockOffset,
 	}
 	report := a.calcReport(options, true)
 	report.sort()
@@ -468,6 +613,9 @@ func (a *obsAggr) reportToLog(report *reportData) {
 	for _, s := range report.noissues {
 		a.log.Info(prefix + s)
 	}
+	for _, s := range report.retried {
+		a.log.Info(prefix + s)
+	}
 	for _, s := range report.issues {
 		a.log.Warn(prefix + s)
 	}
@@ -512,6 +660,11 @@ func (a *obsAggr) reportToFilesystem(report *reportData) {
 		_, _ = f.WriteString(s)
 		_, _ = f.WriteString("\n")
 	}
+	for _, s := range report.retried {
+		_, _ = f.WriteString(prefix)
+		_, _ = f.WriteString(s)
+		_, _ = f.WriteString("\n")
+	}
 	for _, s := range report.summary() {
 		_, _ = f.WriteString(prefix)
 		_, _ = f.WriteString(s)
@@ -550,8 +703,10 @@ func (a *obsAggr) calcReport(options *reportOptions, resetCount bool) *reportDat
 		if resetCount {
 			aggr.reportOkCount = 0
 			aggr.reportFailureCount = 0
+			aggr.reportRetriedCount = 0
 		}
 	}
+	report.updateClockSkews()
 	return report
 }
 
@@ -0,0 +1,190 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package aggregation
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/gardener/network-problem-detector/pkg/agent/aggregation/types"
+	"github.com/gardener/network-problem-detector/pkg/common/nwpd"
+)
+
+func newTestAggregator(maxClockOffset time.Duration) *obsAggr {
+	aggr, _ := NewObsAggregator(&ObsAggregationOptions{
+		Log:            logrus.New(),
+		NodeName:       "node1",
+		ReportPeriod:   1 * time.Hour,
+		TimeWindow:     1 * time.Hour,
+		HostNetwork:    true,
+		MaxClockOffset: maxClockOffset,
+	})
+	return aggr.(*obsAggr)
+}
+
+func addClockOffsets(aggr *obsAggr, offsets ...time.Duration) {
+	for i, offset := range offsets {
+		aggr.Add(&nwpd.Observation{
+			JobID:       "clock-n2n",
+			SrcHost:     "node1",
+			DestHost:    fmt.Sprintf("node%d", i+2),
+			Timestamp:   timestamppb.Now(),
+			Period:      durationpb.New(time.Minute),
+			Ok:          true,
+			ClockOffset: durationpb.New(offset),
+		})
+	}
+}
+
+func TestMedianDuration(t *testing.T) {
+	assert.Equal(t, 2*time.Second, medianDuration([]time.Duration{3 * time.Second, 1 * time.Second, 2 * time.Second}))
+	assert.Equal(t, 1500*time.Millisecond, medianDuration([]time.Duration{2 * time.Second, 1 * time.Second}))
+	assert.Equal(t, -1*time.Second, medianDuration([]time.Duration{-1 * time.Second}))
+}
+
+func TestClockSkewCondition(t *testing.T) {
+	for _, testCase := range []struct {
+		name           string
+		maxClockOffset time.Duration
+		offsets        []time.Duration
+		status         types.ConditionStatus
+		reason         string
+		message        string
+	}{
+		{
+			name:           "offsets within limit",
+			maxClockOffset: time.Second,
+			offsets:        []time.Duration{-2 * time.Second, 100 * time.Millisecond, -200 * time.Millisecond},
+			status:         types.False,
+			reason:         "NoNetworkProblems",
+		},
+		{
+			name:           "median offset exceeds limit",
+			maxClockOffset: time.Second,
+			offsets:        []time.Duration{-2 * time.Second, -3 * time.Second, 100 * time.Millisecond},
+			status:         types.True,
+			reason:         "ClockSkew",
+			message:        "clock skew: median offset -2s to 3 peers exceeds 1s [clock-n2n]",
+		},
+		{
+			name:    "disabled",
+			offsets: []time.Duration{-2 * time.Second, -3 * time.Second, 100 * time.Millisecond},
+			status:  types.False,
+			reason:  "NoNetworkProblems",
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			aggr := newTestAggregator(testCase.maxClockOffset)
+			addClockOffsets(aggr, testCase.offsets...)
+			report := aggr.calcReport(&reportOptions{hostNetwork: true, maxClockOffset: testCase.maxClockOffset}, true)
+			condition := report.status.report(len(testCase.offsets))
+			assert.Equal(t, testCase.status, condition.Status)
+			assert.Equal(t, testCase.reason, condition.Reason)
+			if testCase.message != "" {
+				assert.Equal(t, testCase.message, condition.Message)
+			}
+		})
+	}
+}
+
+func TestSucceededOnRetry(t *testing.T) {
+	aggr := newTestAggregator(0)
+	for i, attempts := range []int32{1, 2, 1} {
+		aggr.Add(&nwpd.Observation{
+			JobID:     "tcp-n2n",
+			SrcHost:   "node1",
+			DestHost:  "node2",
+			Timestamp: timestamppb.New(time.Now().Add(time.Duration(i-3) * time.Minute)),
+			Period:    durationpb.New(time.Minute),
+			Ok:        true,
+			Attempts:  attempts,
+		})
+	}
+	report := aggr.calcReport(&reportOptions{hostNetwork: true, conditionMinFailureCount: 2, conditionMinTimeWindow: time.Minute}, true)
+	assert.Empty(t, report.issues)
+	assert.Equal(t, []string{"node1->node2[tcp-n2n]: OK (1/3 checks succeeded on retry)"}, report.retried)
+	assert.Equal(t, "ok/unknown/failed: 1/0/0 (succeeded on retry: tcp-n2n)", report.jobCounter.summary())
+	condition := report.status.report(1)
+	assert.Equal(t, types.False, condition.Status)
+
+	// the retried state is reset with the report
+	report = aggr.calcReport(&reportOptions{hostNetwork: true}, true)
+	assert.Empty(t, report.retried)
+}
+
+func addFailedObservations(aggr *obsAggr, jobID, destHost string, expectBlocked bool, failureClass nwpd.FailureClass) {
+	for i := 0; i < 3; i++ {
+		aggr.Add(&nwpd.Observation{
+			JobID:         jobID,
+			SrcHost:       "node1",
+			DestHost:      destHost,
+			Timestamp:     timestamppb.New(time.Now().Add(time.Duration(i-3) * time.Minute)),
+			Period:        durationpb.New(time.Minute),
+			Ok:            false,
+			ExpectBlocked: expectBlocked,
+			FailureClass:  failureClass,
+		})
+	}
+}
+
+func TestNetworkPolicyNotEnforced(t *testing.T) {
+	options := &reportOptions{hostNetwork: true, conditionMinFailureCount: 2, conditionMinTimeWindow: time.Minute}
+
+	aggr := newTestAggregator(0)
+	addFailedObservations(aggr, "policy-tcp-p2canary", "canary", true, nwpd.FailureClass_FAILURE_CLASS_NOT_BLOCKED)
+	condition := aggr.calcReport(options, true).status.report(1)
+	assert.Equal(t, types.True, condition.Status)
+	assert.Equal(t, "NetworkPolicyNotEnforced", condition.Reason)
+	assert.Equal(t, "network policy not enforced for jobIDs policy-tcp-p2canary and destinations canary", condition.Message)
+
+	aggr = newTestAggregator(0)
+	addFailedObservations(aggr, "policy-tcp-p2canary", "canary", true, nwpd.FailureClass_FAILURE_CLASS_NOT_BLOCKED)
+	addFailedObservations(aggr, "tcp-n2n", "node2", false, nwpd.FailureClass_FAILURE_CLASS_TIMEOUT)
+	condition = aggr.calcReport(options, true).status.report(1)
+	assert.Equal(t, types.True, condition.Status)
+	assert.Equal(t, "FailedNetworkChecks", condition.Reason)
+	assert.Equal(t, "host network problems for jobID/destination combinations: tcp-n2n/node2; "+
+		"network policy not enforced for jobIDs policy-tcp-p2canary and destinations canary", condition.Message)
+}
+
+func TestNegativeCheckFailuresNotBlocked(t *testing.T) {
+	options := &reportOptions{hostNetwork: true, conditionMinFailureCount: 2, conditionMinTimeWindow: time.Minute}
+
+	for _, failureClass := range []nwpd.FailureClass{nwpd.FailureClass_FAILURE_CLASS_DNS, nwpd.FailureClass_FAILURE_CLASS_UNREACHABLE} {
+		aggr := newTestAggregator(0)
+		addFailedObservations(aggr, "policy-tcp-p2canary", "canary", true, failureClass)
+		condition := aggr.calcReport(options, true).status.report(1)
+		assert.Equal(t, types.True, condition.Status, failureClass.String())
+		assert.Equal(t, "FailedNetworkChecks", condition.Reason, failureClass.String())
+		assert.Equal(t, "host network problems for jobID/destination combinations: policy-tcp-p2canary/canary", condition.Message, failureClass.String())
+	}
+
+	// a later not blocked failure of the same edge is reported as not enforced network policy
+	aggr := newTestAggregator(0)
+	addFailedObservations(aggr, "policy-tcp-p2canary", "canary", true, nwpd.FailureClass_FAILURE_CLASS_DNS)
+	aggr.calcReport(options, true)
+	addFailedObservations(aggr, "policy-tcp-p2canary", "canary", true, nwpd.FailureClass_FAILURE_CLASS_NOT_BLOCKED)
+	condition := aggr.calcReport(options, true).status.report(1)
+	assert.Equal(t, "NetworkPolicyNotEnforced", condition.Reason)
+}
+
+func TestIgnoreAdHocObservations(t *testing.T) {
+	aggr := newTestAggregator(0)
+	aggr.Add(&nwpd.Observation{
+		JobID:     "adhoc-checkTCPPort",
+		SrcHost:   "node1",
+		DestHost:  "node2",
+		Timestamp: timestamppb.Now(),
+		Period:    durationpb.New(time.Minute),
+		AdHoc:     true,
+	})
+	assert.Empty(t, aggr.aggregations)
+}
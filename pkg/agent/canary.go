@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/gardener/network-problem-detector/pkg/common"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var canaryPort int
+
+// CreateRunCanaryCmd creates the command running the network policy canary.
+// The canary is the destination of negative checks, which expect it to be blocked by a network policy.
+func CreateRunCanaryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "run-canary",
+		Short: "runs network policy canary",
+		Long:  `The canary provides HTTP and UDP echo endpoints on the same port. It is expected to be isolated by a network policy.`,
+	}
+	cmd.Flags().IntVar(&canaryPort, "port", common.NetworkPolicyCanaryPort, "TCP and UDP port of the canary.")
+	cmd.RunE = runCanary
+	return cmd
+}
+
+func runCanary(_ *cobra.Command, _ []string) error {
+	log := logrus.WithField("cmd", "canary")
+
+	log.Infof("provide UDP echo responder at ':%d'", canaryPort)
+	go func() {
+		err := runUDPEchoResponder(log.WithField("sub", "udpecho"), canaryPort)
+		log.Warnf("UDP echo responder stopped: %s", err)
+	}()
+
+	log.Infof("provide HTTP echo endpoint at ':%d'", canaryPort)
+	server := &http.Server{
+		Addr:    fmt.Sprintf(":%d", canaryPort),
+		Handler: newHTTPEchoHandler(os.Getenv(common.EnvNodeName), os.Getenv(common.EnvPodName)),
+		// Set timeouts to avoid Slowloris attacks and other issues
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 10 * time.Second,
+		IdleTimeout:  15 * time.Second,
+	}
+	return server.ListenAndServe()
+}
@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package db
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// recordFileSuffix is the suffix of plain record files.
+	recordFileSuffix = ".records"
+	// compressedRecordFileSuffix is the suffix of gzip compressed record files.
+	compressedRecordFileSuffix = recordFileSuffix + ".gz"
+	// tempFileSuffix is the suffix of a compressed record file while it is written.
+	tempFileSuffix = ".tmp"
+)
+
+// IsRecordFile returns true if the file name has the suffix of a plain or compressed record file.
+func IsRecordFile(name string) bool {
+	return strings.HasSuffix(name, recordFileSuffix) || strings.HasSuffix(name, compressedRecordFileSuffix)
+}
+
+// openRecordFile opens a plain or compressed record file for reading.
+func openRecordFile(filename string) (io.ReadCloser, error) {
+	f, err := os.OpenFile(filepath.Clean(filename), os.O_RDONLY, 0o640) //  #nosec G302 -- no sensitive data
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(filename, compressedRecordFileSuffix) {
+		return f, nil
+	}
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		_ = f.Close()
+		if err == io.EOF {
+			// empty file, e.g. compression was interrupted
+			return io.NopCloser(strings.NewReader("")), nil
+		}
+		return nil, err
+	}
+	return &gzipFile{Reader: gr, file: f}, nil
+}
+
+type gzipFile struct {
+	*gzip.Reader
+	file *os.File
+}
+
+func (f *gzipFile) Close() error {
+	err := f.Reader.Close()
+	if err2 := f.file.Close(); err == nil {
+		err = err2
+	}
+	return err
+}
+
+// compressRotatedFiles compresses and indexes all plain record files of the writer except the current one.
+// Files left over by a previous agent, e.g. if it was stopped during compression, are compressed too.
+func (w *obsWriter) compressRotatedFiles(current string) {
+	entries, err := os.ReadDir(w.directory)
+	if err != nil {
+		w.log.Warnf("cannot read directory %s: %s", w.directory, err)
+		return
+	}
+	for _, entry := range entries {
+		filename := path.Join(w.directory, entry.Name())
+		if entry.IsDir() || !strings.HasPrefix(entry.Name(), w.prefix) || !strings.HasSuffix(entry.Name(), recordFileSuffix) || filename == current {
+			continue
+		}
+		if err := compressFile(filename); err != nil {
+			w.log.Warnf("compressing file %s failed: %s", filename, err)
+		} else {
+			w.log.Infof("compressed file %s", filename)
+		}
+	}
+}
+
+// compressFile replaces a plain record file by a gzip compressed file and its index with the same modification time,
+// so that the retention is not affected.
+func compressFile(filename string) error {
+	src, err := os.Open(filepath.Clean(filename))
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	stat, err := src.Stat()
+	if err != nil {
+		return err
+	}
+
+	target := strings.TrimSuffix(filename, recordFileSuffix) + compressedRecordFileSuffix
+	tmp := target + tempFileSuffix
+	index, err := writeIndexedCompressed(tmp, src)
+	if err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	if err := os.Chtimes(tmp, stat.ModTime(), stat.ModTime()); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	if err := writeIndexFile(RecordIndexFilename(filename), index, stat.ModTime()); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("writing index failed: %w", err)
+	}
+	if err := os.Rename(tmp, target); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	if err := os.Remove(filepath.Clean(filename)); err != nil {
+		return fmt.Errorf("removing plain file failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package db
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+)
+
+var _ = Describe("compression", func() {
+	var (
+		dir string
+		w   *obsWriter
+	)
+
+	hourFile := func(hour time.Time, suffix string) string {
+		return fmt.Sprintf("%s/test-%s%s", dir, startOfHourUTC(hour).Format("2006-01-02-15"), suffix)
+	}
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		w = &obsWriter{log: logrus.New(), directory: dir, prefix: "test", retentionHours: 2}
+	})
+
+	It("should compress rotated files and keep the current file", func() {
+		now := time.Now()
+		previous := hourFile(now.Add(-time.Hour), recordFileSuffix)
+		current := hourFile(now, recordFileSuffix)
+		observations := newTestObservations(100)
+		writeTestRecordFile(previous, recordFormatV2, observations)
+		writeTestRecordFile(current, recordFormatV2, observations[:1])
+		modTime := now.Add(-30 * time.Minute).Truncate(time.Second)
+		Expect(os.Chtimes(previous, modTime, modTime)).To(Succeed())
+		plain, err := os.Stat(previous)
+		Expect(err).NotTo(HaveOccurred())
+
+		w.compressRotatedFiles(current)
+
+		Expect(previous).NotTo(BeAnExistingFile())
+		Expect(current).To(BeAnExistingFile())
+		compressed, err := os.Stat(hourFile(now.Add(-time.Hour), compressedRecordFileSuffix))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(compressed.Size()).To(BeNumerically("<", plain.Size()))
+		Expect(compressed.ModTime()).To(Equal(modTime))
+		expectObservations(readTestRecordFile(hourFile(now.Add(-time.Hour), compressedRecordFileSuffix)), observations)
+	})
+
+	It("should list and read plain and compressed files transparently", func() {
+		now := time.Now()
+		observations := newTestObservations(4)
+		writeTestRecordFile(hourFile(now.Add(-time.Hour), recordFileSuffix), recordFormatV2, observations[:2])
+		writeTestRecordFile(hourFile(now, recordFileSuffix), recordFormatV1, observations[2:])
+		w.compressRotatedFiles(hourFile(now, recordFileSuffix))
+
+		files, err := GetRecordFiles(dir, "test", now.Add(-2*time.Hour), now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(files).To(Equal([]string{hourFile(now.Add(-time.Hour), compressedRecordFileSuffix), hourFile(now, recordFileSuffix)}))
+		anyFiles, err := GetAnyRecordFiles(dir, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(anyFiles).To(ConsistOf(files))
+
+		var all []string
+		for _, file := range files {
+			for _, obs := range readTestRecordFile(file) {
+				all = append(all, obs.DestHost)
+			}
+		}
+		Expect(all).To(Equal([]string{"node-0", "node-1", "node-2", "node-0"}))
+	})
+
+	It("should skip a plain file which has already been compressed", func() {
+		now := time.Now()
+		previous := hourFile(now.Add(-time.Hour), recordFileSuffix)
+		observations := newTestObservations(4)
+		writeTestRecordFile(previous, recordFormatV2, observations)
+		Expect(compressFile(previous)).To(Succeed())
+		// state between renaming the compressed file and removing the plain file
+		writeTestRecordFile(previous, recordFormatV2, observations)
+
+		files, err := GetRecordFiles(dir, "test", now.Add(-2*time.Hour), now.Add(-time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(files).To(Equal([]string{hourFile(now.Add(-time.Hour), compressedRecordFileSuffix)}))
+		anyFiles, err := GetAnyRecordFiles(dir, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(anyFiles).To(Equal(files))
+	})
+
+	It("should delete old compressed files by their modification time", func() {
+		now := time.Now()
+		old := hourFile(now.Add(-5*time.Hour), recordFileSuffix)
+		writeTestRecordFile(old, recordFormatV2, newTestObservations(1))
+		Expect(os.Chtimes(old, now.Add(-5*time.Hour), now.Add(-5*time.Hour))).To(Succeed())
+		w.compressRotatedFiles("")
+		Expect(hourFile(now.Add(-5*time.Hour), compressedRecordFileSuffix)).To(BeAnExistingFile())
+
+		w.cleanOldFiles()
+		Expect(hourFile(now.Add(-5*time.Hour), compressedRecordFileSuffix)).NotTo(BeAnExistingFile())
+	})
+
+	It("should ignore a truncated compressed file", func() {
+		filename := hourFile(time.Now().Add(-time.Hour), recordFileSuffix)
+		observations := newTestObservations(1000)
+		writeTestRecordFile(filename, recordFormatV2, observations)
+		Expect(compressFile(filename)).To(Succeed())
+		compressed := hourFile(time.Now().Add(-time.Hour), compressedRecordFileSuffix)
+		data, err := os.ReadFile(compressed)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.WriteFile(compressed, data[:len(data)/2], 0o600)).To(Succeed())
+
+		read := readTestRecordFile(compressed)
+		Expect(len(read)).To(BeNumerically(">", 0))
+		Expect(len(read)).To(BeNumerically("<", len(observations)))
+		expectObservations(read, observations[:len(read)])
+	})
+})
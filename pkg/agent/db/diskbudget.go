@@ -0,0 +1,196 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package db
+
+import (
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// diskCheckPeriod is the period of checking the disk budget.
+	diskCheckPeriod = 30 * time.Second
+
+	// EvictionReasonMaxDiskBytes is the eviction reason if the record files exceed the maximum size.
+	EvictionReasonMaxDiskBytes = "maxDiskBytes"
+	// EvictionReasonMinFreeDiskPercent is the eviction reason if the free space of the file system is below the minimum.
+	EvictionReasonMinFreeDiskPercent = "minFreeDiskPercent"
+)
+
+// DiskBudget limits the disk usage of the record files of a writer.
+type DiskBudget struct {
+	// MaxBytes is the maximum total size of the record files (0 means unlimited).
+	MaxBytes int64
+	// MinFreePercent is the minimum free space of the file system in percent (0 means unlimited).
+	MinFreePercent int
+}
+
+// DiskUsageReporter receives the disk usage of a writer, e.g. to export it as metrics.
+type DiskUsageReporter interface {
+	// ReportDiskUsage reports the total size of the record files and the free space of the file system in percent (negative if unknown).
+	ReportDiskUsage(usedBytes int64, freePercent float64)
+	// IncEvictedFiles counts a file deleted to keep the disk budget.
+	IncEvictedFiles(reason string)
+	// IncDroppedObservations counts an observation not written because the disk budget is exhausted or the disk is full.
+	IncDroppedObservations()
+}
+
+type noopDiskUsageReporter struct{}
+
+func (noopDiskUsageReporter) ReportDiskUsage(_ int64, _ float64) {}
+func (noopDiskUsageReporter) IncEvictedFiles(_ string)           {}
+func (noopDiskUsageReporter) IncDroppedObservations()            {}
+
+type recordFileInfo struct {
+	filename string
+	// index is the index file deleted together with the record file, if any
+	index   string
+	size    int64
+	modTime time.Time
+}
+
+// listFilesByAge returns the files of the writer ordered from oldest to newest and their total size.
+// The size of a record file includes the size of its index.
+func (w *obsWriter) listFilesByAge() ([]recordFileInfo, int64, error) {
+	entries, err := os.ReadDir(w.directory)
+	if err != nil {
+		return nil, 0, err
+	}
+	var (
+		files   []recordFileInfo
+		indexes = map[string]recordFileInfo{}
+		total   int64
+	)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasPrefix(entry.Name(), w.prefix) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		file := recordFileInfo{filename: path.Join(w.directory, entry.Name()), size: info.Size(), modTime: info.ModTime()}
+		total += info.Size()
+		if IsRecordIndexFile(entry.Name()) {
+			indexes[file.filename] = file
+			continue
+		}
+		files = append(files, file)
+	}
+	for i := range files {
+		if index, ok := indexes[RecordIndexFilename(files[i].filename)]; ok {
+			files[i].index = index.filename
+			files[i].size += index.size
+			delete(indexes, index.filename)
+		}
+	}
+	for _, index := range indexes {
+		// orphaned index
+		files = append(files, index)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		if !files[i].modTime.Equal(files[j].modTime) {
+			return files[i].modTime.Before(files[j].modTime)
+		}
+		return files[i].filename < files[j].filename
+	})
+	return files, total, nil
+}
+
+// enforceDiskBudget evicts the oldest files until the disk budget is kept. The current file is only evicted if it alone
+// exceeds the maximum size. It is closed and the next write starts a new file.
+// If the budget cannot be kept, the writer drops observations until space is available again.
+func (w *obsWriter) enforceDiskBudget(current string) {
+	if !w.housekeeping.TryLock() {
+		// files are just compressed or cleaned, check again later
+		return
+	}
+	defer w.housekeeping.Unlock()
+
+	files, used, err := w.listFilesByAge()
+	if err != nil {
+		w.log.Warnf("cannot read directory %s: %s", w.directory, err)
+		return
+	}
+	free, total, fsErr := filesystemSpace(w.directory)
+	freePercent := func() float64 {
+		if fsErr != nil || total == 0 {
+			return -1
+		}
+		return float64(free) * 100 / float64(total)
+	}
+	exceeded := func() string {
+		switch {
+		case w.budget.MaxBytes > 0 && used > w.budget.MaxBytes:
+			return EvictionReasonMaxDiskBytes
+		case w.budget.MinFreePercent > 0 && fsErr == nil && freePercent() < float64(w.budget.MinFreePercent):
+			return EvictionReasonMinFreeDiskPercent
+		default:
+			return ""
+		}
+	}
+
+	evict := func(f recordFileInfo, reason string) {
+		if err := os.Remove(f.filename); err != nil {
+			w.log.Warnf("cannot delete file %s: %s", f.filename, err)
+			return
+		}
+		if f.index != "" {
+			if err := os.Remove(f.index); err != nil && !os.IsNotExist(err) {
+				w.log.Warnf("cannot delete file %s: %s", f.index, err)
+			}
+		}
+		w.log.Infof("deleted file %s to keep disk budget (%s)", f.filename, reason)
+		w.reporter.IncEvictedFiles(reason)
+		used -= f.size
+		free += uint64(f.size) // #nosec G115 -- file sizes are positive
+	}
+
+	for _, f := range files {
+		reason := exceeded()
+		if reason == "" {
+			break
+		}
+		if f.filename == current {
+			continue
+		}
+		evict(f, reason)
+	}
+	if exceeded() == EvictionReasonMaxDiskBytes && current != "" {
+		// the current file alone exceeds the maximum size, start a new file instead of dropping observations until the next rotation
+		for _, f := range files {
+			if f.filename == current {
+				if w.currentFilename() == current {
+					w.closeCurrentFile()
+				}
+				evict(f, EvictionReasonMaxDiskBytes)
+			}
+		}
+	}
+	w.reporter.ReportDiskUsage(used, freePercent())
+
+	if reason := exceeded(); reason != "" {
+		w.setDegraded(true, "disk budget exceeded ("+reason+")")
+	} else {
+		w.setDegraded(false, "disk budget kept")
+	}
+}
+
+// setDegraded switches the degraded mode, in which observations are dropped instead of written.
+// Only the switches are logged, not each dropped observation.
+func (w *obsWriter) setDegraded(degraded bool, cause string) {
+	if w.degraded == degraded {
+		return
+	}
+	w.degraded = degraded
+	if degraded {
+		w.log.Warnf("%s: dropping observations until space is available", cause)
+	} else {
+		w.log.Infof("%s: writing observations again", cause)
+	}
+}
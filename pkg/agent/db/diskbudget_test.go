@@ -0,0 +1,193 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package db
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+)
+
+type fakeDiskUsageReporter struct {
+	usedBytes   int64
+	freePercent float64
+	evicted     map[string]int
+	dropped     int
+}
+
+func (r *fakeDiskUsageReporter) ReportDiskUsage(usedBytes int64, freePercent float64) {
+	r.usedBytes = usedBytes
+	r.freePercent = freePercent
+}
+
+func (r *fakeDiskUsageReporter) IncEvictedFiles(reason string) {
+	r.evicted[reason]++
+}
+
+func (r *fakeDiskUsageReporter) IncDroppedObservations() {
+	r.dropped++
+}
+
+var _ = Describe("disk budget", func() {
+	var (
+		dir      string
+		reporter *fakeDiskUsageReporter
+	)
+
+	newWriter := func(budget DiskBudget) *obsWriter {
+		return &obsWriter{log: logrus.New(), directory: dir, prefix: "test", retentionHours: 24, budget: budget, reporter: reporter}
+	}
+
+	// createFiles creates files of 1000 bytes, the first one is the oldest
+	createFiles := func(count int) []string {
+		var filenames []string
+		now := time.Now()
+		for i := 0; i < count; i++ {
+			filename := filepath.Join(dir, fmt.Sprintf("test-%d%s", i, compressedRecordFileSuffix))
+			Expect(os.WriteFile(filename, make([]byte, 1000), 0o600)).To(Succeed())
+			modTime := now.Add(time.Duration(i-count) * time.Hour)
+			Expect(os.Chtimes(filename, modTime, modTime)).To(Succeed())
+			filenames = append(filenames, filename)
+		}
+		return filenames
+	}
+
+	// waitForHousekeeping waits until the background housekeeping started by opening or closing a file is finished
+	waitForHousekeeping := func(w *obsWriter) {
+		w.housekeepingRuns.Wait()
+	}
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		reporter = &fakeDiskUsageReporter{evicted: map[string]int{}}
+	})
+
+	It("should evict the oldest files if the maximum size is exceeded", func() {
+		files := createFiles(5)
+		w := newWriter(DiskBudget{MaxBytes: 2500})
+		w.enforceDiskBudget(files[4])
+
+		for _, f := range files[:3] {
+			Expect(f).NotTo(BeAnExistingFile())
+		}
+		for _, f := range files[3:] {
+			Expect(f).To(BeAnExistingFile())
+		}
+		Expect(reporter.evicted).To(Equal(map[string]int{EvictionReasonMaxDiskBytes: 3}))
+		Expect(reporter.usedBytes).To(Equal(int64(2000)))
+		Expect(w.degraded).To(BeFalse())
+	})
+
+	It("should not evict files within the budget", func() {
+		files := createFiles(3)
+		w := newWriter(DiskBudget{MaxBytes: 3000})
+		w.enforceDiskBudget(files[2])
+		for _, f := range files {
+			Expect(f).To(BeAnExistingFile())
+		}
+		Expect(reporter.evicted).To(BeEmpty())
+		Expect(reporter.usedBytes).To(Equal(int64(3000)))
+	})
+
+	It("should not evict the current file for free space and degrade until the budget is kept again", func() {
+		files := createFiles(3)
+		w := newWriter(DiskBudget{MinFreePercent: 100})
+		w.enforceDiskBudget(files[0])
+		Expect(files[0]).To(BeAnExistingFile())
+		Expect(files[1]).NotTo(BeAnExistingFile())
+		Expect(files[2]).NotTo(BeAnExistingFile())
+		Expect(w.degraded).To(BeTrue())
+
+		w.budget.MinFreePercent = 0
+		w.enforceDiskBudget(files[0])
+		Expect(w.degraded).To(BeFalse())
+	})
+
+	It("should evict the current file last if it alone exceeds the maximum size and start a new file", func() {
+		files := createFiles(2)
+		w := newWriter(DiskBudget{MaxBytes: 500})
+		file, err := w.getFile()
+		Expect(err).NotTo(HaveOccurred())
+		waitForHousekeeping(w)
+		_, err = file.file.Write(make([]byte, 1000))
+		Expect(err).NotTo(HaveOccurred())
+
+		w.enforceDiskBudget(w.currentFilename())
+		for _, f := range append(files, file.filename) {
+			Expect(f).NotTo(BeAnExistingFile())
+		}
+		Expect(reporter.evicted).To(Equal(map[string]int{EvictionReasonMaxDiskBytes: 3}))
+		Expect(reporter.usedBytes).To(Equal(int64(0)))
+		Expect(w.currentFilename()).To(BeEmpty())
+		Expect(w.degraded).To(BeFalse())
+
+		file2, err := w.getFile()
+		Expect(err).NotTo(HaveOccurred())
+		waitForHousekeeping(w)
+		Expect(file2.filename).To(Equal(file.filename))
+		stat, err := os.Stat(file2.filename)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stat.Size()).To(BeNumerically("<", 500))
+		Expect(file2.file.Close()).To(Succeed())
+	})
+
+	It("should close the expired current file while degraded, so that it can be evicted", func() {
+		w := newWriter(DiskBudget{MinFreePercent: 100})
+		file, err := w.getFile()
+		Expect(err).NotTo(HaveOccurred())
+		waitForHousekeeping(w)
+		w.enforceDiskBudget(w.currentFilename())
+		Expect(w.degraded).To(BeTrue())
+		Expect(file.filename).To(BeAnExistingFile())
+
+		w.closeExpiredFile(file.end.Add(-time.Second))
+		Expect(w.currentFilename()).To(Equal(file.filename))
+		w.closeExpiredFile(file.end.Add(time.Second))
+		Expect(w.currentFilename()).To(BeEmpty())
+		waitForHousekeeping(w)
+
+		w.enforceDiskBudget(w.currentFilename())
+		files, err := GetAnyRecordFiles(dir, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(files).To(BeEmpty())
+		Expect(reporter.evicted).To(Equal(map[string]int{EvictionReasonMinFreeDiskPercent: 1}))
+	})
+
+	It("should evict files if the minimum free space is not available", func() {
+		files := createFiles(2)
+		w := newWriter(DiskBudget{MinFreePercent: 100})
+		w.enforceDiskBudget(files[1])
+		Expect(files[0]).NotTo(BeAnExistingFile())
+		Expect(files[1]).To(BeAnExistingFile())
+		Expect(reporter.evicted).To(Equal(map[string]int{EvictionReasonMinFreeDiskPercent: 1}))
+		Expect(reporter.freePercent).To(BeNumerically(">=", 0))
+		Expect(w.degraded).To(BeTrue())
+	})
+
+	It("should degrade if the disk is full and reopen the current file", func() {
+		w := newWriter(DiskBudget{})
+		file, err := w.getFile()
+		Expect(err).NotTo(HaveOccurred())
+		waitForHousekeeping(w)
+
+		w.writeFailed("writeRecord", &os.PathError{Op: "write", Path: file.filename, Err: syscall.ENOSPC})
+		Expect(w.degraded).To(BeTrue())
+		Expect(reporter.dropped).To(Equal(1))
+		Expect(w.currentFilename()).To(BeEmpty())
+
+		w.enforceDiskBudget(w.currentFilename())
+		Expect(w.degraded).To(BeFalse())
+		file2, err := w.getFile()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(file2.filename).To(Equal(file.filename))
+		Expect(file2.file.Close()).To(Succeed())
+	})
+})
@@ -0,0 +1,19 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package db
+
+import (
+	"syscall"
+)
+
+// filesystemSpace returns the bytes available to unprivileged users and the total bytes of the file system of the directory.
+func filesystemSpace(directory string) (free, total uint64, err error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(directory, &stat); err != nil {
+		return 0, 0, err
+	}
+	bsize := uint64(stat.Bsize) // #nosec G115 -- block size is positive
+	return stat.Bavail * bsize, stat.Blocks * bsize, nil
+}
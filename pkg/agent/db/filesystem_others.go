@@ -0,0 +1,16 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !linux
+
+package db
+
+import (
+	"fmt"
+)
+
+// filesystemSpace is only supported on Linux.
+func filesystemSpace(_ string) (free, total uint64, err error) {
+	return 0, 0, fmt.Errorf("file system space not supported on this platform")
+}
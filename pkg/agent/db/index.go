@@ -0,0 +1,408 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package db
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gardener/network-problem-detector/pkg/common/nwpd"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// A rotated record file is compressed with a separate gzip member for each time slice, so that a reader can
+// start decompressing at any slice. The sidecar index file contains the time range, the complete StringIDMap,
+// the number of ok and failed observations per job and hosts, and the offsets of the slices.
+const (
+	// recordIndexFileSuffix is the suffix of the index file of a compressed record file.
+	recordIndexFileSuffix = recordFileSuffix + ".idx"
+	// recordIndexVersion is the version of the index file format.
+	recordIndexVersion = 1
+	// indexSliceMillis is the duration of a time slice.
+	indexSliceMillis = int64(5 * time.Minute / time.Millisecond)
+)
+
+// IsRecordIndexFile returns true if the file name has the suffix of a record index file.
+func IsRecordIndexFile(name string) bool {
+	return strings.HasSuffix(name, recordIndexFileSuffix)
+}
+
+// RecordIndexFilename returns the file name of the index of a plain or compressed record file.
+func RecordIndexFilename(filename string) string {
+	return strings.TrimSuffix(strings.TrimSuffix(filename, compressedRecordFileSuffix), recordFileSuffix) + recordIndexFileSuffix
+}
+
+type edgeKey struct {
+	jobID    int64
+	srcHost  int64
+	destHost int64
+}
+
+func edgeKeyOf(obs *nwpd.IntObservation) edgeKey {
+	return edgeKey{jobID: obs.JobID, srcHost: obs.SrcHost, destHost: obs.DestHost}
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
+
+// indexedCompressor writes the records of a plain record file as gzip members and collects the index.
+type indexedCompressor struct {
+	cw    *countingWriter
+	gw    *gzip.Writer
+	dirty bool
+
+	index    *nwpd.RecordFileIndex
+	edges    map[edgeKey]*nwpd.RecordFileIndexEdge
+	slice    *nwpd.RecordFileIndexSlice
+	sliceKey int64
+	sliceObs bool
+	hasObs   bool
+}
+
+func (c *indexedCompressor) write(p []byte) error {
+	c.dirty = true
+	_, err := c.gw.Write(p)
+	return err
+}
+
+// closeMember finishes the current gzip member, if anything has been written to it.
+func (c *indexedCompressor) closeMember() error {
+	if !c.dirty {
+		return nil
+	}
+	if err := c.gw.Close(); err != nil {
+		return err
+	}
+	c.gw.Reset(c.cw)
+	c.dirty = false
+	return nil
+}
+
+func (c *indexedCompressor) startSlice() error {
+	if err := c.closeMember(); err != nil {
+		return err
+	}
+	c.slice = &nwpd.RecordFileIndexSlice{Offset: c.cw.n}
+	c.sliceObs = false
+	c.index.Slices = append(c.index.Slices, c.slice)
+	return nil
+}
+
+func (c *indexedCompressor) addRecord(marker byte, value []byte) error {
+	var intobs *nwpd.IntObservation
+	switch marker {
+	case markerStringID:
+		raw := &nwpd.IntString{}
+		if err := proto.Unmarshal(value, raw); err != nil {
+			return fmt.Errorf("error on reading StringIDMap: %s", err)
+		}
+		c.index.Strings = append(c.index.Strings, raw)
+	case markerObservation:
+		var err error
+		intobs, err = IntObsFromBytes(value)
+		if err != nil {
+			return fmt.Errorf("error on unmarshalling: %s", err)
+		}
+	}
+
+	if c.slice == nil || intobs != nil && c.sliceObs && intobs.TimeMillis/indexSliceMillis > c.sliceKey {
+		if err := c.startSlice(); err != nil {
+			return err
+		}
+	}
+	if intobs != nil {
+		c.addObservation(intobs)
+	}
+	buf, err := encodeRecord(byte(c.index.RecordFormat), marker, value) // #nosec G115 -- format version is 1 or 2
+	if err != nil {
+		return err
+	}
+	return c.write(buf)
+}
+
+func (c *indexedCompressor) addObservation(intobs *nwpd.IntObservation) {
+	t := intobs.TimeMillis
+	if !c.sliceObs {
+		c.slice.StartMillis, c.slice.EndMillis = t, t
+		c.sliceKey = t / indexSliceMillis
+		c.sliceObs = true
+	}
+	c.slice.StartMillis = min(c.slice.StartMillis, t)
+	c.slice.EndMillis = max(c.slice.EndMillis, t)
+	if !c.hasObs {
+		c.index.StartMillis, c.index.EndMillis = t, t
+		c.hasObs = true
+	}
+	c.index.StartMillis = min(c.index.StartMillis, t)
+	c.index.EndMillis = max(c.index.EndMillis, t)
+
+	key := edgeKeyOf(intobs)
+	edge := c.edges[key]
+	if edge == nil {
+		edge = &nwpd.RecordFileIndexEdge{JobID: key.jobID, SrcHost: key.srcHost, DestHost: key.destHost}
+		c.edges[key] = edge
+		c.index.Edges = append(c.index.Edges, edge)
+	}
+	if intobs.Ok {
+		edge.OkCount++
+	} else {
+		edge.FailedCount++
+	}
+}
+
+// writeIndexedCompressed writes the valid records of a plain record file as gzip compressed file and returns its index.
+func writeIndexedCompressed(filename string, src io.Reader) (*nwpd.RecordFileIndex, error) {
+	rr, err := newRecordReader(src)
+	var corruptErr *corruptRecordError
+	if err != nil && !errors.As(err, &corruptErr) {
+		return nil, err
+	}
+
+	dest, err := os.OpenFile(filepath.Clean(filename), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o640) //  #nosec G302 -- no sensitive data
+	if err != nil {
+		return nil, err
+	}
+	defer dest.Close()
+	bw := bufio.NewWriter(dest)
+	cw := &countingWriter{w: bw}
+	c := &indexedCompressor{
+		cw:    cw,
+		gw:    gzip.NewWriter(cw),
+		index: &nwpd.RecordFileIndex{Version: recordIndexVersion, RecordFormat: int32(rr.version)},
+		edges: map[edgeKey]*nwpd.RecordFileIndexEdge{},
+	}
+	if rr.version == recordFormatV2 {
+		// the header is a member of its own, so that the slices start with a record
+		if err := c.write(recordFileHeader); err != nil {
+			return nil, err
+		}
+	}
+	for corruptErr == nil {
+		marker, value, err := rr.next()
+		if err == io.EOF || errors.As(err, &corruptErr) {
+			// records after a corrupt record cannot be read anyway
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := c.addRecord(marker, value); err != nil {
+			return nil, err
+		}
+	}
+	if err := c.closeMember(); err != nil {
+		return nil, err
+	}
+	if c.cw.n == 0 {
+		// an empty gzip file is read as empty record file
+		if err := c.write(nil); err != nil {
+			return nil, err
+		}
+		if err := c.closeMember(); err != nil {
+			return nil, err
+		}
+	}
+	if err := bw.Flush(); err != nil {
+		return nil, err
+	}
+	if err := dest.Sync(); err != nil {
+		return nil, err
+	}
+	if err := dest.Close(); err != nil {
+		return nil, err
+	}
+	sort.Slice(c.index.Edges, func(i, j int) bool {
+		a, b := c.index.Edges[i], c.index.Edges[j]
+		if a.JobID != b.JobID {
+			return a.JobID < b.JobID
+		}
+		if a.SrcHost != b.SrcHost {
+			return a.SrcHost < b.SrcHost
+		}
+		return a.DestHost < b.DestHost
+	})
+	c.index.FileSize = c.cw.n
+	return c.index, nil
+}
+
+// writeIndexFile writes the index file with the given modification time.
+func writeIndexFile(filename string, index *nwpd.RecordFileIndex, modTime time.Time) error {
+	data, err := proto.Marshal(index)
+	if err != nil {
+		return err
+	}
+	tmp := filename + tempFileSuffix
+	if err := os.WriteFile(tmp, data, 0o640); err != nil { //  #nosec G306 -- no sensitive data
+		_ = os.Remove(tmp)
+		return err
+	}
+	if err := os.Chtimes(tmp, modTime, modTime); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, filename); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// loadRecordFileIndex loads the index of a compressed record file.
+// It returns nil if there is no index or the index does not match the record file.
+func loadRecordFileIndex(filename string) *nwpd.RecordFileIndex {
+	if !strings.HasSuffix(filename, compressedRecordFileSuffix) {
+		return nil
+	}
+	stat, err := os.Stat(filename)
+	if err != nil {
+		return nil
+	}
+	data, err := os.ReadFile(filepath.Clean(RecordIndexFilename(filename)))
+	if err != nil {
+		return nil
+	}
+	index := &nwpd.RecordFileIndex{}
+	if err := proto.Unmarshal(data, index); err != nil {
+		return nil
+	}
+	if index.Version != recordIndexVersion || index.FileSize != stat.Size() ||
+		(index.RecordFormat != recordFormatV1 && index.RecordFormat != recordFormatV2) {
+		return nil
+	}
+	return index
+}
+
+// RecordFilter describes the observations of interest when iterating a record file.
+// Files and time slices without any observation of interest are skipped if the record file has an index.
+// The visitor may still be called for observations not matching the filter.
+type RecordFilter struct {
+	// Start is the start of the time range (zero means unlimited).
+	Start time.Time
+	// End is the end of the time range (zero means unlimited).
+	End time.Time
+	// Edge returns true if observations of the job between the source and destination host are of interest (nil means all).
+	Edge func(jobID, srcHost, destHost string) bool
+	// FailuresOnly is set if only failed observations are of interest.
+	FailuresOnly bool
+}
+
+func (f *RecordFilter) matchesTime(startMillis, endMillis int64) bool {
+	if !f.Start.IsZero() && endMillis < f.Start.UnixMilli() {
+		return false
+	}
+	if !f.End.IsZero() && startMillis > f.End.UnixMilli() {
+		return false
+	}
+	return true
+}
+
+// matchingEdges returns the edges of the index with observations of interest or nil if all edges are of interest.
+func (f *RecordFilter) matchingEdges(index *nwpd.RecordFileIndex, idMap *StringIDMap) (map[edgeKey]bool, error) {
+	if f.Edge == nil && !f.FailuresOnly {
+		return nil, nil
+	}
+	edges := map[edgeKey]bool{}
+	for _, edge := range index.Edges {
+		if f.FailuresOnly && edge.FailedCount == 0 {
+			continue
+		}
+		if f.Edge != nil {
+			jobID, err := idMap.GetValue(edge.JobID)
+			if err != nil {
+				return nil, err
+			}
+			srcHost, err := idMap.GetValue(edge.SrcHost)
+			if err != nil {
+				return nil, err
+			}
+			destHost, err := idMap.GetValue(edge.DestHost)
+			if err != nil {
+				return nil, err
+			}
+			if !f.Edge(jobID, srcHost, destHost) {
+				continue
+			}
+		}
+		edges[edgeKey{jobID: edge.JobID, srcHost: edge.SrcHost, destHost: edge.DestHost}] = true
+	}
+	return edges, nil
+}
+
+// IterateRecordFileFiltered visits the observations of a plain or compressed record file like IterateRecordFile.
+// If the record file has an index, only the time slices with observations of interest are read.
+func IterateRecordFileFiltered(filename string, filter RecordFilter, visitor ObservationVisitor) error {
+	index := loadRecordFileIndex(filename)
+	if index == nil {
+		return IterateRecordFile(filename, visitor)
+	}
+	if !filter.matchesTime(index.StartMillis, index.EndMillis) {
+		return nil
+	}
+	data := make([]*IntString, 0, len(index.Strings))
+	for _, s := range index.Strings {
+		data = append(data, NewVarint2String(s.Key, s.Value))
+	}
+	idMap := NewStringIDMapFromData(data)
+	edges, err := filter.matchingEdges(index, idMap)
+	if err != nil {
+		return fmt.Errorf("invalid index of %s: %s", filename, err)
+	}
+	if edges != nil && len(edges) == 0 {
+		return nil
+	}
+	accept := func(obs *nwpd.IntObservation) bool {
+		return filter.matchesTime(obs.TimeMillis, obs.TimeMillis) &&
+			(!filter.FailuresOnly || !obs.Ok) &&
+			(edges == nil || edges[edgeKeyOf(obs)])
+	}
+
+	f, err := os.Open(filepath.Clean(filename))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	br := bufio.NewReader(f)
+	var gr *gzip.Reader
+	for _, slice := range index.Slices {
+		if !filter.matchesTime(slice.StartMillis, slice.EndMillis) {
+			continue
+		}
+		if _, err := f.Seek(slice.Offset, io.SeekStart); err != nil {
+			return err
+		}
+		br.Reset(f)
+		if gr == nil {
+			gr, err = gzip.NewReader(br)
+		} else {
+			err = gr.Reset(br)
+		}
+		if err != nil {
+			return fmt.Errorf("reading slice at offset %d failed: %w", slice.Offset, err)
+		}
+		gr.Multistream(false)
+		rr := &recordReader{r: bufio.NewReader(gr), version: byte(index.RecordFormat)} // #nosec G115 -- checked on loading
+		if err := visitRecords(rr, idMap, true, accept, visitor); err != nil {
+			return err
+		}
+	}
+	return nil
+}
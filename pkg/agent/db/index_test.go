@@ -0,0 +1,258 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package db
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gardener/network-problem-detector/pkg/common/nwpd"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// newSpreadObservations creates observations of all jobs and destinations every step starting at start.
+func newSpreadObservations(start time.Time, duration, step time.Duration, jobs, dests int) []*nwpd.Observation {
+	var observations []*nwpd.Observation
+	i := 0
+	for t := start; t.Before(start.Add(duration)); t = t.Add(step) {
+		for j := 0; j < jobs; j++ {
+			for d := 0; d < dests; d++ {
+				observations = append(observations, &nwpd.Observation{
+					JobID:     fmt.Sprintf("job-%d", j),
+					SrcHost:   "node-a",
+					DestHost:  fmt.Sprintf("node-%d", d),
+					Timestamp: timestamppb.New(t),
+					Ok:        i%50 != 0,
+				})
+				i++
+			}
+		}
+	}
+	return observations
+}
+
+func readFilteredTestRecordFile(filename string, filter RecordFilter) []*nwpd.Observation {
+	var observations []*nwpd.Observation
+	Expect(IterateRecordFileFiltered(filename, filter, func(obs *nwpd.Observation) error {
+		observations = append(observations, obs)
+		return nil
+	})).To(Succeed())
+	return observations
+}
+
+func selectObservations(observations []*nwpd.Observation, filter RecordFilter) []*nwpd.Observation {
+	var result []*nwpd.Observation
+	for _, obs := range observations {
+		t := obs.Timestamp.AsTime()
+		if t.Before(filter.Start) || !filter.End.IsZero() && t.After(filter.End) || filter.FailuresOnly && obs.Ok ||
+			filter.Edge != nil && !filter.Edge(obs.JobID, obs.SrcHost, obs.DestHost) {
+			continue
+		}
+		result = append(result, obs)
+	}
+	return result
+}
+
+var _ = Describe("record file index", func() {
+	var (
+		dir          string
+		filename     string
+		compressed   string
+		start        time.Time
+		observations []*nwpd.Observation
+	)
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		start = time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+		filename = filepath.Join(dir, "test-2022-06-01-10"+recordFileSuffix)
+		compressed = filepath.Join(dir, "test-2022-06-01-10"+compressedRecordFileSuffix)
+		observations = newSpreadObservations(start, time.Hour, 30*time.Second, 2, 3)
+	})
+
+	DescribeTable("should write the index on compression",
+		func(version byte) {
+			writeTestRecordFile(filename, version, observations)
+			Expect(compressFile(filename)).To(Succeed())
+
+			index := loadRecordFileIndex(compressed)
+			Expect(index).NotTo(BeNil())
+			Expect(index.RecordFormat).To(Equal(int32(version)))
+			Expect(index.StartMillis).To(Equal(start.UnixMilli()))
+			Expect(index.EndMillis).To(Equal(start.Add(time.Hour - 30*time.Second).UnixMilli()))
+			Expect(index.Strings).To(HaveLen(6))
+			Expect(index.Slices).To(HaveLen(12))
+			Expect(index.Edges).To(HaveLen(6))
+			total := 0
+			for _, edge := range index.Edges {
+				total += int(edge.OkCount + edge.FailedCount)
+			}
+			Expect(total).To(Equal(len(observations)))
+			expectObservations(readTestRecordFile(compressed), observations)
+		},
+		Entry("version 1", byte(recordFormatV1)),
+		Entry("version 2", byte(recordFormatV2)),
+	)
+
+	DescribeTable("should only visit the observations of interest",
+		func(filter RecordFilter, skipped bool) {
+			writeTestRecordFile(filename, recordFormatV2, observations)
+			Expect(compressFile(filename)).To(Succeed())
+
+			visited := readFilteredTestRecordFile(compressed, filter)
+			expectObservations(selectObservations(visited, filter), selectObservations(observations, filter))
+			if skipped {
+				Expect(len(visited)).To(BeNumerically("<", len(observations)))
+			}
+		},
+		Entry("all", RecordFilter{}, false),
+		Entry("time window", RecordFilter{Start: time.Date(2022, 6, 1, 10, 12, 0, 0, time.UTC), End: time.Date(2022, 6, 1, 10, 21, 0, 0, time.UTC)}, true),
+		Entry("edge", RecordFilter{Edge: func(jobID, _, destHost string) bool { return jobID == "job-1" && destHost == "node-2" }}, true),
+		Entry("failures", RecordFilter{FailuresOnly: true}, true),
+	)
+
+	It("should skip files without observations of interest", func() {
+		writeTestRecordFile(filename, recordFormatV2, observations)
+		Expect(compressFile(filename)).To(Succeed())
+
+		Expect(readFilteredTestRecordFile(compressed, RecordFilter{Start: start.Add(2 * time.Hour)})).To(BeEmpty())
+		Expect(readFilteredTestRecordFile(compressed, RecordFilter{End: start.Add(-time.Second)})).To(BeEmpty())
+		Expect(readFilteredTestRecordFile(compressed, RecordFilter{Edge: func(jobID, _, _ string) bool { return jobID == "job-9" }})).To(BeEmpty())
+	})
+
+	It("should ignore an outdated index", func() {
+		writeTestRecordFile(filename, recordFormatV2, observations)
+		Expect(compressFile(filename)).To(Succeed())
+		data, err := os.ReadFile(compressed)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.WriteFile(compressed, data[:len(data)/2], 0o600)).To(Succeed())
+
+		Expect(loadRecordFileIndex(compressed)).To(BeNil())
+		read := readFilteredTestRecordFile(compressed, RecordFilter{Start: start.Add(50 * time.Minute)})
+		Expect(len(read)).To(BeNumerically(">", 0))
+		expectObservations(read, observations[:len(read)])
+	})
+
+	It("should keep the modification time and evict the index together with the record file", func() {
+		writeTestRecordFile(filename, recordFormatV2, observations)
+		modTime := time.Now().Add(-30 * time.Minute).Truncate(time.Second)
+		Expect(os.Chtimes(filename, modTime, modTime)).To(Succeed())
+		Expect(compressFile(filename)).To(Succeed())
+		stat, err := os.Stat(RecordIndexFilename(compressed))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stat.ModTime()).To(Equal(modTime))
+
+		w := &obsWriter{log: logrus.New(), directory: dir, prefix: "test", budget: DiskBudget{MaxBytes: 1}, reporter: noopDiskUsageReporter{}}
+		files, _, err := w.listFilesByAge()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(files).To(HaveLen(1))
+		Expect(files[0].index).To(Equal(RecordIndexFilename(compressed)))
+
+		w.enforceDiskBudget("")
+		Expect(compressed).NotTo(BeAnExistingFile())
+		Expect(RecordIndexFilename(compressed)).NotTo(BeAnExistingFile())
+	})
+})
+
+// writeBenchmarkDataset writes 24 compressed and indexed hourly record files with 4 jobs and 25 destinations
+// checked every 15 seconds (576000 observations) and returns the file names.
+func writeBenchmarkDataset(b *testing.B, start time.Time) []string {
+	dir := b.TempDir()
+	var files []string
+	for hour := 0; hour < 24; hour++ {
+		t := start.Add(time.Duration(hour) * time.Hour)
+		filename := filepath.Join(dir, fmt.Sprintf("bench-%s%s", t.Format("2006-01-02-15"), recordFileSuffix))
+		if err := writeBenchmarkRecordFile(filename, newSpreadObservations(t, time.Hour, 15*time.Second, 4, 25)); err != nil {
+			b.Fatal(err)
+		}
+		if err := compressFile(filename); err != nil {
+			b.Fatal(err)
+		}
+		files = append(files, filepath.Join(dir, fmt.Sprintf("bench-%s%s", t.Format("2006-01-02-15"), compressedRecordFileSuffix)))
+	}
+	return files
+}
+
+func writeBenchmarkRecordFile(filename string, observations []*nwpd.Observation) error {
+	f, err := os.Create(filepath.Clean(filename))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err := f.Write(recordFileHeader); err != nil {
+		return err
+	}
+	wf := &writeFile{filename: filename, file: f, version: recordFormatV2, idMap: NewStringIDMap()}
+	for _, obs := range observations {
+		intobs, err := ToIntObservation(obs, wf.idMap, wf)
+		if err != nil {
+			return err
+		}
+		value, err := IntObsToBytes(intobs)
+		if err != nil {
+			return err
+		}
+		if err := writeRecord(f, wf.version, markerObservation, value); err != nil {
+			return err
+		}
+	}
+	return f.Close()
+}
+
+// BenchmarkQuery compares reading all observations of a synthetic 24h dataset with reading only the slices
+// selected by the index for some typical queries.
+func BenchmarkQuery(b *testing.B) {
+	start := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+	files := writeBenchmarkDataset(b, start)
+
+	queries := []struct {
+		name   string
+		filter RecordFilter
+	}{
+		{"one-edge-24h", RecordFilter{Edge: func(jobID, _, destHost string) bool { return jobID == "job-1" && destHost == "node-7" }}},
+		{"all-edges-10m", RecordFilter{Start: start.Add(14 * time.Hour), End: start.Add(14*time.Hour + 10*time.Minute)}},
+		{"one-job-1h", RecordFilter{Start: start.Add(20 * time.Hour), End: start.Add(21 * time.Hour), Edge: func(jobID, _, _ string) bool { return jobID == "job-2" }}},
+		{"failures-24h", RecordFilter{FailuresOnly: true}},
+	}
+	for _, q := range queries {
+		visitor := func(count *int) ObservationVisitor {
+			return func(obs *nwpd.Observation) error {
+				if len(selectObservations([]*nwpd.Observation{obs}, q.filter)) > 0 {
+					*count++
+				}
+				return nil
+			}
+		}
+		b.Run(q.name+"/full-scan", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				count := 0
+				for _, file := range files {
+					if err := IterateRecordFile(file, visitor(&count)); err != nil {
+						b.Fatal(err)
+					}
+				}
+				b.ReportMetric(float64(count), "observations")
+			}
+		})
+		b.Run(q.name+"/index", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				count := 0
+				for _, file := range files {
+					if err := IterateRecordFileFiltered(file, q.filter, visitor(&count)); err != nil {
+						b.Fatal(err)
+					}
+				}
+				b.ReportMetric(float64(count), "observations")
+			}
+		})
+	}
+}
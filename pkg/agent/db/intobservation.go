@@ -35,9 +35,95 @@ func ToIntObservation(obs *nwpd.Observation, idMap *StringIDMap, persistor IntSt
 		TimeMillis:     obs.Timestamp.AsTime().UnixMilli(),
 		DurationMillis: int32(obs.Duration.AsDuration().Milliseconds()), // #nosec G115 - always in second range
 		PeriodMillis:   int32(obs.Period.AsDuration().Milliseconds()),   // #nosec G115 - always in second range
+		PhaseTimings:   toIntPhaseTimings(obs.PhaseTimings),
+		Attempts:       toIntAttempts(obs.Attempts),
+		ExpectBlocked:  obs.ExpectBlocked,
+		FailureClass:   obs.FailureClass,
+		PingStatistics: toIntPingStatistics(obs.PingStatistics),
 	}, nil
 }
 
+// toIntAttempts only persists the number of attempts of retried checks.
+func toIntAttempts(attempts int32) int32 {
+	if attempts <= 1 {
+		return 0
+	}
+	return attempts
+}
+
+func toIntPhaseTimings(t *nwpd.PhaseTimings) *nwpd.IntPhaseTimings {
+	if t == nil {
+		return nil
+	}
+	return &nwpd.IntPhaseTimings{
+		DnsMicros:     toMicros(t.Dns),
+		ConnectMicros: toMicros(t.Connect),
+		TlsMicros:     toMicros(t.Tls),
+		TtfbMicros:    toMicros(t.Ttfb),
+	}
+}
+
+// toMicros converts a duration to microseconds. A measured phase is stored with at least 1 microsecond to distinguish it from a missing phase.
+func toMicros(d *durationpb.Duration) int32 {
+	if d == nil {
+		return 0
+	}
+	return int32(max(1, d.AsDuration().Microseconds())) // #nosec G115 - always in second range
+}
+
+func fromIntPhaseTimings(t *nwpd.IntPhaseTimings) *nwpd.PhaseTimings {
+	if t == nil {
+		return nil
+	}
+	return &nwpd.PhaseTimings{
+		Dns:     fromMicros(t.DnsMicros),
+		Connect: fromMicros(t.ConnectMicros),
+		Tls:     fromMicros(t.TlsMicros),
+		Ttfb:    fromMicros(t.TtfbMicros),
+	}
+}
+
+func toIntPingStatistics(s *nwpd.PingStatistics) *nwpd.IntPingStatistics {
+	if s == nil {
+		return nil
+	}
+	return &nwpd.IntPingStatistics{
+		PacketsSent:   s.PacketsSent,
+		PacketsRecv:   s.PacketsRecv,
+		MinRttMicros:  toMicros(s.MinRtt),
+		AvgRttMicros:  toMicros(s.AvgRtt),
+		MaxRttMicros:  toMicros(s.MaxRtt),
+		MdevRttMicros: toMicros(s.MdevRtt),
+	}
+}
+
+// fromIntPingStatistics restores the ping statistics. The packet loss is calculated from the packet counts.
+func fromIntPingStatistics(s *nwpd.IntPingStatistics) *nwpd.PingStatistics {
+	if s == nil {
+		return nil
+	}
+	var loss float64
+	if s.PacketsSent > 0 {
+		loss = float64(s.PacketsSent-s.PacketsRecv) * 100 / float64(s.PacketsSent)
+	}
+	return &nwpd.PingStatistics{
+		PacketsSent: s.PacketsSent,
+		PacketsRecv: s.PacketsRecv,
+		LossPercent: loss,
+		MinRtt:      fromMicros(s.MinRttMicros),
+		AvgRtt:      fromMicros(s.AvgRttMicros),
+		MaxRtt:      fromMicros(s.MaxRttMicros),
+		MdevRtt:     fromMicros(s.MdevRttMicros),
+	}
+}
+
+func fromMicros(micros int32) *durationpb.Duration {
+	if micros <= 0 {
+		return nil
+	}
+	return durationpb.New(time.Microsecond * time.Duration(micros))
+}
+
 func IntObsToObservation(o *nwpd.IntObservation, idMap *StringIDMap) (*nwpd.Observation, error) {
 	ss, err := idMap.GetValue(o.SrcHost)
 	if err != nil {
@@ -59,13 +145,18 @@ func IntObsToObservation(o *nwpd.IntObservation, idMap *StringIDMap) (*nwpd.Obse
 		period = durationpb.New(time.Millisecond * time.Duration(o.PeriodMillis))
 	}
 	return &nwpd.Observation{
-		JobID:     sj,
-		SrcHost:   ss,
-		DestHost:  sd,
-		Timestamp: timestamppb.New(time.UnixMilli(o.TimeMillis)),
-		Duration:  duration,
-		Ok:        o.Ok,
-		Period:    period,
+		JobID:          sj,
+		SrcHost:        ss,
+		DestHost:       sd,
+		Timestamp:      timestamppb.New(time.UnixMilli(o.TimeMillis)),
+		Duration:       duration,
+		Ok:             o.Ok,
+		Period:         period,
+		PhaseTimings:   fromIntPhaseTimings(o.PhaseTimings),
+		Attempts:       o.Attempts,
+		ExpectBlocked:  o.ExpectBlocked,
+		FailureClass:   o.FailureClass,
+		PingStatistics: fromIntPingStatistics(o.PingStatistics),
 	}, nil
 }
 
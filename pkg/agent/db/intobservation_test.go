@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package db
+
+import (
+	"time"
+
+	"github.com/gardener/network-problem-detector/pkg/common/nwpd"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var _ = Describe("int observation", func() {
+	DescribeTable("should persist ping statistics",
+		func(stats *nwpd.PingStatistics) {
+			obs := &nwpd.Observation{
+				JobID:          "ping-n2n",
+				SrcHost:        "node-a",
+				DestHost:       "node-b",
+				Timestamp:      timestamppb.Now(),
+				PingStatistics: stats,
+			}
+			idMap := NewStringIDMap()
+			intobs, err := ToIntObservation(obs, idMap, nil)
+			Expect(err).NotTo(HaveOccurred())
+			value, err := IntObsToBytes(intobs)
+			Expect(err).NotTo(HaveOccurred())
+			intobs, err = IntObsFromBytes(value)
+			Expect(err).NotTo(HaveOccurred())
+			actual, err := IntObsToObservation(intobs, idMap)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual.PingStatistics).To(Equal(stats))
+		},
+		Entry("none", nil),
+		Entry("all lost", &nwpd.PingStatistics{PacketsSent: 3, LossPercent: 100}),
+		Entry("partially lost", &nwpd.PingStatistics{
+			PacketsSent: 4,
+			PacketsRecv: 3,
+			LossPercent: 25,
+			MinRtt:      durationpb.New(310 * time.Microsecond),
+			AvgRtt:      durationpb.New(420 * time.Microsecond),
+			MaxRtt:      durationpb.New(1200 * time.Microsecond),
+			MdevRtt:     durationpb.New(55 * time.Microsecond),
+		}),
+	)
+})
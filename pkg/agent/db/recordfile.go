@@ -0,0 +1,186 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package db
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+)
+
+// Record files are sequences of records, each consisting of a marker byte and a payload.
+//
+// Version 1 files have no header. Each record is stored as marker, payload length as little-endian uint16, and payload.
+//
+// Version 2 files start with the header `NWPD` followed by the version byte. Each record is stored as marker,
+// payload length as unsigned varint, payload, and the CRC-32C checksum of marker and payload as little-endian uint32.
+const (
+	recordFormatV1 = 1
+	recordFormatV2 = 2
+
+	// maxRecordSize is the maximum payload size of a version 2 record. Larger lengths are considered as corruption.
+	maxRecordSize = 16 << 20
+)
+
+var (
+	recordFileMagic  = []byte("NWPD")
+	recordFileHeader = append(append([]byte{}, recordFileMagic...), recordFormatV2)
+	crcTable         = crc32.MakeTable(crc32.Castagnoli)
+)
+
+// corruptRecordError reports a corrupt or truncated record. All records before the offset are valid.
+type corruptRecordError struct {
+	offset int64
+	reason string
+}
+
+func (e *corruptRecordError) Error() string {
+	return fmt.Sprintf("corrupt record at offset %d: %s", e.offset, e.reason)
+}
+
+// encodeRecord encodes a record in the given format version.
+func encodeRecord(version byte, marker byte, value []byte) ([]byte, error) {
+	switch version {
+	case recordFormatV1:
+		if len(value) > 0xffff {
+			return nil, fmt.Errorf("record of %d bytes too large for format version 1", len(value))
+		}
+		buf := make([]byte, 3, 3+len(value))
+		buf[0] = marker
+		binary.LittleEndian.PutUint16(buf[1:], uint16(len(value))) // #nosec G115 -- checked above
+		return append(buf, value...), nil
+	case recordFormatV2:
+		if len(value) > maxRecordSize {
+			return nil, fmt.Errorf("record of %d bytes too large", len(value))
+		}
+		buf := make([]byte, 1, 1+binary.MaxVarintLen64+len(value)+4)
+		buf[0] = marker
+		buf = binary.AppendUvarint(buf, uint64(len(value)))
+		buf = append(buf, value...)
+		crc := crc32.Update(crc32.Checksum([]byte{marker}, crcTable), crcTable, value)
+		return binary.LittleEndian.AppendUint32(buf, crc), nil
+	default:
+		return nil, fmt.Errorf("unsupported record format version %d", version)
+	}
+}
+
+// writeRecord writes a record with a single write call, so that a record is either written completely or truncated.
+func writeRecord(w io.Writer, version byte, marker byte, value []byte) error {
+	buf, err := encodeRecord(version, marker, value)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(buf)
+	return err
+}
+
+// recordReader reads the records of a version 1 or 2 record file.
+type recordReader struct {
+	r       *bufio.Reader
+	version byte
+	// offset is the end of the last valid record
+	offset int64
+}
+
+// newRecordReader detects the format version by the file header. Files without header are read as version 1.
+// An empty file is reported as version 2.
+func newRecordReader(r io.Reader) (*recordReader, error) {
+	rr := &recordReader{r: bufio.NewReader(r), version: recordFormatV1}
+	header, err := rr.r.Peek(len(recordFileHeader))
+	switch {
+	case len(header) == 0 && err == io.EOF:
+		rr.version = recordFormatV2
+		return rr, nil
+	case err != nil && err != io.EOF:
+		return rr, rr.readError(err)
+	case header[0] != recordFileMagic[0]:
+		// version 1 files start with a marker
+		return rr, nil
+	case len(header) < len(recordFileHeader) || !bytes.HasPrefix(header, recordFileMagic):
+		rr.version = recordFormatV2
+		return rr, rr.corrupt("invalid header")
+	case header[len(recordFileMagic)] != recordFormatV2:
+		return nil, fmt.Errorf("unsupported record format version %d", header[len(recordFileMagic)])
+	default:
+		rr.version = recordFormatV2
+		_, _ = rr.r.Discard(len(recordFileHeader))
+		rr.offset = int64(len(recordFileHeader))
+		return rr, nil
+	}
+}
+
+// next returns the next record. It returns io.EOF after the last record and a *corruptRecordError
+// if the next record is corrupt or truncated.
+func (rr *recordReader) next() (byte, []byte, error) {
+	marker, err := rr.r.ReadByte()
+	if err == io.EOF {
+		return 0, nil, io.EOF
+	} else if err != nil {
+		// a truncated compressed file can end with an unexpected EOF between two records
+		return 0, nil, rr.readError(err)
+	}
+	switch marker {
+	case markerStringID, markerObservation, markerOpen:
+	default:
+		return 0, nil, rr.corrupt(fmt.Sprintf("invalid marker %d", marker))
+	}
+
+	var length, size int
+	if rr.version == recordFormatV1 {
+		var buf [2]byte
+		if _, err := io.ReadFull(rr.r, buf[:]); err != nil {
+			return 0, nil, rr.readError(err)
+		}
+		length = int(binary.LittleEndian.Uint16(buf[:]))
+		size = 1 + 2 + length
+	} else {
+		l, err := binary.ReadUvarint(rr.r)
+		if err != nil {
+			return 0, nil, rr.readError(err)
+		}
+		if l > maxRecordSize {
+			return 0, nil, rr.corrupt(fmt.Sprintf("invalid length %d", l))
+		}
+		length = int(l)
+		size = 1 + uvarintLen(l) + length + 4
+	}
+
+	value := make([]byte, length)
+	if _, err := io.ReadFull(rr.r, value); err != nil {
+		return 0, nil, rr.readError(err)
+	}
+	if rr.version == recordFormatV2 {
+		var buf [4]byte
+		if _, err := io.ReadFull(rr.r, buf[:]); err != nil {
+			return 0, nil, rr.readError(err)
+		}
+		crc := crc32.Update(crc32.Checksum([]byte{marker}, crcTable), crcTable, value)
+		if binary.LittleEndian.Uint32(buf[:]) != crc {
+			return 0, nil, rr.corrupt("checksum mismatch")
+		}
+	}
+	rr.offset += int64(size)
+	return marker, value, nil
+}
+
+func (rr *recordReader) corrupt(reason string) error {
+	return &corruptRecordError{offset: rr.offset, reason: reason}
+}
+
+func (rr *recordReader) readError(err error) error {
+	if err == io.EOF || errors.Is(err, io.ErrUnexpectedEOF) {
+		return rr.corrupt("truncated")
+	}
+	return err
+}
+
+func uvarintLen(x uint64) int {
+	var buf [binary.MaxVarintLen64]byte
+	return binary.PutUvarint(buf[:], x)
+}
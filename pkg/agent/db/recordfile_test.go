@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package db
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gardener/network-problem-detector/pkg/common/nwpd"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func newTestObservations(count int) []*nwpd.Observation {
+	var observations []*nwpd.Observation
+	start := time.Now().Add(-time.Minute).Truncate(time.Millisecond)
+	for i := 0; i < count; i++ {
+		observations = append(observations, &nwpd.Observation{
+			JobID:     "tcp-n2n",
+			SrcHost:   "node-a",
+			DestHost:  fmt.Sprintf("node-%d", i%3),
+			Timestamp: timestamppb.New(start.Add(time.Duration(i) * time.Second)),
+			Duration:  durationpb.New(time.Duration(i+1) * time.Millisecond),
+			Ok:        i%2 == 0,
+		})
+	}
+	return observations
+}
+
+func writeTestRecordFile(filename string, version byte, observations []*nwpd.Observation) {
+	f, err := os.Create(filename)
+	Expect(err).NotTo(HaveOccurred())
+	defer f.Close()
+	if version == recordFormatV2 {
+		_, err = f.Write(recordFileHeader)
+		Expect(err).NotTo(HaveOccurred())
+	}
+	wf := &writeFile{filename: filename, file: f, version: version, idMap: NewStringIDMap()}
+	Expect(writeRecord(f, version, markerOpen, []byte("12:00:00"))).To(Succeed())
+	for _, obs := range observations {
+		intobs, err := ToIntObservation(obs, wf.idMap, wf)
+		Expect(err).NotTo(HaveOccurred())
+		value, err := IntObsToBytes(intobs)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(writeRecord(f, version, markerObservation, value)).To(Succeed())
+	}
+}
+
+func readTestRecordFile(filename string) []*nwpd.Observation {
+	var observations []*nwpd.Observation
+	Expect(IterateRecordFile(filename, func(obs *nwpd.Observation) error {
+		observations = append(observations, obs)
+		return nil
+	})).To(Succeed())
+	return observations
+}
+
+func expectObservations(actual, expected []*nwpd.Observation) {
+	Expect(actual).To(HaveLen(len(expected)))
+	for i := range expected {
+		Expect(actual[i].DestHost).To(Equal(expected[i].DestHost))
+		Expect(actual[i].Timestamp.AsTime()).To(Equal(expected[i].Timestamp.AsTime()))
+		Expect(actual[i].Ok).To(Equal(expected[i].Ok))
+	}
+}
+
+var _ = Describe("record file", func() {
+	var dir string
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+	})
+
+	DescribeTable("should read all records",
+		func(version byte) {
+			filename := filepath.Join(dir, "test.records")
+			observations := newTestObservations(10)
+			writeTestRecordFile(filename, version, observations)
+			expectObservations(readTestRecordFile(filename), observations)
+		},
+		Entry("format version 1", byte(recordFormatV1)),
+		Entry("format version 2", byte(recordFormatV2)),
+	)
+
+	It("should read records larger than 64 KB in format version 2", func() {
+		value := bytes.Repeat([]byte{42}, 100000)
+		_, err := encodeRecord(recordFormatV1, markerObservation, value)
+		Expect(err).To(HaveOccurred())
+
+		buf := &bytes.Buffer{}
+		buf.Write(recordFileHeader)
+		Expect(writeRecord(buf, recordFormatV2, markerObservation, value)).To(Succeed())
+		rr, err := newRecordReader(buf)
+		Expect(err).NotTo(HaveOccurred())
+		marker, read, err := rr.next()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(marker).To(Equal(byte(markerObservation)))
+		Expect(read).To(Equal(value))
+		_, _, err = rr.next()
+		Expect(err).To(Equal(io.EOF))
+	})
+
+	DescribeTable("should keep the valid records before the first corrupt record",
+		func(version byte, corrupt func(data []byte) []byte) {
+			filename := filepath.Join(dir, "test.records")
+			observations := newTestObservations(5)
+			writeTestRecordFile(filename, version, observations)
+			data, err := os.ReadFile(filename)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(os.WriteFile(filename, corrupt(data), 0o600)).To(Succeed())
+			expectObservations(readTestRecordFile(filename), observations[:4])
+		},
+		Entry("truncated record in format version 1", byte(recordFormatV1), func(data []byte) []byte { return data[:len(data)-3] }),
+		Entry("truncated record in format version 2", byte(recordFormatV2), func(data []byte) []byte { return data[:len(data)-3] }),
+		Entry("checksum mismatch", byte(recordFormatV2), func(data []byte) []byte {
+			data[len(data)-6] ^= 0xff
+			return data
+		}),
+	)
+
+	DescribeTable("should truncate a corrupt file and continue it in its format",
+		func(version byte) {
+			w := &obsWriter{log: logrus.New(), directory: dir, prefix: "test", retentionHours: 24}
+			filename := fmt.Sprintf("%s/test-%s.records", dir, startOfHourUTC(time.Now()).Format("2006-01-02-15"))
+			observations := newTestObservations(4)
+			writeTestRecordFile(filename, version, observations[:3])
+			f, err := os.OpenFile(filename, os.O_APPEND|os.O_WRONLY, 0o600)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = f.Write([]byte{markerObservation, 0xff})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(f.Close()).To(Succeed())
+
+			file, err := w.getFile()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(file.version).To(Equal(version))
+			intobs, err := ToIntObservation(observations[3], file.idMap, file)
+			Expect(err).NotTo(HaveOccurred())
+			value, err := IntObsToBytes(intobs)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(writeRecord(file.file, file.version, markerObservation, value)).To(Succeed())
+			Expect(file.file.Close()).To(Succeed())
+
+			expectObservations(readTestRecordFile(filename), observations)
+		},
+		Entry("format version 1", byte(recordFormatV1)),
+		Entry("format version 2", byte(recordFormatV2)),
+	)
+
+	It("should start new files with the header", func() {
+		w := &obsWriter{log: logrus.New(), directory: dir, prefix: "test", retentionHours: 24}
+		file, err := w.getFile()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(file.version).To(Equal(byte(recordFormatV2)))
+		Expect(file.file.Close()).To(Succeed())
+		data, err := os.ReadFile(file.filename)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(HavePrefix(string(recordFileHeader)))
+	})
+})
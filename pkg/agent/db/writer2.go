@@ -5,7 +5,7 @@
 package db
 
 import (
-	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -13,7 +13,9 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
 	"sync/atomic"
+	"syscall"
 	"time"
 
 	"github.com/gardener/network-problem-detector/pkg/common"
@@ -28,10 +30,18 @@ type obsWriter struct {
 	directory      string
 	prefix         string
 	retentionHours int
+	budget         DiskBudget
+	reporter       DiskUsageReporter
 	currentFile    atomic.Value
-	obsChan        chan *nwpd.Observation
-	done           chan struct{}
-	ticker         *time.Ticker
+	housekeeping   sync.Mutex
+	// housekeepingRuns tracks the running background housekeeping
+	housekeepingRuns sync.WaitGroup
+	obsChan          chan *nwpd.Observation
+	done             chan struct{}
+	ticker           *time.Ticker
+	diskTicker       *time.Ticker
+	// degraded is set if observations are dropped because the disk budget is exceeded or the disk is full
+	degraded bool
 }
 
 var _ nwpd.ObservationWriter = &obsWriter{}
@@ -46,6 +56,7 @@ type writeFile struct {
 	filename string
 	end      time.Time
 	file     *os.File
+	version  byte
 	idMap    *StringIDMap
 }
 
@@ -60,24 +71,31 @@ func (wf *writeFile) Persist(obj *IntString) error {
 	if err != nil {
 		return err
 	}
-	return writeRecord(wf.file, markerStringID, bytes)
+	return writeRecord(wf.file, wf.version, markerStringID, bytes)
 }
 
 var _ nwpd.ObservationWriter = &obsWriter{}
 
-func NewObsWriter(log logrus.FieldLogger, directory, prefix string, retentionHours int) (nwpd.ObservationWriter, error) {
+// NewObsWriter creates a writer for hourly record files. The reporter is optional.
+func NewObsWriter(log logrus.FieldLogger, directory, prefix string, retentionHours int, budget DiskBudget, reporter DiskUsageReporter) (nwpd.ObservationWriter, error) {
 	err := os.MkdirAll(directory, 0o750) //  #nosec G302 -- no sensitive data
 	if err != nil {
 		return nil, err
 	}
+	if reporter == nil {
+		reporter = noopDiskUsageReporter{}
+	}
 	writer := &obsWriter{
 		log:            log,
 		directory:      directory,
 		prefix:         prefix,
 		retentionHours: retentionHours,
+		budget:         budget,
+		reporter:       reporter,
 		obsChan:        make(chan *nwpd.Observation, 100),
 		done:           make(chan struct{}),
 		ticker:         time.NewTicker(5 * time.Second),
+		diskTicker:     time.NewTicker(diskCheckPeriod),
 	}
 
 	return writer, nil
@@ -92,19 +110,28 @@ func (w *obsWriter) Stop() {
 		w.ticker.Stop()
 		w.ticker = nil
 	}
+	w.diskTicker.Stop()
 	w.done <- struct{}{}
-	file := w.currentFile.Load().(*writeFile)
-	if file != nil {
+	if file, ok := w.currentFile.Load().(*writeFile); ok && file != nil {
 		_ = file.file.Close()
 	}
+	w.housekeepingRuns.Wait()
 }
 
 func (w *obsWriter) Run() {
+	w.enforceDiskBudget(w.currentFilename())
 	for {
 		select {
 		case <-w.done:
 			return
+		case <-w.diskTicker.C:
+			w.enforceDiskBudget(w.currentFilename())
 		case <-w.ticker.C:
+			if w.degraded {
+				// keep rotating, so that the file of the previous hour can be compressed and evicted
+				w.closeExpiredFile(time.Now().UTC())
+				continue
+			}
 			file, err := w.getFile()
 			if err != nil {
 				w.log.Warnf("sync failed: getFile: %s", err)
@@ -116,14 +143,18 @@ func (w *obsWriter) Run() {
 				continue
 			}
 		case obs := <-w.obsChan:
+			if w.degraded {
+				w.reporter.IncDroppedObservations()
+				continue
+			}
 			file, err := w.getFile()
 			if err != nil {
-				w.log.Warnf("write failed: getFile: %s", err)
+				w.writeFailed("getFile", err)
 				continue
 			}
 			intobs, err := ToIntObservation(obs, file.idMap, file)
 			if err != nil {
-				w.log.Warnf("write failed: ToIntObservation: %s", err)
+				w.writeFailed("ToIntObservation", err)
 				continue
 			}
 			value, err := IntObsToBytes(intobs)
@@ -131,89 +162,94 @@ func (w *obsWriter) Run() {
 				w.log.Warnf("write failed: IntObsToBytes: %s", err)
 				continue
 			}
-			if err := writeRecord(file.file, markerObservation, value); err != nil {
-				w.log.Warnf("write failed: %s", err)
+			if err := writeRecord(file.file, file.version, markerObservation, value); err != nil {
+				w.writeFailed("writeRecord", err)
 				continue
 			}
 		}
 	}
 }
 
-func writeRecord(w io.Writer, marker byte, value []byte) error {
-	if _, err := w.Write([]byte{marker}); err != nil {
-		return err
-	}
-
-	length := uint16(len(value)) // #nosec G115 - records are always in range
-	if err := binary.Write(w, binary.LittleEndian, length); err != nil {
-		return err
+// writeFailed handles a failed write. The current file is closed, so that a partially written record is truncated
+// on reopening it. If the disk is full, the writer switches to the degraded mode until the next disk budget check.
+func (w *obsWriter) writeFailed(step string, err error) {
+	w.closeCurrentFile()
+	if errors.Is(err, syscall.ENOSPC) {
+		w.reporter.IncDroppedObservations()
+		w.setDegraded(true, "disk full")
+		return
 	}
+	w.log.Warnf("write failed: %s: %s", step, err)
+}
 
-	if _, err := w.Write(value); err != nil {
-		return err
+// closeCurrentFile closes the current file. The next write opens the file of the current hour again.
+func (w *obsWriter) closeCurrentFile() {
+	if file, ok := w.currentFile.Load().(*writeFile); ok && file != nil {
+		_ = file.file.Close()
+		w.currentFile.Store((*writeFile)(nil))
 	}
-	return nil
 }
 
-func readRecord(r io.Reader) (byte, []byte, error) {
-	marker := make([]byte, 1)
-	if n, err := r.Read(marker); err == io.EOF {
-		return 0, nil, nil
-	} else if err != nil {
-		return 0, nil, err
-	} else if n != 1 {
-		return 0, nil, fmt.Errorf("missing marker")
+// closeExpiredFile closes the current file if its hour is over and starts the housekeeping without opening a new file.
+func (w *obsWriter) closeExpiredFile(now time.Time) {
+	if file, ok := w.currentFile.Load().(*writeFile); ok && file != nil && now.After(file.end) {
+		w.closeCurrentFile()
+		w.startHousekeeping("")
 	}
+}
 
-	var length uint16
-	if err := binary.Read(r, binary.LittleEndian, &length); err != nil {
-		return 0, nil, err
+func (w *obsWriter) currentFilename() string {
+	if file, ok := w.currentFile.Load().(*writeFile); ok && file != nil {
+		return file.filename
 	}
-	value := make([]byte, length)
-	if n, err := r.Read(value); err != nil {
-		return 0, nil, err
-	} else if n != int(length) {
-		return 0, nil, fmt.Errorf("incomplete block: %d != %d", n, int(length))
-	}
-	return marker[0], value, nil
+	return ""
 }
 
-func (w *obsWriter) loadStringIDMap(filename string) (*StringIDMap, error) {
+// loadStringIDMap loads the StringIDMap of an existing record file and returns its format version.
+// If the file contains a corrupt or truncated record, it is truncated to the valid records before it.
+func (w *obsWriter) loadStringIDMap(filename string) (*StringIDMap, byte, error) {
 	f, err := os.OpenFile(filepath.Clean(filename), os.O_RDONLY, 0o640) //  #nosec G302 -- no sensitive data
 	if err != nil {
 		if os.IsNotExist(err) {
-			return NewStringIDMap(), nil
+			return NewStringIDMap(), recordFormatV2, nil
 		}
-		return nil, err
+		return nil, 0, err
 	}
+	defer f.Close()
 
 	var objects []*IntString
-	for {
-		marker, value, err := readRecord(f)
+	rr, err := newRecordReader(f)
+	for err == nil {
+		offset := rr.offset
+		var (
+			marker byte
+			value  []byte
+		)
+		marker, value, err = rr.next()
 		if err != nil {
-			return nil, fmt.Errorf("reading StringIDMap failed: %s", err)
-		}
-		if value == nil {
 			break
 		}
-		switch marker {
-		case markerStringID:
+		if marker == markerStringID {
 			raw := &nwpd.IntString{}
-			if err := proto.Unmarshal(value, raw); err != nil {
-				return nil, fmt.Errorf("reading StringIDMap from file %s failed: %s", filename, err)
+			if uerr := proto.Unmarshal(value, raw); uerr != nil {
+				err = &corruptRecordError{offset: offset, reason: fmt.Sprintf("reading StringIDMap failed: %s", uerr)}
+				break
 			}
-			obj := NewVarint2String(raw.Key, raw.Value)
-			objects = append(objects, obj)
-		case markerObservation:
-			// ignore
-		case markerOpen:
-			// ignore
-		default:
-			return nil, fmt.Errorf("invalid file format")
+			objects = append(objects, NewVarint2String(raw.Key, raw.Value))
+		}
+	}
+	var corruptErr *corruptRecordError
+	switch {
+	case err == io.EOF:
+	case errors.As(err, &corruptErr):
+		w.log.Warnf("file %s: %s, truncating file", filename, err)
+		if err := os.Truncate(filepath.Clean(filename), corruptErr.offset); err != nil {
+			return nil, 0, fmt.Errorf("truncating file failed: %w", err)
 		}
+	default:
+		return nil, 0, fmt.Errorf("reading StringIDMap failed: %w", err)
 	}
-	idMap := NewStringIDMapFromData(objects)
-	return idMap, nil
+	return NewStringIDMapFromData(objects), rr.version, nil
 }
 
 func (w *obsWriter) getFile() (*writeFile, error) {
@@ -223,9 +259,6 @@ func (w *obsWriter) getFile() (*writeFile, error) {
 		file = f
 	}
 	if file == nil || now.After(file.end) {
-		go func() {
-			w.cleanOldFiles()
-		}()
 		// rotate output file
 		if file != nil {
 			if err := file.file.Close(); err != nil {
@@ -235,12 +268,12 @@ func (w *obsWriter) getFile() (*writeFile, error) {
 		currentUTC := startOfHourUTC(now)
 		next := now.Add(61 * time.Minute)
 		nextUTC := startOfHourUTC(next)
-		filename := fmt.Sprintf("%s/%s-%s.records", w.directory, w.prefix, currentUTC.Format("2006-01-02-15"))
-		idMap, err := w.loadStringIDMap(filename)
+		filename := fmt.Sprintf("%s/%s-%s%s", w.directory, w.prefix, currentUTC.Format("2006-01-02-15"), recordFileSuffix)
+		idMap, version, err := w.loadStringIDMap(filename)
 		if err != nil {
-			// corrupted file, delete it
+			// unreadable file, delete it
 			w.log.Warnf("loading StringIDMap from file %s failed: %s", filename, err)
-			w.log.Infof("deleting corrupt file %s", filename)
+			w.log.Infof("deleting unreadable file %s", filename)
 			if err := os.Remove(filepath.Clean(filename)); err != nil {
 				w.log.Warnf("cannot delete file %s: %s", filename, err)
 			}
@@ -250,21 +283,49 @@ func (w *obsWriter) getFile() (*writeFile, error) {
 		if err != nil {
 			return nil, err
 		}
-		err = writeRecord(f, markerOpen, []byte(now.UTC().Format("15:04:05")))
+		stat, err := f.Stat()
+		if err != nil {
+			_ = f.Close()
+			return nil, err
+		}
+		if stat.Size() == 0 {
+			// new files are always written in the current format, existing files are continued in their format
+			version = recordFormatV2
+			if _, err := f.Write(recordFileHeader); err != nil {
+				_ = f.Close()
+				return nil, err
+			}
+		}
+		err = writeRecord(f, version, markerOpen, []byte(now.UTC().Format("15:04:05")))
 		if err != nil {
+			_ = f.Close()
 			return nil, err
 		}
 		file = &writeFile{
 			filename: filename,
 			end:      nextUTC,
 			idMap:    idMap,
+			version:  version,
 			file:     f,
 		}
 		w.currentFile.Store(file)
+		w.startHousekeeping(filename)
 	}
 	return file, nil
 }
 
+// startHousekeeping cleans old files and compresses the rotated files in the background.
+func (w *obsWriter) startHousekeeping(current string) {
+	w.housekeepingRuns.Add(1)
+	go func() {
+		defer w.housekeepingRuns.Done()
+		w.housekeeping.Lock()
+		defer w.housekeeping.Unlock()
+		w.cleanOldFiles()
+		w.compressRotatedFiles(current)
+	}()
+}
+
 func (w *obsWriter) cleanOldFiles() {
 	hours := w.retentionHours
 	if hours <= 0 {
@@ -337,6 +398,15 @@ func (w *obsWriter) ListObservations(options nwpd.ListObservationsOptions) (nwpd
 	srcHostFilter := createFilter(options.FilterSrcHosts)
 	descHostFilter := createFilter(options.FilterDestHosts)
 
+	filter := RecordFilter{
+		Start: start,
+		End:   end,
+		Edge: func(jobID, srcHost, destHost string) bool {
+			return jobIDFilter(jobID) && srcHostFilter(srcHost) && descHostFilter(destHost)
+		},
+		FailuresOnly: options.FailuresOnly,
+	}
+
 	files, err := GetRecordFiles(w.directory, w.prefix, start, end)
 	if err != nil {
 		return nil, err
@@ -345,7 +415,7 @@ func (w *obsWriter) ListObservations(options nwpd.ListObservationsOptions) (nwpd
 		if len(result) == limit {
 			break
 		}
-		err := IterateRecordFile(file, func(obs *nwpd.Observation) error {
+		err := IterateRecordFileFiltered(file, filter, func(obs *nwpd.Observation) error {
 			if len(result) == limit {
 				return nil
 			}
@@ -374,35 +444,44 @@ func startOfHourUTC(t time.Time) time.Time {
 	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location())
 }
 
-// GetRecordFiles gets all observation record files.
+// GetRecordFiles gets all plain and compressed observation record files of the writer for the given time range.
+// A compressed file takes precedence over a plain file of the same hour, which is only removed after compression.
 func GetRecordFiles(directory, prefix string, start, end time.Time) ([]string, error) {
 	startHour := startOfHourUTC(start)
 	endHour := startOfHourUTC(end)
 	var files []string
 	for hour := startHour; !hour.After(endHour); hour = hour.Add(time.Hour) {
-		filename := fmt.Sprintf("%s/%s-%s.records", directory, prefix, hour.Format("2006-01-02-15"))
-		stat, err := os.Stat(filename)
-		if err != nil {
-			if os.IsNotExist(err) {
-				continue
+		for _, suffix := range []string{compressedRecordFileSuffix, recordFileSuffix} {
+			filename := fmt.Sprintf("%s/%s-%s%s", directory, prefix, hour.Format("2006-01-02-15"), suffix)
+			stat, err := os.Stat(filename)
+			if err != nil {
+				if os.IsNotExist(err) {
+					continue
+				}
+				return nil, err
 			}
-			return nil, err
-		}
-		if stat.IsDir() {
-			return nil, fmt.Errorf("%s is not a file", filename)
+			if stat.IsDir() {
+				return nil, fmt.Errorf("%s is not a file", filename)
+			}
+			files = append(files, filename)
+			break
 		}
-		files = append(files, filename)
 	}
 	return files, nil
 }
 
-// GetAnyRecordFiles gets all observation record files.
+// GetAnyRecordFiles gets all plain and compressed observation record files in the directory.
+// Plain files which have already been compressed are skipped.
 func GetAnyRecordFiles(directory string, subdir bool) ([]string, error) {
 	entries, err := os.ReadDir(directory)
 	if err != nil {
 		return nil, err
 	}
 
+	names := map[string]bool{}
+	for _, entry := range entries {
+		names[entry.Name()] = true
+	}
 	var files []string
 	for _, entry := range entries {
 		if entry.IsDir() {
@@ -415,7 +494,10 @@ func GetAnyRecordFiles(directory string, subdir bool) ([]string, error) {
 			}
 			continue
 		}
-		if !strings.HasSuffix(entry.Name(), ".records") {
+		if !IsRecordFile(entry.Name()) {
+			continue
+		}
+		if plain, ok := strings.CutSuffix(entry.Name(), recordFileSuffix); ok && names[plain+compressedRecordFileSuffix] {
 			continue
 		}
 		files = append(files, path.Join(directory, entry.Name()))
@@ -425,23 +507,38 @@ func GetAnyRecordFiles(directory string, subdir bool) ([]string, error) {
 
 type ObservationVisitor func(obs *nwpd.Observation) error
 
+// IterateRecordFile visits all observations of a plain or compressed record file of any format version.
+// Reading stops without error at the first corrupt or truncated record, e.g. a partially written record at the end of the current file.
 func IterateRecordFile(filename string, visitor ObservationVisitor) error {
-	f, err := os.OpenFile(filepath.Clean(filename), os.O_RDONLY, 0o640) //  #nosec G302 -- no sensitive data
+	f, err := openRecordFile(filename)
 	if err != nil {
 		return err
 	}
+	defer f.Close()
+
+	rr, err := newRecordReader(f)
+	if err != nil {
+		return ignoreCorruptRecord(err)
+	}
+	return visitRecords(rr, NewStringIDMap(), false, nil, visitor)
+}
 
-	idMap := NewStringIDMap()
+// visitRecords visits the observations read by the record reader. String records are appended to the StringIDMap,
+// unless it is a snapshot of the complete file. Observations are only converted and visited if accepted (nil accepts all).
+func visitRecords(rr *recordReader, idMap *StringIDMap, snapshot bool, accept func(obs *nwpd.IntObservation) bool, visitor ObservationVisitor) error {
 	for {
-		marker, value, err := readRecord(f)
-		if err != nil {
-			return err
-		}
-		if value == nil {
+		marker, value, err := rr.next()
+		if err == io.EOF {
 			break
 		}
+		if err != nil {
+			return ignoreCorruptRecord(err)
+		}
 		switch marker {
 		case markerStringID:
+			if snapshot {
+				continue
+			}
 			raw := &nwpd.IntString{}
 			if err := proto.Unmarshal(value, raw); err != nil {
 				return fmt.Errorf("error on reading StringIDMap: %s", err)
@@ -455,6 +552,9 @@ func IterateRecordFile(filename string, visitor ObservationVisitor) error {
 			if err != nil {
 				return fmt.Errorf("error on unmarshalling: %s", err)
 			}
+			if accept != nil && !accept(intobs) {
+				continue
+			}
 			obs, err := IntObsToObservation(intobs, idMap)
 			if err != nil {
 				return fmt.Errorf("error on converting observation: %s", err)
@@ -464,9 +564,15 @@ func IterateRecordFile(filename string, visitor ObservationVisitor) error {
 			}
 		case markerOpen:
 			// ignore
-		default:
-			return fmt.Errorf("invalid file format")
 		}
 	}
 	return nil
 }
+
+func ignoreCorruptRecord(err error) error {
+	var corruptErr *corruptRecordError
+	if errors.As(err, &corruptErr) {
+		return nil
+	}
+	return err
+}
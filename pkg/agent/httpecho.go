@@ -0,0 +1,32 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/gardener/network-problem-detector/pkg/agent/runners"
+)
+
+// newHTTPEchoHandler returns the handler of the HTTP echo endpoint called by `checkPeerIdentity` jobs of other agents.
+// It responds with the identity of the agent and the source IP address of the request.
+func newHTTPEchoHandler(nodeName, podName string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientIP, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			clientIP = r.RemoteAddr
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(&runners.EchoResponse{
+			NodeName:  nodeName,
+			PodName:   podName,
+			ClientIP:  clientIP,
+			Timestamp: time.Now().UTC(),
+		})
+	})
+}
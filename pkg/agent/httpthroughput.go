@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gardener/network-problem-detector/pkg/agent/runners"
+)
+
+const (
+	// maxConcurrentThroughputTransfers limits the number of parallel transfers, as they compete for the bandwidth.
+	maxConcurrentThroughputTransfers = 2
+	// throughputTransferTimeout is the maximum duration of a single transfer. It overrides the timeouts of the HTTP server.
+	throughputTransferTimeout = 5 * time.Minute
+)
+
+// newHTTPThroughputHandler returns the handler of the HTTP throughput endpoint called by `checkThroughput` jobs of other agents.
+// A GET request sends the number of zero bytes given by the query parameter `bytes`, a POST request consumes the body
+// and responds with the number of received bytes.
+func newHTTPThroughputHandler() http.Handler {
+	slots := make(chan struct{}, maxConcurrentThroughputTransfers)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case slots <- struct{}{}:
+			defer func() { <-slots }()
+		default:
+			http.Error(w, "too many concurrent transfers", http.StatusServiceUnavailable)
+			return
+		}
+
+		rc := http.NewResponseController(w)
+		deadline := time.Now().Add(throughputTransferTimeout)
+		switch r.Method {
+		case http.MethodGet:
+			n, err := strconv.ParseInt(r.URL.Query().Get("bytes"), 10, 64)
+			if err != nil || n <= 0 || n > runners.MaxThroughputBytes {
+				http.Error(w, fmt.Sprintf("invalid bytes (allowed range: 1-%d)", runners.MaxThroughputBytes), http.StatusBadRequest)
+				return
+			}
+			_ = rc.SetWriteDeadline(deadline)
+			w.Header().Set("Content-Type", "application/octet-stream")
+			w.Header().Set("Content-Length", strconv.FormatInt(n, 10))
+			_, _ = io.CopyN(w, runners.ZeroReader{}, n)
+		case http.MethodPost:
+			_ = rc.SetReadDeadline(deadline)
+			_ = rc.SetWriteDeadline(deadline)
+			n, err := io.Copy(io.Discard, http.MaxBytesReader(w, r.Body, runners.MaxThroughputBytes))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+				return
+			}
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = fmt.Fprintf(w, "%d\n", n)
+		default:
+			w.Header().Set("Allow", "GET, POST")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+}
@@ -0,0 +1,30 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gardener/network-problem-detector/pkg/agent/runners"
+
+	"github.com/spf13/cobra"
+)
+
+// CreateJobSchemaCmd creates the command printing the JSON schema of the jobs of the agent configuration.
+func CreateJobSchemaCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "job-schema",
+		Short: "prints the JSON schema of a job of the agent configuration",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			data, err := json.MarshalIndent(runners.JobSchema(), "", "  ")
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), string(data))
+			return err
+		},
+	}
+}
@@ -7,7 +7,9 @@ package agent
 import (
 	"sync"
 
+	"github.com/gardener/network-problem-detector/pkg/agent/db"
 	"github.com/gardener/network-problem-detector/pkg/common"
+	"github.com/gardener/network-problem-detector/pkg/common/nwpd"
 
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -15,6 +17,18 @@ import (
 func init() {
 	prometheus.MustRegister(AggregatedObservations)
 	prometheus.MustRegister(AggregatedObservationsLatency)
+	prometheus.MustRegister(PathMTU)
+	prometheus.MustRegister(HTTPPhaseDuration)
+	prometheus.MustRegister(TLSCertRemainingLifetime)
+	prometheus.MustRegister(PeerClockOffset)
+	prometheus.MustRegister(Throughput)
+	prometheus.MustRegister(StreamDroppedObservations)
+	prometheus.MustRegister(SchedulingLag)
+	prometheus.MustRegister(SkippedJobRuns)
+	prometheus.MustRegister(RecordsDiskUsage)
+	prometheus.MustRegister(RecordsFilesystemFree)
+	prometheus.MustRegister(RecordsEvictedFiles)
+	prometheus.MustRegister(RecordsDroppedObservations)
 }
 
 var (
@@ -23,7 +37,7 @@ var (
 			Name: "nwpd_aggregated_observations",
 			Help: "Total counts of observations",
 		},
-		[]string{"src", "dest", "jobid", "status"},
+		[]string{"src", "dest", "jobid", "status", "class"},
 	)
 	AggregatedObservationsLatency = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -32,6 +46,87 @@ var (
 		},
 		[]string{"src", "dest", "jobid"},
 	)
+	PathMTU = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "nwpd_path_mtu_bytes",
+			Help: "Discovered path MTU in bytes",
+		},
+		[]string{"src", "dest", "jobid"},
+	)
+	HTTPPhaseDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "nwpd_http_phase_duration_seconds",
+			Help:    "Duration of the phases (dns, connect, tls, ttfb) of HTTP requests in seconds",
+			Buckets: prometheus.ExponentialBuckets(0.0005, 2, 14),
+		},
+		[]string{"jobid", "phase"},
+	)
+	TLSCertRemainingLifetime = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "nwpd_tls_cert_remaining_lifetime_seconds",
+			Help: "Remaining lifetime of the earliest expiring certificate of the TLS server certificate chain in seconds",
+		},
+		[]string{"src", "dest", "jobid"},
+	)
+	PeerClockOffset = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "nwpd_peer_clock_offset_seconds",
+			Help: "Estimated offset of the clock of the peer agent relative to the local clock in seconds",
+		},
+		[]string{"src", "dest", "jobid"},
+	)
+	Throughput = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "nwpd_throughput_bytes_per_second",
+			Help: "Measured throughput of transfers to or from the peer agent in bytes per second",
+		},
+		[]string{"src", "dest", "jobid"},
+	)
+	SchedulingLag = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "nwpd_scheduling_lag_seconds",
+			Help:    "Delay between the planned and the actual start of job runs in seconds",
+			Buckets: prometheus.ExponentialBuckets(0.001, 2, 14),
+		},
+	)
+	SkippedJobRuns = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "nwpd_skipped_job_runs",
+			Help: "Total count of job runs skipped because the previous run was still active",
+		},
+		[]string{"jobid"},
+	)
+	RecordsDiskUsage = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "nwpd_records_disk_usage_bytes",
+			Help: "Total size of the observation record files in bytes",
+		},
+	)
+	RecordsFilesystemFree = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "nwpd_records_filesystem_free_percent",
+			Help: "Free space of the file system of the observation record files in percent",
+		},
+	)
+	RecordsEvictedFiles = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "nwpd_records_evicted_files",
+			Help: "Total count of observation record files deleted to keep the disk budget",
+		},
+		[]string{"reason"},
+	)
+	RecordsDroppedObservations = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "nwpd_records_dropped_observations",
+			Help: "Total count of observations not written because the disk budget is exceeded or the disk is full",
+		},
+	)
+	StreamDroppedObservations = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "nwpd_stream_dropped_observations",
+			Help: "Total count of observations dropped for slow clients of the observation stream",
+		},
+	)
 )
 
 type observationKey struct {
@@ -76,20 +171,81 @@ func (k *observationKeys) remove(isObsolete func(key observationKey) bool) []obs
 	return keys
 }
 
-func IncAggregatedObservation(src, dest, jobid string, ok bool) {
+// IncAggregatedObservation counts an observation. The class label is the failure class and empty for successful checks.
+func IncAggregatedObservation(src, dest, jobid string, ok bool, class nwpd.FailureClass) {
 	status := "ok"
 	if !ok {
 		status = "failed"
 	}
 	metricKeys.add(src, dest, jobid)
-	AggregatedObservations.WithLabelValues(src, dest, jobid, status).Inc()
+	AggregatedObservations.WithLabelValues(src, dest, jobid, status, class.Label()).Inc()
 }
 
 func ReportAggregatedObservationLatency(src, dest, jobid string, seconds float64) {
 	AggregatedObservationsLatency.WithLabelValues(src, dest, jobid).Set(seconds)
 }
 
+func ReportPathMTU(src, dest, jobid string, mtu int32) {
+	PathMTU.WithLabelValues(src, dest, jobid).Set(float64(mtu))
+}
+
+func ReportHTTPPhaseDurations(jobid string, timings *nwpd.PhaseTimings) {
+	for i, d := range timings.Durations() {
+		if d != nil {
+			HTTPPhaseDuration.WithLabelValues(jobid, nwpd.PhaseNames[i]).Observe(d.AsDuration().Seconds())
+		}
+	}
+}
+
+func ReportTLSCertRemainingLifetime(src, dest, jobid string, seconds float64) {
+	TLSCertRemainingLifetime.WithLabelValues(src, dest, jobid).Set(seconds)
+}
+
+func ReportPeerClockOffset(src, dest, jobid string, seconds float64) {
+	PeerClockOffset.WithLabelValues(src, dest, jobid).Set(seconds)
+}
+
+func ReportThroughput(src, dest, jobid string, bytesPerSecond float64) {
+	Throughput.WithLabelValues(src, dest, jobid).Set(bytesPerSecond)
+}
+
+func ReportSchedulingLag(seconds float64) {
+	SchedulingLag.Observe(seconds)
+}
+
+func IncSkippedJobRuns(jobid string) {
+	SkippedJobRuns.WithLabelValues(jobid).Inc()
+}
+
+func IncStreamDroppedObservations() {
+	StreamDroppedObservations.Inc()
+}
+
+// writerMetrics exports the disk usage of the observation writer.
+type writerMetrics struct{}
+
+var _ db.DiskUsageReporter = writerMetrics{}
+
+func (writerMetrics) ReportDiskUsage(usedBytes int64, freePercent float64) {
+	RecordsDiskUsage.Set(float64(usedBytes))
+	if freePercent >= 0 {
+		RecordsFilesystemFree.Set(freePercent)
+	}
+}
+
+func (writerMetrics) IncEvictedFiles(reason string) {
+	RecordsEvictedFiles.WithLabelValues(reason).Inc()
+}
+
+func (writerMetrics) IncDroppedObservations() {
+	RecordsDroppedObservations.Inc()
+}
+
 func deleteOutdatedMetricByObsoleteJobIDs(jobIDs []string) {
+	for _, id := range jobIDs {
+		SkippedJobRuns.DeleteLabelValues(id)
+		HTTPPhaseDuration.DeletePartialMatch(prometheus.Labels{"jobid": id})
+	}
 	if len(jobIDs) > 0 {
 		keys := metricKeys.remove(func(key observationKey) bool {
 			for _, id := range jobIDs {
@@ -112,8 +268,11 @@ func deleteOutdatedMetricByValidDestHosts(validDestHosts common.StringSet) {
 
 func deleteOutdatedMetricsByKeys(keys []observationKey) {
 	for _, key := range keys {
-		AggregatedObservations.DeleteLabelValues(key.src, key.dest, key.jobid, "ok")
-		AggregatedObservations.DeleteLabelValues(key.src, key.dest, key.jobid, "failed")
+		AggregatedObservations.DeletePartialMatch(prometheus.Labels{"src": key.src, "dest": key.dest, "jobid": key.jobid})
 		AggregatedObservationsLatency.DeleteLabelValues(key.src, key.dest, key.jobid)
+		PathMTU.DeleteLabelValues(key.src, key.dest, key.jobid)
+		TLSCertRemainingLifetime.DeleteLabelValues(key.src, key.dest, key.jobid)
+		PeerClockOffset.DeleteLabelValues(key.src, key.dest, key.jobid)
+		Throughput.DeleteLabelValues(key.src, key.dest, key.jobid)
 	}
 }
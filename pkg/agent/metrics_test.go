@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"time"
+
+	"github.com/gardener/network-problem-detector/pkg/common/nwpd"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+var _ = Describe("metrics", func() {
+	It("should count aggregated observations by failure class", func() {
+		IncAggregatedObservation("metrics-src", "metrics-dest", "metrics-job", true, nwpd.FailureClass_FAILURE_CLASS_UNSPECIFIED)
+		IncAggregatedObservation("metrics-src", "metrics-dest", "metrics-job", false, nwpd.FailureClass_FAILURE_CLASS_TIMEOUT)
+		IncAggregatedObservation("metrics-src", "metrics-dest", "metrics-job", false, nwpd.FailureClass_FAILURE_CLASS_TIMEOUT)
+		IncAggregatedObservation("metrics-src", "metrics-dest", "metrics-job", false, nwpd.FailureClass_FAILURE_CLASS_CONNECTION_REFUSED)
+
+		Expect(testutil.ToFloat64(AggregatedObservations.WithLabelValues("metrics-src", "metrics-dest", "metrics-job", "ok", ""))).To(Equal(float64(1)))
+		Expect(testutil.ToFloat64(AggregatedObservations.WithLabelValues("metrics-src", "metrics-dest", "metrics-job", "failed", "timeout"))).To(Equal(float64(2)))
+		Expect(testutil.ToFloat64(AggregatedObservations.WithLabelValues("metrics-src", "metrics-dest", "metrics-job", "failed", "connection_refused"))).To(Equal(float64(1)))
+
+		count := testutil.CollectAndCount(AggregatedObservations)
+		deleteOutdatedMetricByObsoleteJobIDs([]string{"metrics-job"})
+		Expect(testutil.CollectAndCount(AggregatedObservations)).To(Equal(count - 3))
+	})
+	It("should aggregate HTTP phase durations per job and phase", func() {
+		timings := &nwpd.PhaseTimings{Connect: durationpb.New(2 * time.Millisecond), Ttfb: durationpb.New(5 * time.Millisecond)}
+		ReportHTTPPhaseDurations("phase-job", timings)
+		ReportHTTPPhaseDurations("phase-job", timings)
+
+		count := testutil.CollectAndCount(HTTPPhaseDuration)
+		deleteOutdatedMetricByObsoleteJobIDs([]string{"phase-job"})
+		Expect(testutil.CollectAndCount(HTTPPhaseDuration)).To(Equal(count - 2))
+	})
+})
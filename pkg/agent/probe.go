@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gardener/network-problem-detector/pkg/agent/runners"
+	"github.com/gardener/network-problem-detector/pkg/common/config"
+	"github.com/gardener/network-problem-detector/pkg/common/nwpd"
+
+	"github.com/twitchtv/twirp"
+)
+
+const (
+	// defaultProbeTimeout is the overall timeout of a probe if not specified by the request.
+	defaultProbeTimeout = 10 * time.Second
+	// maxProbeTimeout is the maximum overall timeout of a probe.
+	maxProbeTimeout = 1 * time.Minute
+	// adHocJobIDPrefix is the prefix of the job ID of probes.
+	adHocJobIDPrefix = "adhoc-"
+)
+
+// RunProbe runs the checks of the given runner args synchronously. The results are tagged as ad-hoc and are neither
+// persisted nor fed to the aggregator.
+func (s *server) RunProbe(ctx context.Context, request *nwpd.RunProbeRequest) (*nwpd.RunProbeResponse, error) {
+	if len(request.Args) == 0 {
+		return nil, twirp.RequiredArgumentError("args")
+	}
+	timeout := defaultProbeTimeout
+	if request.Timeout != nil {
+		timeout = request.Timeout.AsDuration()
+		if timeout <= 0 || timeout > maxProbeTimeout {
+			return nil, twirp.InvalidArgumentError("timeout", fmt.Sprintf("must be between 0 and %s", maxProbeTimeout))
+		}
+	}
+
+	job := &config.Job{
+		JobID: adHocJobIDPrefix + request.Args[0],
+		Args:  request.Args,
+	}
+	internalJob, err := s.parseProbeJob(job)
+	if err != nil {
+		return nil, twirp.NewError(twirp.InvalidArgument, err.Error())
+	}
+	if internalJob == nil {
+		return nil, twirp.NewError(twirp.FailedPrecondition, "no destination hosts")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	observations, pending, err := internalJob.Probe(ctx, s.nodeName, request.RestrictToDestHosts)
+	if err != nil {
+		return nil, twirp.NewError(twirp.FailedPrecondition, err.Error())
+	}
+	for _, obs := range observations {
+		s.logObservation(obs)
+	}
+	s.log.Infof("probe %s: %d observations, %d pending checks", job.JobID, len(observations), pending)
+	return &nwpd.RunProbeResponse{
+		Observations:  observations,
+		PendingChecks: int32(pending), // #nosec G115 -- number of checks fits in int32
+	}, nil
+}
+
+// parseProbeJob parses the job of a probe. Unlike regular jobs, all nodes are considered as destinations.
+func (s *server) parseProbeJob(job *config.Job) (*runners.InternalJob, error) {
+	agentCfg, currentClusterCfg := s.currentConfigs()
+	clusterCfg := config.ClusterConfig{}
+	if currentClusterCfg != nil {
+		clusterCfg = *currentClusterCfg
+	}
+	return parseJob(job, networkCfgOf(agentCfg), clusterCfg, &config.SampleConfig{}, s.checkLimiter())
+}
+
+// withProbeWriteDeadline extends the write deadline of the HTTP server for probe requests, as they may take up to
+// the maximum probe timeout.
+func withProbeWriteDeadline(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == nwpd.AgentServicePathPrefix+"RunProbe" {
+			_ = http.NewResponseController(w).SetWriteDeadline(time.Now().Add(maxProbeTimeout + 10*time.Second))
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
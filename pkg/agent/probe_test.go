@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/gardener/network-problem-detector/pkg/common/config"
+	"github.com/gardener/network-problem-detector/pkg/common/nwpd"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+	"github.com/twitchtv/twirp"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+var _ = Describe("RunProbe", func() {
+	var (
+		s        *server
+		listener net.Listener
+	)
+
+	BeforeEach(func() {
+		var err error
+		listener, err = net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).To(BeNil())
+		s = &server{
+			log:                  logrus.New(),
+			nodeName:             "node1",
+			currentAgentConfig:   &config.AgentConfig{},
+			currentClusterConfig: &config.ClusterConfig{},
+		}
+	})
+
+	AfterEach(func() {
+		_ = listener.Close()
+	})
+
+	It("should run the checks and tag the observations", func() {
+		port := listener.Addr().(*net.TCPAddr).Port
+		response, err := s.RunProbe(context.Background(), &nwpd.RunProbeRequest{
+			Args:                []string{"checkTCPPort", "--endpoints", fmt.Sprintf("local:127.0.0.1:%d", port), "--endpoints", "other:127.0.0.2:1"},
+			RestrictToDestHosts: []string{"local"},
+			Timeout:             durationpb.New(5 * time.Second),
+		})
+		Expect(err).To(BeNil())
+		Expect(response.PendingChecks).To(BeZero())
+		Expect(response.Observations).To(HaveLen(1))
+		obs := response.Observations[0]
+		Expect(obs.Ok).To(BeTrue())
+		Expect(obs.AdHoc).To(BeTrue())
+		Expect(obs.JobID).To(Equal("adhoc-checkTCPPort"))
+		Expect(obs.SrcHost).To(Equal("node1"))
+		Expect(obs.DestHost).To(Equal("local"))
+	})
+
+	DescribeTable("should reject invalid requests",
+		func(request *nwpd.RunProbeRequest, expectedCode twirp.ErrorCode, expectedMsg string) {
+			_, err := s.RunProbe(context.Background(), request)
+			twerr, ok := err.(twirp.Error)
+			Expect(ok).To(BeTrue())
+			Expect(twerr.Code()).To(Equal(expectedCode))
+			Expect(twerr.Msg()).To(Equal(expectedMsg))
+		},
+		Entry("missing args", &nwpd.RunProbeRequest{}, twirp.InvalidArgument, "args is required"),
+		Entry("invalid timeout", &nwpd.RunProbeRequest{Args: []string{"checkTCPPort"}, Timeout: durationpb.New(time.Hour)},
+			twirp.InvalidArgument, "timeout must be between 0 and 1m0s"),
+		Entry("invalid args", &nwpd.RunProbeRequest{Args: []string{"checkFoo"}}, twirp.InvalidArgument,
+			`invalid job adhoc-checkFoo: unknown command "checkFoo" for "runner"`),
+		Entry("no targets", &nwpd.RunProbeRequest{Args: []string{"checkTCPPort", "--node-port", "10250"}}, twirp.FailedPrecondition,
+			"no destination hosts"),
+		Entry("unknown destination host", &nwpd.RunProbeRequest{Args: []string{"checkTCPPort", "--endpoints", "a:127.0.0.1:1"},
+			RestrictToDestHosts: []string{"b"}}, twirp.FailedPrecondition, "no destination hosts matching [b]"),
+	)
+})
@@ -0,0 +1,116 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runners
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gardener/network-problem-detector/pkg/common/config"
+	"github.com/gardener/network-problem-detector/pkg/common/nwpd"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// ClockSkewOptions are the options for measuring the clock offset to peer agents.
+type ClockSkewOptions struct {
+	// Samples is the number of requests per measurement. The sample with the shortest round trip time is used.
+	Samples int
+	// Timeout is the timeout of a request.
+	Timeout time.Duration
+}
+
+type checkClockSkewArgs struct {
+	peerEndpointArgs
+	samples int
+}
+
+func (a *checkClockSkewArgs) createRunner(_ *cobra.Command, _ []string) error {
+	endpoints, err := a.buildPeerEndpoints()
+	if err != nil {
+		return err
+	}
+	if a.samples < 1 {
+		return fmt.Errorf("invalid samples %d", a.samples)
+	}
+	config := a.runnerArgs.prepareConfig()
+	options := ClockSkewOptions{Samples: a.samples, Timeout: config.timeoutOr(5 * time.Second)}
+	if r := NewCheckClockSkew(endpoints, options, config); r != nil {
+		a.runnerArgs.runner = r
+	}
+	return nil
+}
+
+func createCheckClockSkewCmd(ra *runnerArgs) *cobra.Command {
+	a := &checkClockSkewArgs{peerEndpointArgs: peerEndpointArgs{runnerArgs: ra}}
+	cmd := &cobra.Command{
+		Use:   "checkClockSkew",
+		Short: "estimates the clock offset to peer agents using the timestamps of their HTTP echo endpoint",
+		RunE:  a.createRunner,
+	}
+	a.addFlags(cmd)
+	cmd.Flags().IntVar(&a.samples, "samples", 4, "number of requests per measurement, the one with the shortest round trip time is used.")
+	return cmd
+}
+
+func NewCheckClockSkew(endpoints []PeerEndpoint, options ClockSkewOptions, rconfig RunnerConfig) Runner {
+	if len(endpoints) == 0 {
+		return nil
+	}
+	return &checkClockSkew{
+		robinRound[PeerEndpoint]{
+			itemsName: "endpoints",
+			items:     config.CloneAndShuffle(endpoints),
+			runFunc: func(endpoint PeerEndpoint, obs *nwpd.Observation) (string, error) {
+				return checkClockSkewFunc(endpoint, options, obs)
+			},
+			config: rconfig,
+		},
+	}
+}
+
+type checkClockSkew struct {
+	robinRound[PeerEndpoint]
+}
+
+var _ Runner = &checkClockSkew{}
+
+func checkClockSkewFunc(endpoint PeerEndpoint, options ClockSkewOptions, obs *nwpd.Observation) (string, error) {
+	// the connection is reused for all samples, so only the first one includes the connection setup
+	tr := &http.Transport{MaxIdleConnsPerHost: 1}
+	defer tr.CloseIdleConnections()
+	client := &http.Client{Transport: tr, Timeout: options.Timeout}
+
+	var bestOffset, bestRTT time.Duration
+	for i := 0; i < options.Samples; i++ {
+		sent := time.Now()
+		echo, err := requestEcho(client, endpoint.Endpoint)
+		if err != nil {
+			return "", err
+		}
+		received := time.Now()
+		if err := checkIdentity(endpoint, echo); err != nil {
+			return "", fmt.Errorf("%w, got node %s, pod %s", err, echo.NodeName, echo.PodName)
+		}
+		offset, rtt := clockOffset(sent, echo.Timestamp, received)
+		if i == 0 || rtt < bestRTT {
+			bestOffset, bestRTT = offset, rtt
+		}
+	}
+
+	obs.ClockOffset = durationpb.New(bestOffset)
+	obs.Duration = durationpb.New(bestRTT)
+	return fmt.Sprintf("clock offset %.3f ms (rtt %.3f ms, %d samples)", millis(bestOffset), millis(bestRTT), options.Samples), nil
+}
+
+// clockOffset estimates the offset of the peer clock NTP-style, assuming the peer timestamp was taken in the middle of the round trip.
+// The sent and received times must carry the monotonic clock reading.
+func clockOffset(sent, peer, received time.Time) (offset, rtt time.Duration) {
+	rtt = received.Sub(sent)
+	mid := sent.Add(rtt / 2).Round(0)
+	return peer.Sub(mid), rtt
+}
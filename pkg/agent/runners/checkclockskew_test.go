@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runners
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/gardener/network-problem-detector/pkg/common/config"
+	"github.com/gardener/network-problem-detector/pkg/common/nwpd"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("checkClockSkew", func() {
+	DescribeTable("clockOffset",
+		func(rtt, peerDelta, expectedOffset time.Duration) {
+			sent := time.Now()
+			received := sent.Add(rtt)
+			peer := sent.Add(peerDelta).Round(0)
+			offset, measuredRTT := clockOffset(sent, peer, received)
+			Expect(offset).To(Equal(expectedOffset))
+			Expect(measuredRTT).To(Equal(rtt))
+		},
+		Entry("synchronous clocks", 10*time.Millisecond, 5*time.Millisecond, time.Duration(0)),
+		Entry("peer clock ahead", 10*time.Millisecond, 2005*time.Millisecond, 2*time.Second),
+		Entry("peer clock behind", 10*time.Millisecond, -995*time.Millisecond, -1*time.Second),
+	)
+
+	var (
+		server   *httptest.Server
+		requests atomic.Int32
+	)
+	BeforeEach(func() {
+		requests.Store(0)
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			requests.Add(1)
+			_ = json.NewEncoder(w).Encode(&EchoResponse{
+				NodeName:  "node1",
+				PodName:   "pod1",
+				Timestamp: time.Now().Add(3 * time.Second),
+			})
+		}))
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	endpoint := func(nodename string) PeerEndpoint {
+		host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+		Expect(err).To(BeNil())
+		p, err := strconv.Atoi(port)
+		Expect(err).To(BeNil())
+		return PeerEndpoint{Endpoint: config.Endpoint{Hostname: nodename, IP: host, Port: p}}
+	}
+	options := ClockSkewOptions{Samples: 3, Timeout: 5 * time.Second}
+
+	It("should estimate the clock offset of the peer", func() {
+		obs := &nwpd.Observation{}
+		result, err := checkClockSkewFunc(endpoint("node1"), options, obs)
+		Expect(err).To(BeNil())
+		Expect(result).To(MatchRegexp(`^clock offset (29\d{2}|30\d{2})\.\d{3} ms \(rtt \d+\.\d{3} ms, 3 samples\)$`))
+		Expect(requests.Load()).To(Equal(int32(3)))
+		Expect(obs.Duration).NotTo(BeNil())
+		// the peer timestamp is taken somewhere within the round trip, so the estimate is off by at most half of the rtt
+		// in either direction, e.g. 2999.8 ms for an offset of 3 s
+		Expect(obs.ClockOffset.AsDuration()).To(BeNumerically("~", 3*time.Second, obs.Duration.AsDuration()/2))
+	})
+
+	It("should fail for an unexpected peer", func() {
+		obs := &nwpd.Observation{}
+		_, err := checkClockSkewFunc(endpoint("node2"), options, obs)
+		Expect(err).NotTo(BeNil())
+		Expect(err.Error()).To(Equal("identity mismatch: expected node node2, got node node1, pod pod1"))
+		Expect(obs.ClockOffset).To(BeNil())
+	})
+})
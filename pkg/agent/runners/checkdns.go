@@ -0,0 +1,343 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runners
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gardener/network-problem-detector/pkg/common"
+	"github.com/gardener/network-problem-detector/pkg/common/config"
+	"github.com/gardener/network-problem-detector/pkg/common/nwpd"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// maxUDPDNSMessageSize is the maximum size of a DNS response over UDP without EDNS0.
+const maxUDPDNSMessageSize = 512
+
+// DNSQueryOptions are the options for querying DNS servers directly.
+type DNSQueryOptions struct {
+	// Names are the fully qualified DNS names to query.
+	Names []string
+	// Types are the record types to query for each name.
+	Types []dnsmessage.Type
+	// TCP if the queries should be sent over TCP instead of UDP.
+	TCP bool
+	// Expected are expected answer values. If set, the answers of each query must contain at least one of them.
+	Expected []string
+	// Timeout is the timeout for a single query.
+	Timeout time.Duration
+}
+
+type checkDNSArgs struct {
+	runnerArgs   *runnerArgs
+	servers      []string
+	kubeDNSSvc   bool
+	kubeDNSPods  bool
+	names        []string
+	internalKAPI bool
+	types        []string
+	protocol     string
+	expected     []string
+}
+
+func (a *checkDNSArgs) createRunner(_ *cobra.Command, _ []string) error {
+	allowEmpty := false
+	var servers []config.Endpoint
+	switch {
+	case len(a.servers) > 0:
+		for _, s := range a.servers {
+			parts := strings.SplitN(s, ":", 3)
+			if len(parts) < 2 {
+				return fmt.Errorf("invalid server %s", s)
+			}
+			port := 53
+			if len(parts) == 3 {
+				var err error
+				port, err = strconv.Atoi(parts[2])
+				if err != nil {
+					return fmt.Errorf("invalid server port %s", parts[2])
+				}
+			}
+			servers = append(servers, config.Endpoint{Hostname: parts[0], IP: parts[1], Port: port})
+		}
+	case a.kubeDNSSvc:
+		allowEmpty = true
+		if ep := a.runnerArgs.clusterCfg.KubeDNSService; ep != nil {
+			servers = append(servers, config.Endpoint{Hostname: common.DomainNameKubeDNSService, IP: ep.IP, Port: ep.Port})
+		}
+	case a.kubeDNSPods:
+		allowEmpty = true
+		for _, pe := range a.runnerArgs.clusterCfg.KubeDNSPods {
+			servers = append(servers, config.Endpoint{Hostname: pe.Podname, IP: pe.PodIP, Port: int(pe.Port)})
+		}
+	}
+	if !allowEmpty && len(servers) == 0 {
+		return fmt.Errorf("no DNS servers")
+	}
+
+	config := a.runnerArgs.prepareConfig()
+	options := DNSQueryOptions{
+		Expected: a.expected,
+		Timeout:  config.timeoutOr(2 * time.Second),
+	}
+	for _, name := range a.names {
+		options.Names = append(options.Names, fullQualified(name))
+	}
+	if a.internalKAPI {
+		options.Names = append(options.Names, common.DomainNameKubernetesService)
+	}
+	if len(options.Names) == 0 {
+		return fmt.Errorf("no DNS names")
+	}
+	for _, t := range a.types {
+		switch strings.ToUpper(t) {
+		case "A":
+			options.Types = append(options.Types, dnsmessage.TypeA)
+		case "AAAA":
+			options.Types = append(options.Types, dnsmessage.TypeAAAA)
+		case "SRV":
+			options.Types = append(options.Types, dnsmessage.TypeSRV)
+		default:
+			return fmt.Errorf("unsupported record type %s", t)
+		}
+	}
+	switch strings.ToLower(a.protocol) {
+	case "udp":
+	case "tcp":
+		options.TCP = true
+	default:
+		return fmt.Errorf("unsupported protocol %s", a.protocol)
+	}
+
+	if r := NewCheckDNS(servers, options, config); r != nil {
+		a.runnerArgs.runner = r
+	}
+	return nil
+}
+
+func createCheckDNSCmd(ra *runnerArgs) *cobra.Command {
+	a := &checkDNSArgs{runnerArgs: ra}
+	cmd := &cobra.Command{
+		Use:   "checkDNS",
+		Short: "sends DNS queries directly to DNS servers",
+		RunE:  a.createRunner,
+	}
+	cmd.Flags().StringSliceVar(&a.servers, "servers", nil, "DNS servers in format <hostname>:<ip>[:<port>].")
+	cmd.Flags().BoolVar(&a.kubeDNSSvc, "servers-kube-dns-service", false, "uses known cluster IP of the kube-dns service as alternative to specifying servers.")
+	cmd.Flags().BoolVar(&a.kubeDNSPods, "servers-kube-dns-pods", false, "uses known pod endpoints of the kube-dns service as alternative to specifying servers.")
+	cmd.Flags().StringSliceVar(&a.names, "names", nil, "DNS names")
+	cmd.Flags().BoolVar(&a.internalKAPI, "name-internal-kube-apiserver", false, "uses DNS name 'kubernetes.default.svc.cluster.local.'")
+	cmd.Flags().StringSliceVar(&a.types, "types", []string{"A"}, "record types to query (A, AAAA, SRV).")
+	cmd.Flags().StringVar(&a.protocol, "protocol", "udp", "protocol used for the queries (udp, tcp). UDP queries fall back to TCP on truncated responses.")
+	cmd.Flags().StringSliceVar(&a.expected, "expect", nil, "optional expected answers (IP addresses or SRV targets in format <fully qualified target>:<port>). Each query must return at least one of them.")
+	return cmd
+}
+
+func NewCheckDNS(servers []config.Endpoint, options DNSQueryOptions, rconfig RunnerConfig) Runner {
+	if len(servers) == 0 {
+		return nil
+	}
+	return &checkDNS{
+		robinRound[config.Endpoint]{
+			itemsName: "servers",
+			items:     config.CloneAndShuffle(servers),
+			runFunc: func(server config.Endpoint, _ *nwpd.Observation) (string, error) {
+				return checkDNSFunc(server, options)
+			},
+			config: rconfig,
+		},
+	}
+}
+
+type checkDNS struct {
+	robinRound[config.Endpoint]
+}
+
+var _ Runner = &checkDNS{}
+
+func checkDNSFunc(server config.Endpoint, options DNSQueryOptions) (string, error) {
+	addr := net.JoinHostPort(server.IP, strconv.Itoa(server.Port))
+	var results []string
+	for _, name := range options.Names {
+		for _, qtype := range options.Types {
+			result, err := queryDNS(addr, name, qtype, options)
+			if err != nil {
+				return "", fmt.Errorf("%s %s: %w", typeName(qtype), name, err)
+			}
+			results = append(results, result)
+		}
+	}
+	return strings.Join(results, "; "), nil
+}
+
+// queryDNS sends a single query to the server and checks the response.
+func queryDNS(addr, name string, qtype dnsmessage.Type, options DNSQueryOptions) (string, error) {
+	qname, err := dnsmessage.NewName(name)
+	if err != nil {
+		return "", err
+	}
+	id := make([]byte, 2)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	query := dnsmessage.Message{
+		Header:    dnsmessage.Header{ID: binary.BigEndian.Uint16(id), RecursionDesired: true},
+		Questions: []dnsmessage.Question{{Name: qname, Type: qtype, Class: dnsmessage.ClassINET}},
+	}
+	request, err := query.Pack()
+	if err != nil {
+		return "", err
+	}
+
+	// transport describes the used protocol(s) including a fallback to TCP on truncation
+	transport := "udp"
+	var response *dnsmessage.Message
+	if !options.TCP {
+		response, err = exchangeDNS("udp", addr, request, query.ID, options.Timeout)
+		if err != nil {
+			return "", fmt.Errorf("%w (%s)", err, transport)
+		}
+		if response.Truncated {
+			transport = "udp truncated, tcp fallback"
+			response = nil
+		}
+	} else {
+		transport = "tcp"
+	}
+	if response == nil {
+		response, err = exchangeDNS("tcp", addr, request, query.ID, options.Timeout)
+		if err != nil {
+			return "", fmt.Errorf("%w (%s)", err, transport)
+		}
+	}
+
+	var answers []string
+	for _, rr := range response.Answers {
+		switch body := rr.Body.(type) {
+		case *dnsmessage.AResource:
+			answers = append(answers, net.IP(body.A[:]).String())
+		case *dnsmessage.AAAAResource:
+			answers = append(answers, net.IP(body.AAAA[:]).String())
+		case *dnsmessage.SRVResource:
+			answers = append(answers, net.JoinHostPort(body.Target.String(), strconv.Itoa(int(body.Port))))
+		}
+	}
+	if response.RCode == dnsmessage.RCodeNameError {
+		return "", withFailureClass(nwpd.FailureClass_FAILURE_CLASS_DNS_NOT_FOUND, fmt.Errorf("%s (%s)", rcodeName(response.RCode), transport))
+	}
+	if response.RCode != dnsmessage.RCodeSuccess {
+		return "", withFailureClass(nwpd.FailureClass_FAILURE_CLASS_DNS, fmt.Errorf("%s (%s)", rcodeName(response.RCode), transport))
+	}
+	if len(answers) == 0 {
+		// NODATA: the name exists, but has no records of the queried type
+		return "", withFailureClass(nwpd.FailureClass_FAILURE_CLASS_DNS, fmt.Errorf("%s without answers (%s)", rcodeName(response.RCode), transport))
+	}
+	if len(options.Expected) > 0 && !containsAny(answers, options.Expected) {
+		return "", withFailureClass(nwpd.FailureClass_FAILURE_CLASS_DNS, fmt.Errorf("unexpected answers %s (%s)", strings.Join(answers, ","), transport))
+	}
+	return fmt.Sprintf("%s %s: %s %s (%s)", typeName(qtype), name, rcodeName(response.RCode), strings.Join(answers, ","), transport), nil
+}
+
+// exchangeDNS sends the request and reads the response with the given ID.
+func exchangeDNS(network, addr string, request []byte, id uint16, timeout time.Duration) (*dnsmessage.Message, error) {
+	conn, err := net.DialTimeout(network, addr, timeout)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return nil, err
+	}
+
+	if network == "tcp" {
+		msg := make([]byte, 2+len(request))
+		binary.BigEndian.PutUint16(msg, uint16(len(request))) // #nosec G115 -- DNS queries are small
+		copy(msg[2:], request)
+		if _, err := conn.Write(msg); err != nil {
+			return nil, err
+		}
+		length := make([]byte, 2)
+		if _, err := io.ReadFull(conn, length); err != nil {
+			return nil, err
+		}
+		buf := make([]byte, binary.BigEndian.Uint16(length))
+		if _, err := io.ReadFull(conn, buf); err != nil {
+			return nil, err
+		}
+		return parseDNSResponse(buf, id)
+	}
+
+	if _, err := conn.Write(request); err != nil {
+		return nil, err
+	}
+	buf := make([]byte, maxUDPDNSMessageSize)
+	for {
+		n, err := conn.Read(buf)
+		if err != nil {
+			return nil, err
+		}
+		response, err := parseDNSResponse(buf[:n], id)
+		if err != nil {
+			// ignore outdated or invalid responses
+			continue
+		}
+		return response, nil
+	}
+}
+
+func parseDNSResponse(buf []byte, id uint16) (*dnsmessage.Message, error) {
+	var parser dnsmessage.Parser
+	header, err := parser.Start(buf)
+	if err != nil {
+		return nil, err
+	}
+	if !header.Response || header.ID != id {
+		return nil, fmt.Errorf("unexpected response ID %d", header.ID)
+	}
+	if header.Truncated {
+		// the answers of a truncated response may be incomplete
+		return &dnsmessage.Message{Header: header}, nil
+	}
+	response := &dnsmessage.Message{}
+	if err := response.Unpack(buf); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func typeName(t dnsmessage.Type) string {
+	return strings.TrimPrefix(t.String(), "Type")
+}
+
+func rcodeName(rcode dnsmessage.RCode) string {
+	switch rcode {
+	case dnsmessage.RCodeSuccess:
+		return "NOERROR"
+	case dnsmessage.RCodeNameError:
+		return "NXDOMAIN"
+	default:
+		return strings.ToUpper(strings.TrimPrefix(rcode.String(), "RCode"))
+	}
+}
+
+func containsAny(values, candidates []string) bool {
+	for _, v := range values {
+		for _, c := range candidates {
+			if v == c {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,154 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runners
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+	"time"
+
+	"github.com/gardener/network-problem-detector/pkg/common/config"
+	"github.com/gardener/network-problem-detector/pkg/common/nwpd"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// fakeDNSServer answers A queries for 'example.com.' on UDP and TCP. UDP responses are truncated if truncateUDP is set.
+type fakeDNSServer struct {
+	udp         net.PacketConn
+	tcp         net.Listener
+	truncateUDP bool
+}
+
+func newFakeDNSServer(truncateUDP bool) *fakeDNSServer {
+	var (
+		udp net.PacketConn
+		tcp net.Listener
+		err error
+	)
+	// the TCP port may already be in use for the UDP port chosen randomly, so retry a few times
+	for i := 0; i < 10; i++ {
+		udp, err = net.ListenPacket("udp", "127.0.0.1:0")
+		Expect(err).To(BeNil())
+		if tcp, err = net.Listen("tcp", udp.LocalAddr().String()); err == nil {
+			break
+		}
+		_ = udp.Close()
+	}
+	Expect(err).To(BeNil())
+	s := &fakeDNSServer{udp: udp, tcp: tcp, truncateUDP: truncateUDP}
+	go s.serveUDP()
+	go s.serveTCP()
+	return s
+}
+
+func (s *fakeDNSServer) endpoint() config.Endpoint {
+	addr := s.udp.LocalAddr().(*net.UDPAddr)
+	return config.Endpoint{Hostname: "dns", IP: addr.IP.String(), Port: addr.Port}
+}
+
+func (s *fakeDNSServer) close() {
+	_ = s.udp.Close()
+	_ = s.tcp.Close()
+}
+
+func (s *fakeDNSServer) serveUDP() {
+	buf := make([]byte, 512)
+	for {
+		n, addr, err := s.udp.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		if response := s.answer(buf[:n], s.truncateUDP); response != nil {
+			_, _ = s.udp.WriteTo(response, addr)
+		}
+	}
+}
+
+func (s *fakeDNSServer) serveTCP() {
+	for {
+		conn, err := s.tcp.Accept()
+		if err != nil {
+			return
+		}
+		length := make([]byte, 2)
+		if _, err := io.ReadFull(conn, length); err == nil {
+			buf := make([]byte, binary.BigEndian.Uint16(length))
+			if _, err := io.ReadFull(conn, buf); err == nil {
+				if response := s.answer(buf, false); response != nil {
+					binary.BigEndian.PutUint16(length, uint16(len(response))) // #nosec G115 -- test
+					_, _ = conn.Write(append(length, response...))
+				}
+			}
+		}
+		_ = conn.Close()
+	}
+}
+
+func (s *fakeDNSServer) answer(request []byte, truncate bool) []byte {
+	var query dnsmessage.Message
+	if err := query.Unpack(request); err != nil || len(query.Questions) != 1 {
+		return nil
+	}
+	q := query.Questions[0]
+	response := dnsmessage.Message{
+		Header:    dnsmessage.Header{ID: query.ID, Response: true, Truncated: truncate},
+		Questions: query.Questions,
+	}
+	switch {
+	case truncate:
+	case q.Name.String() == "nodata.example.com.":
+	case q.Name.String() != "example.com.":
+		response.RCode = dnsmessage.RCodeNameError
+	case q.Type == dnsmessage.TypeA:
+		response.Answers = append(response.Answers, dnsmessage.Resource{
+			Header: dnsmessage.ResourceHeader{Name: q.Name, Type: dnsmessage.TypeA, Class: dnsmessage.ClassINET, TTL: 60},
+			Body:   &dnsmessage.AResource{A: [4]byte{10, 1, 2, 3}},
+		})
+	}
+	buf, err := response.Pack()
+	if err != nil {
+		return nil
+	}
+	return buf
+}
+
+var _ = Describe("checkDNS", func() {
+	DescribeTable("should query DNS server",
+		func(truncateUDP bool, options DNSQueryOptions, expected string, expectedErr string, expectedClass nwpd.FailureClass) {
+			server := newFakeDNSServer(truncateUDP)
+			defer server.close()
+
+			options.Types = []dnsmessage.Type{dnsmessage.TypeA}
+			options.Timeout = 1 * time.Second
+			result, err := checkDNSFunc(server.endpoint(), options)
+			if expectedErr != "" {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal(expectedErr))
+				Expect(classifyFailure(err)).To(Equal(expectedClass))
+				return
+			}
+			Expect(err).To(BeNil())
+			Expect(result).To(Equal(expected))
+		},
+		Entry("udp", false, DNSQueryOptions{Names: []string{"example.com."}},
+			"A example.com.: NOERROR 10.1.2.3 (udp)", "", nwpd.FailureClass_FAILURE_CLASS_UNSPECIFIED),
+		Entry("tcp", false, DNSQueryOptions{Names: []string{"example.com."}, TCP: true},
+			"A example.com.: NOERROR 10.1.2.3 (tcp)", "", nwpd.FailureClass_FAILURE_CLASS_UNSPECIFIED),
+		Entry("truncated udp with tcp fallback", true, DNSQueryOptions{Names: []string{"example.com."}},
+			"A example.com.: NOERROR 10.1.2.3 (udp truncated, tcp fallback)", "", nwpd.FailureClass_FAILURE_CLASS_UNSPECIFIED),
+		Entry("expected answer", false, DNSQueryOptions{Names: []string{"example.com."}, Expected: []string{"10.0.0.1", "10.1.2.3"}},
+			"A example.com.: NOERROR 10.1.2.3 (udp)", "", nwpd.FailureClass_FAILURE_CLASS_UNSPECIFIED),
+		Entry("unexpected answer", false, DNSQueryOptions{Names: []string{"example.com."}, Expected: []string{"10.0.0.1"}},
+			"", "A example.com.: unexpected answers 10.1.2.3 (udp)", nwpd.FailureClass_FAILURE_CLASS_DNS),
+		Entry("nxdomain", false, DNSQueryOptions{Names: []string{"example.com.", "foo.bar."}},
+			"", "A foo.bar.: NXDOMAIN (udp)", nwpd.FailureClass_FAILURE_CLASS_DNS_NOT_FOUND),
+		Entry("nodata", false, DNSQueryOptions{Names: []string{"nodata.example.com."}},
+			"", "A nodata.example.com.: NOERROR without answers (udp)", nwpd.FailureClass_FAILURE_CLASS_DNS),
+	)
+})
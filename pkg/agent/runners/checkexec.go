@@ -0,0 +1,213 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runners
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gardener/network-problem-detector/pkg/common/config"
+	"github.com/gardener/network-problem-detector/pkg/common/nwpd"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const (
+	// defaultExecTimeout is the default timeout of a command execution.
+	defaultExecTimeout = 10 * time.Second
+	// maxExecOutputSize is the maximum number of bytes of stdout and stderr kept from a command execution.
+	maxExecOutputSize = 64 * 1024
+	// execWaitDelay is the time to wait for the output pipes to be closed after the command has exited or was killed.
+	execWaitDelay = 1 * time.Second
+	// execDurationPrefix is the prefix of an output line overriding the measured duration.
+	execDurationPrefix = "duration="
+)
+
+// ExecOptions are the options for executing a custom probe command.
+// The placeholders `{hostname}`, `{ip}` and `{port}` in the arguments and environment values are replaced by the target endpoint.
+type ExecOptions struct {
+	// Command is the binary or script to execute.
+	Command string
+	// Args are the arguments of the command.
+	Args []string
+	// Env are additional environment variables in format `<name>=<value>`.
+	Env []string
+	// Timeout is the timeout of a command execution.
+	Timeout time.Duration
+}
+
+type checkExecArgs struct {
+	endpointArgs
+	command string
+	args    []string
+	env     []string
+}
+
+func (a *checkExecArgs) createRunner(_ *cobra.Command, _ []string) error {
+	if a.command == "" {
+		return fmt.Errorf("missing command")
+	}
+	for _, env := range a.env {
+		if name, _, ok := strings.Cut(env, "="); !ok || name == "" {
+			return fmt.Errorf("invalid env %s (expected format <name>=<value>)", env)
+		}
+	}
+	endpoints, err := a.buildEndpoints()
+	if err != nil {
+		return err
+	}
+	config := a.runnerArgs.prepareConfig()
+	options := ExecOptions{
+		Command: a.command,
+		Args:    a.args,
+		Env:     a.env,
+		Timeout: config.timeoutOr(defaultExecTimeout),
+	}
+
+	if r := NewCheckExec(endpoints, options, config); r != nil {
+		a.runnerArgs.runner = r
+	}
+	return nil
+}
+
+func createCheckExecCmd(ra *runnerArgs) *cobra.Command {
+	a := &checkExecArgs{endpointArgs: endpointArgs{runnerArgs: ra}}
+	cmd := &cobra.Command{
+		Use:   "checkExec",
+		Short: "runs a custom probe command for endpoints, exit code 0 means ok",
+		RunE:  a.createRunner,
+	}
+	a.addFlags(cmd)
+	cmd.Flags().StringVar(&a.command, "command", "", "binary or script to execute.")
+	cmd.Flags().StringArrayVar(&a.args, "arg", nil, "argument of the command (can be repeated). The placeholders {hostname}, {ip} and {port} are replaced by the endpoint.")
+	cmd.Flags().StringArrayVar(&a.env, "env", nil, "additional environment variable in format <name>=<value> (can be repeated). The placeholders {hostname}, {ip} and {port} are replaced by the endpoint.")
+	return cmd
+}
+
+func NewCheckExec(endpoints []config.Endpoint, options ExecOptions, rconfig RunnerConfig) Runner {
+	if len(endpoints) == 0 {
+		return nil
+	}
+	return &checkExec{
+		robinRound[config.Endpoint]{
+			itemsName: "endpoints",
+			items:     config.CloneAndShuffle(endpoints),
+			runFunc: func(endpoint config.Endpoint, obs *nwpd.Observation) (string, error) {
+				return checkExecFunc(endpoint, options, obs)
+			},
+			config: rconfig,
+		},
+	}
+}
+
+type checkExec struct {
+	robinRound[config.Endpoint]
+}
+
+var _ Runner = &checkExec{}
+
+func checkExecFunc(endpoint config.Endpoint, options ExecOptions, obs *nwpd.Observation) (string, error) {
+	replacer := strings.NewReplacer(
+		"{hostname}", strings.TrimSuffix(endpoint.Hostname, "."),
+		"{ip}", endpoint.IP,
+		"{port}", strconv.Itoa(endpoint.Port),
+	)
+	args := make([]string, len(options.Args))
+	for i, arg := range options.Args {
+		args[i] = replacer.Replace(arg)
+	}
+	env := os.Environ()
+	for _, e := range options.Env {
+		env = append(env, replacer.Replace(e))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), options.Timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, options.Command, args...) // #nosec G204 -- command is explicitly configured by job
+	cmd.Env = env
+	cmd.WaitDelay = execWaitDelay
+	stdout := &limitedBuffer{limit: maxExecOutputSize}
+	stderr := &limitedBuffer{limit: maxExecOutputSize}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	err := cmd.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		return "", withFailureClass(nwpd.FailureClass_FAILURE_CLASS_TIMEOUT, fmt.Errorf("timeout after %s", options.Timeout))
+	}
+	result, duration, parseErr := parseExecOutput(stdout.Bytes())
+	if err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return "", err
+		}
+		msg := result
+		if msg == "" {
+			msg, _, _ = parseExecOutput(stderr.Bytes())
+		}
+		if msg == "" {
+			return "", fmt.Errorf("exit code %d", exitErr.ExitCode())
+		}
+		return "", fmt.Errorf("exit code %d: %s", exitErr.ExitCode(), msg)
+	}
+	if parseErr != nil {
+		return "", parseErr
+	}
+	if duration != nil {
+		obs.Duration = durationpb.New(*duration)
+	}
+	if result == "" {
+		result = "exit code 0"
+	}
+	return result, nil
+}
+
+// parseExecOutput returns the first line of the output, which is not a duration line, and the optional duration.
+func parseExecOutput(output []byte) (result string, duration *time.Duration, err error) {
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if value, ok := strings.CutPrefix(line, execDurationPrefix); ok {
+			d, perr := time.ParseDuration(value)
+			if perr != nil || d < 0 {
+				err = fmt.Errorf("invalid duration output %q", line)
+				continue
+			}
+			duration = &d
+			continue
+		}
+		if result == "" && line != "" {
+			result = line
+		}
+	}
+	return
+}
+
+// limitedBuffer is a buffer discarding everything written beyond its limit.
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if remaining := b.limit - b.Len(); remaining > 0 {
+		if len(p) > remaining {
+			_, _ = b.Buffer.Write(p[:remaining])
+		} else {
+			_, _ = b.Buffer.Write(p)
+		}
+	}
+	// report everything as written to avoid blocking the command
+	return len(p), nil
+}
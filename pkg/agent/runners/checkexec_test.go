@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runners
+
+import (
+	"time"
+
+	"github.com/gardener/network-problem-detector/pkg/common/config"
+	"github.com/gardener/network-problem-detector/pkg/common/nwpd"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("checkExec", func() {
+	endpoint := config.Endpoint{Hostname: "server.example.com.", IP: "10.0.0.9", Port: 55555}
+
+	DescribeTable("should run the command",
+		func(script string, expectedResult string, expectedErr string, expectedDuration time.Duration) {
+			options := ExecOptions{
+				Command: "/bin/sh",
+				Args:    []string{"-c", script, "probe", "{hostname}", "{ip}", "{port}"},
+				Env:     []string{"TARGET={ip}:{port}"},
+				Timeout: time.Second,
+			}
+			obs := &nwpd.Observation{}
+			result, err := checkExecFunc(endpoint, options, obs)
+			if expectedErr != "" {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal(expectedErr))
+				return
+			}
+			Expect(err).To(BeNil())
+			Expect(result).To(Equal(expectedResult))
+			if expectedDuration != 0 {
+				Expect(obs.Duration.AsDuration()).To(Equal(expectedDuration))
+			} else {
+				Expect(obs.Duration).To(BeNil())
+			}
+		},
+		Entry("placeholders in args", `echo "$1 $2 $3"; echo second line`, "server.example.com 10.0.0.9 55555", "", time.Duration(0)),
+		Entry("placeholders in env", `echo "target $TARGET"`, "target 10.0.0.9:55555", "", time.Duration(0)),
+		Entry("duration override", `echo duration=150ms; echo ok`, "ok", "", 150*time.Millisecond),
+		Entry("no output", `true`, "exit code 0", "", time.Duration(0)),
+		Entry("non-zero exit code", `echo failed; exit 3`, "", "exit code 3: failed", time.Duration(0)),
+		Entry("non-zero exit code with stderr", `echo "no route" >&2; exit 1`, "", "exit code 1: no route", time.Duration(0)),
+		Entry("invalid duration", `echo duration=soon; echo ok`, "", `invalid duration output "duration=soon"`, time.Duration(0)),
+		Entry("timeout", `sleep 10`, "", "timeout after 1s", time.Duration(0)),
+	)
+})
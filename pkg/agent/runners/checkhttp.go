@@ -0,0 +1,261 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runners
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gardener/network-problem-detector/pkg/common/config"
+	"github.com/gardener/network-problem-detector/pkg/common/nwpd"
+
+	"github.com/spf13/cobra"
+)
+
+// maxHTTPBodySize is the maximum number of bytes of the response body matched against the body regex.
+const maxHTTPBodySize = 1 << 20
+
+// HTTPOptions are the options for HTTP(S) requests.
+type HTTPOptions struct {
+	// HTTPS if the request uses the HTTPS scheme.
+	HTTPS bool
+	// Method is the HTTP method.
+	Method string
+	// Path is the request path including an optional query.
+	Path string
+	// Headers are additional request headers.
+	Headers http.Header
+	// MinStatus is the minimal expected status code.
+	MinStatus int
+	// MaxStatus is the maximal expected status code.
+	MaxStatus int
+	// BodyRegex is an optional regular expression the response body must match.
+	BodyRegex *regexp.Regexp
+	// RootCAs are the CAs used for TLS verification. If nil, the system CAs are used.
+	RootCAs *x509.CertPool
+	// ServerName overrides the server name used for SNI and TLS verification.
+	ServerName string
+	// InsecureSkipVerify disables the TLS verification.
+	InsecureSkipVerify bool
+	// Timeout is the timeout of a request.
+	Timeout time.Duration
+}
+
+type checkHTTPArgs struct {
+	endpointArgs
+	scheme             string
+	method             string
+	path               string
+	headers            []string
+	expectedStatus     string
+	bodyRegex          string
+	caBundle           string
+	serverName         string
+	insecureSkipVerify bool
+}
+
+func (a *checkHTTPArgs) createRunner(_ *cobra.Command, _ []string) error {
+	endpoints, err := a.buildEndpoints()
+	if err != nil {
+		return err
+	}
+	config := a.runnerArgs.prepareConfig()
+	options, err := a.buildOptions(config.timeoutOr(10 * time.Second))
+	if err != nil {
+		return err
+	}
+
+	if r := NewCheckHTTP(endpoints, *options, config); r != nil {
+		a.runnerArgs.runner = r
+	}
+	return nil
+}
+
+func (a *checkHTTPArgs) buildOptions(timeout time.Duration) (*HTTPOptions, error) {
+	options := &HTTPOptions{
+		Method:             strings.ToUpper(a.method),
+		Path:               a.path,
+		Headers:            http.Header{},
+		ServerName:         a.serverName,
+		InsecureSkipVerify: a.insecureSkipVerify,
+		Timeout:            timeout,
+	}
+	switch strings.ToLower(a.scheme) {
+	case "http":
+	case "https":
+		options.HTTPS = true
+	default:
+		return nil, fmt.Errorf("unsupported scheme %s", a.scheme)
+	}
+	if !strings.HasPrefix(options.Path, "/") {
+		options.Path = "/" + options.Path
+	}
+	for _, header := range a.headers {
+		parts := strings.SplitN(header, ":", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("invalid header %s", header)
+		}
+		options.Headers.Add(strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]))
+	}
+	minStatus, maxStatus, err := parseStatusRange(a.expectedStatus)
+	if err != nil {
+		return nil, err
+	}
+	options.MinStatus, options.MaxStatus = minStatus, maxStatus
+	if a.bodyRegex != "" {
+		options.BodyRegex, err = regexp.Compile(a.bodyRegex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid body regex: %w", err)
+		}
+	}
+	if a.caBundle != "" {
+		if options.RootCAs, err = loadCABundle(a.caBundle); err != nil {
+			return nil, err
+		}
+	}
+	return options, nil
+}
+
+// parseStatusRange parses a status code range in format `<min>-<max>` or a single status code.
+func parseStatusRange(s string) (int, int, error) {
+	parts := strings.SplitN(s, "-", 2)
+	minStatus, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid expected status %s", s)
+	}
+	maxStatus := minStatus
+	if len(parts) == 2 {
+		maxStatus, err = strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid expected status %s", s)
+		}
+	}
+	if minStatus < 100 || maxStatus > 599 || minStatus > maxStatus {
+		return 0, 0, fmt.Errorf("invalid expected status %s", s)
+	}
+	return minStatus, maxStatus, nil
+}
+
+func createCheckHTTPCmd(ra *runnerArgs) *cobra.Command {
+	a := &checkHTTPArgs{endpointArgs: endpointArgs{runnerArgs: ra}}
+	cmd := &cobra.Command{
+		Use:   "checkHTTP",
+		Short: "performs an HTTP(S) request to the given endpoints and checks the response",
+		RunE:  a.createRunner,
+	}
+	a.addFlags(cmd)
+	cmd.Flags().StringVar(&a.scheme, "scheme", "https", "URL scheme (http, https).")
+	cmd.Flags().StringVar(&a.method, "method", http.MethodGet, "HTTP method.")
+	cmd.Flags().StringVar(&a.path, "path", "/", "request path including optional query.")
+	cmd.Flags().StringArrayVar(&a.headers, "header", nil, "additional request header in format '<name>: <value>' (can be repeated).")
+	cmd.Flags().StringVar(&a.expectedStatus, "expected-status", "200-399", "expected status code or range in format <min>-<max>.")
+	cmd.Flags().StringVar(&a.bodyRegex, "body-regex", "", "optional regular expression the response body must match.")
+	cmd.Flags().StringVar(&a.caBundle, "ca-bundle", "", "file containing CA certificates for TLS verification. If not specified, the system CAs are used.")
+	cmd.Flags().StringVar(&a.serverName, "server-name", "", "overrides the server name used for SNI and TLS verification.")
+	cmd.Flags().BoolVar(&a.insecureSkipVerify, "insecure-skip-verify", false, "disables TLS verification.")
+	return cmd
+}
+
+func NewCheckHTTP(endpoints []config.Endpoint, options HTTPOptions, rconfig RunnerConfig) Runner {
+	if len(endpoints) == 0 {
+		return nil
+	}
+	return &checkHTTP{
+		robinRound[config.Endpoint]{
+			itemsName: "endpoints",
+			items:     config.CloneAndShuffle(endpoints),
+			runFunc: func(endpoint config.Endpoint, obs *nwpd.Observation) (string, error) {
+				return checkHTTPFunc(endpoint, options, obs)
+			},
+			config: rconfig,
+		},
+	}
+}
+
+type checkHTTP struct {
+	robinRound[config.Endpoint]
+}
+
+var _ Runner = &checkHTTP{}
+
+func checkHTTPFunc(endpoint config.Endpoint, options HTTPOptions, obs *nwpd.Observation) (string, error) {
+	serverName := options.ServerName
+	if serverName == "" {
+		serverName = strings.TrimSuffix(endpoint.Hostname, ".")
+	}
+	dialer := &net.Dialer{}
+	tr := &http.Transport{
+		DisableKeepAlives: true,
+		TLSClientConfig: &tls.Config{
+			RootCAs:            options.RootCAs,
+			ServerName:         serverName,
+			InsecureSkipVerify: options.InsecureSkipVerify, // #nosec G402 -- explicitly requested by job configuration
+			MinVersion:         tls.VersionTLS12,
+		},
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			if endpoint.IP != "" {
+				// connect to the known IP address instead of resolving the hostname
+				addr = net.JoinHostPort(endpoint.IP, strconv.Itoa(endpoint.Port))
+			}
+			return dialer.DialContext(ctx, network, addr)
+		},
+	}
+	client := &http.Client{
+		Transport: tr,
+		Timeout:   options.Timeout,
+		CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	scheme := "http"
+	if options.HTTPS {
+		scheme = "https"
+	}
+	url := fmt.Sprintf("%s://%s%s", scheme, net.JoinHostPort(strings.TrimSuffix(endpoint.Hostname, "."), strconv.Itoa(endpoint.Port)), options.Path)
+	req, err := http.NewRequest(options.Method, url, nil)
+	if err != nil {
+		return "", err
+	}
+	for name, values := range options.Headers {
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+	if host := options.Headers.Get("Host"); host != "" {
+		req.Host = host
+	}
+
+	tracer := &phaseTracer{}
+	resp, err := client.Do(tracer.withTrace(req))
+	tracer.apply(obs)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < options.MinStatus || resp.StatusCode > options.MaxStatus {
+		return "", withFailureClass(nwpd.FailureClass_FAILURE_CLASS_HTTP_STATUS, fmt.Errorf("unexpected status %s (expected %d-%d)", resp.Status, options.MinStatus, options.MaxStatus))
+	}
+	if options.BodyRegex != nil {
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxHTTPBodySize))
+		if err != nil {
+			return "", fmt.Errorf("reading body failed: %w", err)
+		}
+		if !options.BodyRegex.Match(body) {
+			return "", withFailureClass(nwpd.FailureClass_FAILURE_CLASS_HTTP_STATUS, fmt.Errorf("status %s, body does not match %q", resp.Status, options.BodyRegex.String()))
+		}
+	}
+	return resp.Status, nil
+}
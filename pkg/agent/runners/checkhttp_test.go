@@ -0,0 +1,152 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runners
+
+import (
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/gardener/network-problem-detector/pkg/common/config"
+	"github.com/gardener/network-problem-detector/pkg/common/nwpd"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("checkHTTP", func() {
+	var (
+		server  *httptest.Server
+		rootCAs *x509.CertPool
+	)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/healthz":
+			if r.Header.Get("X-Test") != "foo" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_, _ = fmt.Fprintf(w, "ok %s %s", r.Method, r.Host)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+
+	endpoint := func() config.Endpoint {
+		host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+		Expect(err).To(BeNil())
+		p, err := strconv.Atoi(port)
+		Expect(err).To(BeNil())
+		// the hostname is not resolvable, the request is sent to the IP address
+		return config.Endpoint{Hostname: "example.com", IP: host, Port: p}
+	}
+
+	defaultOptions := func(https bool) HTTPOptions {
+		return HTTPOptions{
+			HTTPS:      https,
+			Method:     http.MethodGet,
+			Path:       "/healthz",
+			Headers:    http.Header{"X-Test": []string{"foo"}},
+			MinStatus:  200,
+			MaxStatus:  299,
+			RootCAs:    rootCAs,
+			ServerName: "example.com",
+			Timeout:    5 * time.Second,
+		}
+	}
+
+	Context("with HTTP", func() {
+		BeforeEach(func() {
+			server = httptest.NewServer(handler)
+		})
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("should succeed with expected status and body", func() {
+			options := defaultOptions(false)
+			options.Method = http.MethodPost
+			options.BodyRegex = regexp.MustCompile(`^ok POST example\.com:\d+$`)
+			result, err := checkHTTPFunc(endpoint(), options, &nwpd.Observation{})
+			Expect(err).To(BeNil())
+			Expect(result).To(Equal("200 OK"))
+		})
+
+		It("should fail on unexpected status", func() {
+			options := defaultOptions(false)
+			options.Path = "/foo"
+			_, err := checkHTTPFunc(endpoint(), options, &nwpd.Observation{})
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(Equal("unexpected status 500 Internal Server Error (expected 200-299)"))
+		})
+
+		It("should fail on body mismatch", func() {
+			options := defaultOptions(false)
+			options.BodyRegex = regexp.MustCompile("^healthy$")
+			_, err := checkHTTPFunc(endpoint(), options, &nwpd.Observation{})
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(ContainSubstring("body does not match"))
+		})
+
+		It("should fail on timeout", func() {
+			blocking := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+				time.Sleep(500 * time.Millisecond)
+			}))
+			defer blocking.Close()
+			server = blocking
+			options := defaultOptions(false)
+			options.Timeout = 50 * time.Millisecond
+			_, err := checkHTTPFunc(endpoint(), options, &nwpd.Observation{})
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(ContainSubstring("Client.Timeout exceeded"))
+		})
+	})
+
+	Context("with HTTPS", func() {
+		BeforeEach(func() {
+			server = httptest.NewTLSServer(handler)
+			rootCAs = x509.NewCertPool()
+			rootCAs.AddCert(server.Certificate())
+		})
+		AfterEach(func() {
+			server.Close()
+			rootCAs = nil
+		})
+
+		It("should verify the server certificate with the CA bundle and SNI override", func() {
+			obs := &nwpd.Observation{}
+			result, err := checkHTTPFunc(endpoint(), defaultOptions(true), obs)
+			Expect(err).To(BeNil())
+			Expect(result).To(Equal("200 OK"))
+			Expect(obs.PhaseTimings).NotTo(BeNil())
+			Expect(obs.PhaseTimings.Dns).To(BeNil())
+			Expect(obs.PhaseTimings.Connect).NotTo(BeNil())
+			Expect(obs.PhaseTimings.Tls).NotTo(BeNil())
+			Expect(obs.PhaseTimings.Ttfb).NotTo(BeNil())
+		})
+
+		It("should fail if the server certificate is not valid for the server name", func() {
+			options := defaultOptions(true)
+			options.ServerName = "foo.bar"
+			_, err := checkHTTPFunc(endpoint(), options, &nwpd.Observation{})
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(ContainSubstring("certificate is valid for"))
+		})
+
+		It("should fail with unknown CA", func() {
+			options := defaultOptions(true)
+			options.RootCAs = x509.NewCertPool()
+			_, err := checkHTTPFunc(endpoint(), options, &nwpd.Observation{})
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(ContainSubstring("certificate signed by unknown authority"))
+		})
+	})
+})
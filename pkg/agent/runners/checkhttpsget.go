@@ -10,13 +10,18 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gardener/network-problem-detector/pkg/common"
 	"github.com/gardener/network-problem-detector/pkg/common/config"
+	"github.com/gardener/network-problem-detector/pkg/common/nwpd"
 
 	"github.com/spf13/cobra"
 )
 
+// defaultHTTPSGetTimeout is the default timeout of a request.
+const defaultHTTPSGetTimeout = 30 * time.Second
+
 type checkHTTPSGetArgs struct {
 	runnerArgs   *runnerArgs
 	internalKAPI bool
@@ -93,8 +98,10 @@ func NewCheckHTTPSGet(endpoints []config.Endpoint, rconfig RunnerConfig) Runner
 		robinRound[config.Endpoint]{
 			itemsName: "endpoints",
 			items:     config.CloneAndShuffle(endpoints),
-			runFunc:   checkHTTPSGetFunc,
-			config:    rconfig,
+			runFunc: func(endpoint config.Endpoint, obs *nwpd.Observation) (string, error) {
+				return checkHTTPSGetFunc(endpoint, rconfig.timeoutOr(defaultHTTPSGetTimeout), obs)
+			},
+			config: rconfig,
 		},
 	}
 }
@@ -105,13 +112,19 @@ type checkHTTPSGet struct {
 
 var _ Runner = &checkHTTPSGet{}
 
-func checkHTTPSGetFunc(endpoint config.Endpoint) (string, error) {
+func checkHTTPSGetFunc(endpoint config.Endpoint, timeout time.Duration, obs *nwpd.Observation) (string, error) {
 	tr := &http.Transport{
 		TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, // #nosec G402 -- connection check only, no sensitive data
 	}
-	client := &http.Client{Transport: tr}
+	client := &http.Client{Transport: tr, Timeout: timeout}
 	url := fmt.Sprintf("https://%s:%d", endpoint.Hostname, endpoint.Port)
-	resp, err := client.Get(url)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	tracer := &phaseTracer{}
+	resp, err := client.Do(tracer.withTrace(req))
+	tracer.apply(obs)
 	if err != nil {
 		return "", err
 	}
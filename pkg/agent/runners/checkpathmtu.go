@@ -0,0 +1,224 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runners
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/gardener/network-problem-detector/pkg/common/config"
+	"github.com/gardener/network-problem-detector/pkg/common/nwpd"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	// ipv4UDPHeaderSize is the size of the IPv4 and UDP headers of a probe packet.
+	ipv4UDPHeaderSize = 20 + 8
+	// ipv6UDPHeaderSize is the size of the IPv6 and UDP headers of a probe packet.
+	ipv6UDPHeaderSize = 40 + 8
+	// minPathMTU is the smallest MTU which can be probed.
+	minPathMTU = 576
+	// defaultMTUFloor is the default minimal path MTU which is considered as ok. It is the minimum link MTU of IPv6,
+	// a smaller path MTU is a sign of a misconfigured overlay network.
+	defaultMTUFloor = 1280
+	// pathMTUProbeAttempts is the number of attempts per probe size to distinguish packet loss from a too large packet.
+	pathMTUProbeAttempts = 2
+	// defaultPathMTUProbeTimeout is the default time to wait for the echo response of a single probe.
+	defaultPathMTUProbeTimeout = 500 * time.Millisecond
+)
+
+// PathMTUOptions are the options for the path MTU discovery.
+type PathMTUOptions struct {
+	// Sizes are explicit MTU sizes to probe. If empty, all sizes between MinMTU and MaxMTU are candidates.
+	Sizes []int
+	// MinMTU is the smallest MTU to probe.
+	MinMTU int
+	// MaxMTU is the largest MTU to probe.
+	MaxMTU int
+	// MTUFloor is the minimal path MTU which is considered as ok (disabled if 0).
+	MTUFloor int
+	// ProbeTimeout is the time to wait for the echo response of a single probe.
+	ProbeTimeout time.Duration
+}
+
+// DefaultPathMTUOptions returns the default options probing MTUs between 1200 and 1500 and failing below 1280.
+func DefaultPathMTUOptions() PathMTUOptions {
+	return PathMTUOptions{
+		MinMTU:       1200,
+		MaxMTU:       1500,
+		MTUFloor:     defaultMTUFloor,
+		ProbeTimeout: defaultPathMTUProbeTimeout,
+	}
+}
+
+// candidates returns the ascending list of MTU sizes to probe.
+func (o PathMTUOptions) candidates() []int {
+	if len(o.Sizes) > 0 {
+		sizes := append([]int{}, o.Sizes...)
+		sort.Ints(sizes)
+		return sizes
+	}
+	var sizes []int
+	for mtu := o.MinMTU; mtu <= o.MaxMTU; mtu++ {
+		sizes = append(sizes, mtu)
+	}
+	return sizes
+}
+
+type checkPathMTUArgs struct {
+	endpointArgs
+	options PathMTUOptions
+}
+
+func (a *checkPathMTUArgs) createRunner(_ *cobra.Command, _ []string) error {
+	endpoints, err := a.buildEndpoints()
+	if err != nil {
+		return err
+	}
+	candidates := a.options.candidates()
+	if len(candidates) == 0 {
+		return fmt.Errorf("invalid MTU range [%d,%d]", a.options.MinMTU, a.options.MaxMTU)
+	}
+	if candidates[0] < minPathMTU {
+		return fmt.Errorf("invalid MTU size %d (minimum: %d)", candidates[0], minPathMTU)
+	}
+	if a.options.MTUFloor < 0 {
+		return fmt.Errorf("invalid MTU floor %d", a.options.MTUFloor)
+	}
+
+	config := a.runnerArgs.prepareConfig()
+	a.options.ProbeTimeout = config.timeoutOr(defaultPathMTUProbeTimeout)
+	if r := NewCheckPathMTU(endpoints, a.options, config); r != nil {
+		a.runnerArgs.runner = r
+	}
+	return nil
+}
+
+func createCheckPathMTUCmd(ra *runnerArgs) *cobra.Command {
+	a := &checkPathMTUArgs{endpointArgs: endpointArgs{runnerArgs: ra}}
+	cmd := &cobra.Command{
+		Use:   "checkPathMTU",
+		Short: "discovers the path MTU to the UDP echo responder of an agent using packets with don't-fragment bit",
+		RunE:  a.createRunner,
+	}
+	a.addFlags(cmd)
+	defaults := DefaultPathMTUOptions()
+	cmd.Flags().IntSliceVar(&a.options.Sizes, "sizes", nil, "explicit MTU sizes to probe as alternative to the range given by min-mtu and max-mtu.")
+	cmd.Flags().IntVar(&a.options.MinMTU, "min-mtu", defaults.MinMTU, "smallest MTU to probe.")
+	cmd.Flags().IntVar(&a.options.MaxMTU, "max-mtu", defaults.MaxMTU, "largest MTU to probe.")
+	cmd.Flags().IntVar(&a.options.MTUFloor, "mtu-floor", defaults.MTUFloor, "minimal path MTU considered as ok (disabled if 0).")
+	return cmd
+}
+
+func NewCheckPathMTU(endpoints []config.Endpoint, options PathMTUOptions, rconfig RunnerConfig) Runner {
+	if len(endpoints) == 0 {
+		return nil
+	}
+	return &checkPathMTU{
+		robinRound: robinRound[config.Endpoint]{
+			itemsName: "endpoints",
+			items:     config.CloneAndShuffle(endpoints),
+			runFunc: func(endpoint config.Endpoint, obs *nwpd.Observation) (string, error) {
+				return checkPathMTUFunc(endpoint, options, obs)
+			},
+			config: rconfig,
+		},
+		options: options,
+	}
+}
+
+type checkPathMTU struct {
+	robinRound[config.Endpoint]
+	options PathMTUOptions
+}
+
+// TestOptions returns the options for testing.
+func (r *checkPathMTU) TestOptions() any {
+	return r.options
+}
+
+var _ Runner = &checkPathMTU{}
+
+func checkPathMTUFunc(endpoint config.Endpoint, options PathMTUOptions, obs *nwpd.Observation) (string, error) {
+	headerSize := ipv4UDPHeaderSize
+	if ip := net.ParseIP(endpoint.IP); ip != nil && ip.To4() == nil {
+		headerSize = ipv6UDPHeaderSize
+	}
+	dialer := net.Dialer{Control: setDontFragment}
+	conn, err := dialer.Dial("udp", net.JoinHostPort(endpoint.IP, strconv.Itoa(endpoint.Port)))
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	probes := 0
+	mtu, err := searchPathMTU(options.candidates(), func(mtu int) (bool, error) {
+		probes++
+		for i := 0; i < pathMTUProbeAttempts; i++ {
+			_, err := udpEcho(conn, mtu-headerSize, options.ProbeTimeout)
+			switch {
+			case err == nil:
+				return true, nil
+			case errors.Is(err, syscall.EMSGSIZE):
+				// packet is larger than the MTU of the local interface or a known path MTU
+				return false, nil
+			case isTimeout(err):
+				continue
+			default:
+				return false, err
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	if mtu == 0 {
+		return "", fmt.Errorf("no echo response for MTU %d", options.candidates()[0])
+	}
+	obs.PathMTU = int32(mtu) // #nosec G115 -- MTU sizes are small
+	result := fmt.Sprintf("path MTU %d (%d probes)", mtu, probes)
+	if mtu < options.MTUFloor {
+		return "", fmt.Errorf("%s below floor %d", result, options.MTUFloor)
+	}
+	return result, nil
+}
+
+// searchPathMTU performs a binary search for the largest candidate passing the probe.
+// The candidates must be sorted ascending. Returns 0 if even the smallest candidate fails.
+func searchPathMTU(candidates []int, probe func(mtu int) (bool, error)) (int, error) {
+	if len(candidates) == 0 {
+		return 0, nil
+	}
+	ok, err := probe(candidates[0])
+	if err != nil || !ok {
+		return 0, err
+	}
+	lo, hi := 0, len(candidates)-1
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		ok, err := probe(candidates[mid])
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	return candidates[lo], nil
+}
+
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
@@ -0,0 +1,32 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runners
+
+import (
+	"math/bits"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("checkPathMTU", func() {
+	DescribeTable("should search path MTU",
+		func(candidates []int, pathMTU int, expected int) {
+			probes := 0
+			actual, err := searchPathMTU(candidates, func(mtu int) (bool, error) {
+				probes++
+				return mtu <= pathMTU, nil
+			})
+			Expect(err).To(BeNil())
+			Expect(actual).To(Equal(expected))
+			Expect(probes).To(BeNumerically("<=", 1+bits.Len(uint(len(candidates)))))
+		},
+		Entry("full range", DefaultPathMTUOptions().candidates(), 1450, 1450),
+		Entry("maximum", DefaultPathMTUOptions().candidates(), 9000, 1500),
+		Entry("minimum", DefaultPathMTUOptions().candidates(), 1200, 1200),
+		Entry("below minimum", DefaultPathMTUOptions().candidates(), 1000, 0),
+		Entry("explicit sizes", PathMTUOptions{Sizes: []int{1500, 1400, 1450}}.candidates(), 1460, 1450),
+	)
+})
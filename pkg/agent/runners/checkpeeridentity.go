@@ -0,0 +1,185 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runners
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gardener/network-problem-detector/pkg/common"
+	"github.com/gardener/network-problem-detector/pkg/common/config"
+	"github.com/gardener/network-problem-detector/pkg/common/nwpd"
+
+	"github.com/spf13/cobra"
+)
+
+// EchoPath is the path of the HTTP echo endpoint of the agent.
+const EchoPath = "/echo"
+
+// maxEchoResponseSize is the maximum size of a response of the HTTP echo endpoint.
+const maxEchoResponseSize = 4096
+
+// EchoResponse is the response of the HTTP echo endpoint of the agent.
+type EchoResponse struct {
+	// NodeName is the name of the node of the responding agent.
+	NodeName string `json:"nodeName"`
+	// PodName is the name of the responding agent pod.
+	PodName string `json:"podName"`
+	// ClientIP is the source IP address of the request as seen by the responding agent.
+	ClientIP string `json:"clientIP"`
+	// Timestamp is the time of the response.
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// PeerIdentityOptions are the options for checking the identity of peer agents.
+type PeerIdentityOptions struct {
+	// SourceIPs are the expected source IP addresses seen by the peer. If empty, the source IP address is not checked.
+	SourceIPs []string
+	// Timeout is the timeout of a request.
+	Timeout time.Duration
+}
+
+type checkPeerIdentityArgs struct {
+	peerEndpointArgs
+	checkSourceIP bool
+}
+
+func (a *checkPeerIdentityArgs) createRunner(_ *cobra.Command, _ []string) error {
+	endpoints, err := a.buildPeerEndpoints()
+	if err != nil {
+		return err
+	}
+	config := a.runnerArgs.prepareConfig()
+	options := PeerIdentityOptions{Timeout: config.timeoutOr(5 * time.Second)}
+	if a.checkSourceIP {
+		options.SourceIPs = ownPodIPs()
+	}
+
+	if r := NewCheckPeerIdentity(endpoints, options, config); r != nil {
+		a.runnerArgs.runner = r
+	}
+	return nil
+}
+
+// ownPodIPs returns the IP addresses of the agent pod provided by the downward API.
+func ownPodIPs() []string {
+	value := os.Getenv(common.EnvPodIPs)
+	if value == "" {
+		value = os.Getenv(common.EnvPodIP)
+	}
+	var ips []string
+	for _, ip := range strings.Split(value, ",") {
+		if ip = strings.TrimSpace(ip); ip != "" {
+			ips = append(ips, ip)
+		}
+	}
+	return ips
+}
+
+func createCheckPeerIdentityCmd(ra *runnerArgs) *cobra.Command {
+	a := &checkPeerIdentityArgs{peerEndpointArgs: peerEndpointArgs{runnerArgs: ra}}
+	cmd := &cobra.Command{
+		Use:   "checkPeerIdentity",
+		Short: "calls the HTTP echo endpoint of peer agents and checks their node and pod names",
+		RunE:  a.createRunner,
+	}
+	a.addFlags(cmd)
+	cmd.Flags().BoolVar(&a.checkSourceIP, "check-source-ip", false, "if the source IP address seen by the peer must be an IP address of the agent pod (detects SNAT).")
+	return cmd
+}
+
+func NewCheckPeerIdentity(endpoints []PeerEndpoint, options PeerIdentityOptions, rconfig RunnerConfig) Runner {
+	if len(endpoints) == 0 {
+		return nil
+	}
+	return &checkPeerIdentity{
+		robinRound[PeerEndpoint]{
+			itemsName: "endpoints",
+			items:     config.CloneAndShuffle(endpoints),
+			runFunc: func(endpoint PeerEndpoint, _ *nwpd.Observation) (string, error) {
+				return checkPeerIdentityFunc(endpoint, options)
+			},
+			config: rconfig,
+		},
+	}
+}
+
+type checkPeerIdentity struct {
+	robinRound[PeerEndpoint]
+}
+
+var _ Runner = &checkPeerIdentity{}
+
+func checkPeerIdentityFunc(endpoint PeerEndpoint, options PeerIdentityOptions) (string, error) {
+	client := &http.Client{
+		Transport: &http.Transport{DisableKeepAlives: true},
+		Timeout:   options.Timeout,
+	}
+	echo, err := requestEcho(client, endpoint.Endpoint)
+	if err != nil {
+		return "", err
+	}
+
+	result := fmt.Sprintf("node %s, pod %s, source IP %s", echo.NodeName, echo.PodName, echo.ClientIP)
+	if err := checkIdentity(endpoint, echo); err != nil {
+		return "", fmt.Errorf("%w, got %s", err, result)
+	}
+	if len(options.SourceIPs) > 0 && !containsIP(options.SourceIPs, echo.ClientIP) {
+		return "", fmt.Errorf("unexpected source IP (SNAT?): expected one of %s, got %s", strings.Join(options.SourceIPs, ","), result)
+	}
+	return result, nil
+}
+
+// checkIdentity checks if the echo response is sent by the expected peer agent.
+func checkIdentity(endpoint PeerEndpoint, echo *EchoResponse) error {
+	if echo.NodeName != endpoint.expectedNodeName() || (endpoint.Podname != "" && echo.PodName != endpoint.Podname) {
+		expected := "node " + endpoint.expectedNodeName()
+		if endpoint.Podname != "" {
+			expected += ", pod " + endpoint.Podname
+		}
+		return fmt.Errorf("identity mismatch: expected %s", expected)
+	}
+	return nil
+}
+
+// requestEcho calls the HTTP echo endpoint of the agent at the given endpoint.
+func requestEcho(client *http.Client, endpoint config.Endpoint) (*EchoResponse, error) {
+	url := fmt.Sprintf("http://%s%s", net.JoinHostPort(endpoint.IP, strconv.Itoa(endpoint.Port)), EchoPath)
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxEchoResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("reading body failed: %w", err)
+	}
+	echo := &EchoResponse{}
+	if err := json.Unmarshal(body, echo); err != nil {
+		return nil, fmt.Errorf("invalid echo response: %w", err)
+	}
+	return echo, nil
+}
+
+// containsIP checks if the list contains the IP address, ignoring differences in the notation.
+func containsIP(ips []string, ip string) bool {
+	parsed := net.ParseIP(ip)
+	for _, s := range ips {
+		if s == ip || (parsed != nil && parsed.Equal(net.ParseIP(s))) {
+			return true
+		}
+	}
+	return false
+}
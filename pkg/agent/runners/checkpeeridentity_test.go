@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runners
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"time"
+
+	"github.com/gardener/network-problem-detector/pkg/common/config"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("checkPeerIdentity", func() {
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != EchoPath {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			clientIP, _, _ := net.SplitHostPort(r.RemoteAddr)
+			_ = json.NewEncoder(w).Encode(&EchoResponse{
+				NodeName:  "node1",
+				PodName:   "pod1",
+				ClientIP:  clientIP,
+				Timestamp: time.Now(),
+			})
+		}))
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	endpoint := func(hostname, podname string) PeerEndpoint {
+		host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+		Expect(err).To(BeNil())
+		p, err := strconv.Atoi(port)
+		Expect(err).To(BeNil())
+		return PeerEndpoint{Endpoint: config.Endpoint{Hostname: hostname, IP: host, Port: p}, Podname: podname}
+	}
+	options := PeerIdentityOptions{Timeout: 5 * time.Second}
+
+	DescribeTable("identity",
+		func(hostname, nodename, podname, sourceIP, expectedErr string) {
+			opts := options
+			if sourceIP != "" {
+				opts.SourceIPs = []string{sourceIP}
+			}
+			ep := endpoint(hostname, podname)
+			ep.NodeName = nodename
+			result, err := checkPeerIdentityFunc(ep, opts)
+			if expectedErr == "" {
+				Expect(err).To(BeNil())
+				Expect(result).To(Equal("node node1, pod pod1, source IP 127.0.0.1"))
+			} else {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(Equal(expectedErr))
+			}
+		},
+		Entry("matching pod", "node1", "", "pod1", "", ""),
+		Entry("matching node", "node1.", "", "", "", ""),
+		Entry("matching node name with different hostname", "ip-10-0-0-1.internal", "node1", "", "", ""),
+		Entry("matching source IP", "node1", "", "pod1", "::ffff:127.0.0.1", ""),
+		Entry("wrong node", "node2", "", "", "",
+			"identity mismatch: expected node node2, got node node1, pod pod1, source IP 127.0.0.1"),
+		Entry("wrong node name with matching hostname", "node1", "node2", "", "",
+			"identity mismatch: expected node node2, got node node1, pod pod1, source IP 127.0.0.1"),
+		Entry("wrong pod", "node1", "", "pod2", "",
+			"identity mismatch: expected node node1, pod pod2, got node node1, pod pod1, source IP 127.0.0.1"),
+		Entry("unexpected source IP", "node1", "", "pod1", "10.128.0.11",
+			"unexpected source IP (SNAT?): expected one of 10.128.0.11, got node node1, pod pod1, source IP 127.0.0.1"),
+	)
+
+	It("should fail if the endpoint is not an agent", func() {
+		server.Close()
+		server = httptest.NewServer(http.NotFoundHandler())
+		_, err := checkPeerIdentityFunc(endpoint("node1", ""), options)
+		Expect(err).NotTo(BeNil())
+		Expect(err.Error()).To(Equal("unexpected status 404 Not Found"))
+	})
+})
@@ -0,0 +1,233 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runners
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gardener/network-problem-detector/pkg/common/config"
+	"github.com/gardener/network-problem-detector/pkg/common/nwpd"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	// ThroughputPath is the path of the HTTP throughput endpoint of the agent.
+	// A GET request with query parameter `bytes` downloads the given number of bytes, a POST request uploads the body
+	// and is answered with the number of received bytes.
+	ThroughputPath = "/throughput"
+	// MaxThroughputBytes is the maximum number of bytes transferred by a single throughput request.
+	MaxThroughputBytes = 64 * 1024 * 1024
+	// throughputChunkSize is the maximum size of a single read of a rate limited transfer.
+	throughputChunkSize = 32 * 1024
+	// minThroughputPeriod is the minimum period of throughput jobs, as each run consumes bandwidth.
+	minThroughputPeriod = 5 * time.Minute
+)
+
+// ThroughputOptions are the options for measuring the throughput to peer agents.
+type ThroughputOptions struct {
+	// Bytes is the number of bytes to transfer.
+	Bytes int64
+	// Upload if the bytes are uploaded to the peer instead of downloaded.
+	Upload bool
+	// MaxRate is the maximum transfer rate in bytes per second.
+	MaxRate float64
+	// MinRate is the minimal transfer rate in bytes per second considered as ok (disabled if 0).
+	MinRate float64
+	// Timeout is the timeout of a transfer.
+	Timeout time.Duration
+}
+
+type checkThroughputArgs struct {
+	peerEndpointArgs
+	bytes     int64
+	direction string
+	maxMbps   float64
+	minMbps   float64
+}
+
+func (a *checkThroughputArgs) createRunner(_ *cobra.Command, _ []string) error {
+	endpoints, err := a.buildPeerEndpoints()
+	if err != nil {
+		return err
+	}
+	config := a.runnerArgs.prepareConfig()
+	options := ThroughputOptions{
+		Bytes:   a.bytes,
+		MaxRate: a.maxMbps * 1e6 / 8,
+		MinRate: a.minMbps * 1e6 / 8,
+		Timeout: config.timeoutOr(60 * time.Second),
+	}
+	switch strings.ToLower(a.direction) {
+	case "download":
+	case "upload":
+		options.Upload = true
+	default:
+		return fmt.Errorf("invalid direction %s", a.direction)
+	}
+	if options.Bytes <= 0 || options.Bytes > MaxThroughputBytes {
+		return fmt.Errorf("invalid bytes %d (allowed range: 1-%d)", options.Bytes, MaxThroughputBytes)
+	}
+	if options.MaxRate <= 0 {
+		return fmt.Errorf("invalid max rate %g Mbit/s", a.maxMbps)
+	}
+	if options.MinRate < 0 || options.MinRate > options.MaxRate {
+		return fmt.Errorf("invalid min rate %g Mbit/s", a.minMbps)
+	}
+	if minDuration := time.Duration(float64(options.Bytes) / options.MaxRate * float64(time.Second)); minDuration >= options.Timeout {
+		return fmt.Errorf("timeout %s too short for transferring %d bytes with max rate %g Mbit/s", options.Timeout, options.Bytes, a.maxMbps)
+	}
+	if config.Period < minThroughputPeriod {
+		return fmt.Errorf("period %s too short for throughput checks (minimum %s)", config.Period, minThroughputPeriod)
+	}
+
+	if r := NewCheckThroughput(endpoints, options, config); r != nil {
+		a.runnerArgs.runner = r
+	}
+	return nil
+}
+
+func createCheckThroughputCmd(ra *runnerArgs) *cobra.Command {
+	a := &checkThroughputArgs{peerEndpointArgs: peerEndpointArgs{runnerArgs: ra}}
+	cmd := &cobra.Command{
+		Use:   "checkThroughput",
+		Short: "measures the throughput by downloading or uploading bytes from or to the HTTP server of peer agents",
+		RunE:  a.createRunner,
+	}
+	a.addFlags(cmd)
+	cmd.Flags().Int64Var(&a.bytes, "bytes", 10*1000*1000, fmt.Sprintf("number of bytes to transfer (max %d).", MaxThroughputBytes))
+	cmd.Flags().StringVar(&a.direction, "direction", "download", "direction of the transfer (download, upload).")
+	cmd.Flags().Float64Var(&a.maxMbps, "max-mbps", 100, "maximum transfer rate in Mbit/s.")
+	cmd.Flags().Float64Var(&a.minMbps, "min-mbps", 0, "minimal transfer rate in Mbit/s considered as ok (disabled if 0).")
+	return cmd
+}
+
+func NewCheckThroughput(endpoints []PeerEndpoint, options ThroughputOptions, rconfig RunnerConfig) Runner {
+	if len(endpoints) == 0 {
+		return nil
+	}
+	return &checkThroughput{
+		robinRound[PeerEndpoint]{
+			itemsName: "endpoints",
+			items:     config.CloneAndShuffle(endpoints),
+			runFunc: func(endpoint PeerEndpoint, obs *nwpd.Observation) (string, error) {
+				return checkThroughputFunc(endpoint, options, obs)
+			},
+			config: rconfig,
+		},
+	}
+}
+
+type checkThroughput struct {
+	robinRound[PeerEndpoint]
+}
+
+var _ Runner = &checkThroughput{}
+
+func checkThroughputFunc(endpoint PeerEndpoint, options ThroughputOptions, obs *nwpd.Observation) (string, error) {
+	client := &http.Client{
+		Transport: &http.Transport{DisableKeepAlives: true},
+		Timeout:   options.Timeout,
+	}
+	url := fmt.Sprintf("http://%s%s", net.JoinHostPort(endpoint.IP, strconv.Itoa(endpoint.Port)), ThroughputPath)
+
+	direction := "download"
+	var (
+		resp  *http.Response
+		start time.Time
+		n     int64
+		err   error
+	)
+	if options.Upload {
+		direction = "upload"
+		body := newRateLimitedReader(io.LimitReader(ZeroReader{}, options.Bytes), options.MaxRate)
+		start = time.Now()
+		resp, err = client.Post(url, "application/octet-stream", body)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode == http.StatusOK {
+			// the peer responds with the number of received bytes
+			data, err := io.ReadAll(io.LimitReader(resp.Body, 32))
+			if err != nil {
+				return "", fmt.Errorf("reading upload response failed: %w", err)
+			}
+			if n, err = strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64); err != nil {
+				return "", fmt.Errorf("invalid upload response %q", string(data))
+			}
+		}
+	} else {
+		resp, err = client.Get(fmt.Sprintf("%s?bytes=%d", url, options.Bytes))
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode == http.StatusOK {
+			start = time.Now()
+			n, err = io.Copy(io.Discard, newRateLimitedReader(resp.Body, options.MaxRate))
+			if err != nil {
+				return "", fmt.Errorf("download failed after %d bytes: %w", n, err)
+			}
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", withFailureClass(nwpd.FailureClass_FAILURE_CLASS_HTTP_STATUS, fmt.Errorf("unexpected status %s", resp.Status))
+	}
+	duration := time.Since(start)
+	if n != options.Bytes {
+		return "", fmt.Errorf("%s incomplete: %d of %d bytes transferred", direction, n, options.Bytes)
+	}
+
+	rate := float64(n) / duration.Seconds()
+	obs.Throughput = rate
+	result := fmt.Sprintf("%s of %d bytes in %.3f s: %.1f Mbit/s", direction, n, duration.Seconds(), rate*8/1e6)
+	if options.MinRate > 0 && rate < options.MinRate {
+		return "", fmt.Errorf("throughput below %.1f Mbit/s: %s", options.MinRate*8/1e6, result)
+	}
+	return result, nil
+}
+
+// ZeroReader is an endless source of zero bytes.
+type ZeroReader struct{}
+
+func (ZeroReader) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
+
+// rateLimitedReader limits the rate of reading from the underlying reader.
+type rateLimitedReader struct {
+	r     io.Reader
+	rate  float64
+	start time.Time
+	n     int64
+}
+
+func newRateLimitedReader(r io.Reader, rate float64) *rateLimitedReader {
+	return &rateLimitedReader{r: r, rate: rate}
+}
+
+func (l *rateLimitedReader) Read(p []byte) (int, error) {
+	if l.start.IsZero() {
+		l.start = time.Now()
+	}
+	// wait until the bytes already read are within the rate
+	if wait := time.Duration(float64(l.n)/l.rate*float64(time.Second)) - time.Since(l.start); wait > 0 {
+		time.Sleep(wait)
+	}
+	if len(p) > throughputChunkSize {
+		p = p[:throughputChunkSize]
+	}
+	n, err := l.r.Read(p)
+	l.n += int64(n)
+	return n, err
+}
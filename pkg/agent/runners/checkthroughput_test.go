@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runners
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"time"
+
+	"github.com/gardener/network-problem-detector/pkg/common/config"
+	"github.com/gardener/network-problem-detector/pkg/common/nwpd"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("checkThroughput", func() {
+	var server *httptest.Server
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet:
+				n, _ := strconv.ParseInt(r.URL.Query().Get("bytes"), 10, 64)
+				_, _ = io.CopyN(w, ZeroReader{}, n)
+			case http.MethodPost:
+				n, _ := io.Copy(io.Discard, r.Body)
+				_, _ = fmt.Fprintf(w, "%d\n", n)
+			}
+		}))
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	endpoint := func() PeerEndpoint {
+		host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+		Expect(err).To(BeNil())
+		p, err := strconv.Atoi(port)
+		Expect(err).To(BeNil())
+		return PeerEndpoint{Endpoint: config.Endpoint{Hostname: "node1", IP: host, Port: p}}
+	}
+
+	DescribeTable("transfer",
+		func(upload bool, minMbps float64, expectedResult, expectedErr string) {
+			options := ThroughputOptions{
+				Bytes:   1000 * 1000,
+				Upload:  upload,
+				MaxRate: 40 * 1e6 / 8,
+				MinRate: minMbps * 1e6 / 8,
+				Timeout: 10 * time.Second,
+			}
+			obs := &nwpd.Observation{}
+			start := time.Now()
+			result, err := checkThroughputFunc(endpoint(), options, obs)
+			// the rate limit of 40 Mbit/s needs at least 200ms for 1 MB
+			Expect(time.Since(start)).To(BeNumerically(">=", 150*time.Millisecond))
+			if expectedErr != "" {
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(MatchRegexp(expectedErr))
+				return
+			}
+			Expect(err).To(BeNil())
+			Expect(result).To(MatchRegexp(expectedResult))
+			Expect(obs.Throughput).To(BeNumerically("<=", 1.1*options.MaxRate))
+			Expect(obs.Throughput).To(BeNumerically(">", 0))
+		},
+		Entry("download", false, 0.0, `^download of 1000000 bytes in \d+\.\d{3} s: \d+\.\d Mbit/s$`, ""),
+		Entry("upload", true, 0.0, `^upload of 1000000 bytes in \d+\.\d{3} s: \d+\.\d Mbit/s$`, ""),
+		Entry("throughput below minimum", false, 80.0, "", `^throughput below 80\.0 Mbit/s: download of 1000000 bytes`),
+	)
+
+	It("should fail on unexpected status", func() {
+		options := ThroughputOptions{Bytes: 1000, MaxRate: 1e6, Timeout: 10 * time.Second}
+		ep := endpoint()
+		server.Config.Handler = http.NotFoundHandler()
+		_, err := checkThroughputFunc(ep, options, &nwpd.Observation{})
+		Expect(err).NotTo(BeNil())
+		Expect(err.Error()).To(Equal("unexpected status 404 Not Found"))
+	})
+})
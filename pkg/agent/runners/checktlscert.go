@@ -0,0 +1,201 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runners
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gardener/network-problem-detector/pkg/common/config"
+	"github.com/gardener/network-problem-detector/pkg/common/nwpd"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// TLSCertOptions are the options for checking the TLS handshake and the server certificate chain.
+type TLSCertOptions struct {
+	// RootCAs are the CAs used for the chain validation. If nil, the system CAs are used.
+	RootCAs *x509.CertPool
+	// ServerName overrides the server name used for SNI and the chain validation.
+	ServerName string
+	// InsecureSkipVerify disables the chain validation, only the expiry is checked.
+	InsecureSkipVerify bool
+	// MinDaysValid is the minimal number of days until the earliest expiring certificate of the chain expires.
+	MinDaysValid int
+	// Timeout is the timeout of the handshake.
+	Timeout time.Duration
+}
+
+type checkTLSCertArgs struct {
+	endpointArgs
+	caBundle           string
+	serverName         string
+	insecureSkipVerify bool
+	minDaysValid       int
+}
+
+func (a *checkTLSCertArgs) createRunner(_ *cobra.Command, _ []string) error {
+	endpoints, err := a.buildEndpoints()
+	if err != nil {
+		return err
+	}
+	config := a.runnerArgs.prepareConfig()
+	options := TLSCertOptions{
+		ServerName:         a.serverName,
+		InsecureSkipVerify: a.insecureSkipVerify,
+		MinDaysValid:       a.minDaysValid,
+		Timeout:            config.timeoutOr(10 * time.Second),
+	}
+	if a.caBundle != "" {
+		if options.RootCAs, err = loadCABundle(a.caBundle); err != nil {
+			return err
+		}
+	}
+	if options.MinDaysValid < 0 {
+		return fmt.Errorf("invalid minimal days valid %d", options.MinDaysValid)
+	}
+
+	if r := NewCheckTLSCert(endpoints, options, config); r != nil {
+		a.runnerArgs.runner = r
+	}
+	return nil
+}
+
+func createCheckTLSCertCmd(ra *runnerArgs) *cobra.Command {
+	a := &checkTLSCertArgs{endpointArgs: endpointArgs{runnerArgs: ra}}
+	cmd := &cobra.Command{
+		Use:   "checkTLSCert",
+		Short: "performs a TLS handshake to the given endpoints and checks the server certificate chain",
+		RunE:  a.createRunner,
+	}
+	a.addFlags(cmd)
+	cmd.Flags().StringVar(&a.caBundle, "ca-bundle", "", "file containing CA certificates for the chain validation. If not specified, the system CAs are used.")
+	cmd.Flags().StringVar(&a.serverName, "server-name", "", "overrides the server name used for SNI and the chain validation.")
+	cmd.Flags().BoolVar(&a.insecureSkipVerify, "insecure-skip-verify", false, "disables the chain validation, only the expiry is checked.")
+	cmd.Flags().IntVar(&a.minDaysValid, "min-days-valid", 14, "minimal number of days until a certificate of the chain expires.")
+	return cmd
+}
+
+func NewCheckTLSCert(endpoints []config.Endpoint, options TLSCertOptions, rconfig RunnerConfig) Runner {
+	if len(endpoints) == 0 {
+		return nil
+	}
+	return &checkTLSCert{
+		robinRound[config.Endpoint]{
+			itemsName: "endpoints",
+			items:     config.CloneAndShuffle(endpoints),
+			runFunc: func(endpoint config.Endpoint, obs *nwpd.Observation) (string, error) {
+				return checkTLSCertFunc(endpoint, options, time.Now(), obs)
+			},
+			config: rconfig,
+		},
+	}
+}
+
+type checkTLSCert struct {
+	robinRound[config.Endpoint]
+}
+
+var _ Runner = &checkTLSCert{}
+
+func checkTLSCertFunc(endpoint config.Endpoint, options TLSCertOptions, now time.Time, obs *nwpd.Observation) (string, error) {
+	serverName := options.ServerName
+	if serverName == "" {
+		serverName = normalise(endpoint.Hostname)
+	}
+	host := endpoint.IP
+	if host == "" {
+		host = normalise(endpoint.Hostname)
+	}
+	addr := net.JoinHostPort(host, strconv.Itoa(endpoint.Port))
+
+	dialer := &net.Dialer{Timeout: options.Timeout}
+	conn, err := tls.DialWithDialer(dialer, "tcp", addr, &tls.Config{
+		ServerName: serverName,
+		// the chain is validated explicitly below to report the certificate details even if the validation fails
+		InsecureSkipVerify: true, // #nosec G402 -- chain is validated explicitly
+		MinVersion:         tls.VersionTLS12,
+	})
+	if err != nil {
+		return "", err
+	}
+	state := conn.ConnectionState()
+	_ = conn.Close()
+
+	if len(state.PeerCertificates) == 0 {
+		return "", fmt.Errorf("%s: no server certificate", tls.VersionName(state.Version))
+	}
+
+	expiring := state.PeerCertificates[0]
+	for _, cert := range state.PeerCertificates[1:] {
+		if cert.NotAfter.Before(expiring.NotAfter) {
+			expiring = cert
+		}
+	}
+	remaining := expiring.NotAfter.Sub(now)
+	obs.CertRemainingLifetime = durationpb.New(remaining)
+	days := int(math.Floor(remaining.Hours() / 24))
+
+	verified := "not verified"
+	var verifyErr error
+	if !options.InsecureSkipVerify {
+		verifyErr = verifyChain(state.PeerCertificates, serverName, options.RootCAs, now)
+		if verifyErr == nil {
+			verified = "verified"
+		}
+	}
+
+	result := fmt.Sprintf("%s, chain %s, %s, expires in %d days (%s)", tls.VersionName(state.Version),
+		describeChain(state.PeerCertificates), verified, days, expiring.NotAfter.UTC().Format(time.RFC3339))
+	switch {
+	case verifyErr != nil:
+		return "", withFailureClass(nwpd.FailureClass_FAILURE_CLASS_TLS, fmt.Errorf("certificate validation failed: %w: %s", verifyErr, result))
+	case days < options.MinDaysValid:
+		return "", withFailureClass(nwpd.FailureClass_FAILURE_CLASS_TLS, fmt.Errorf("certificate %s expires in less than %d days: %s", expiring.Subject.String(), options.MinDaysValid, result))
+	}
+	return result, nil
+}
+
+// verifyChain validates the certificate chain presented by the server for the server name.
+func verifyChain(certs []*x509.Certificate, serverName string, rootCAs *x509.CertPool, now time.Time) error {
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         rootCAs,
+		Intermediates: intermediates,
+		DNSName:       serverName,
+		CurrentTime:   now,
+	})
+	return err
+}
+
+// describeChain returns the subjects of the chain certificates with the SANs of the leaf certificate.
+func describeChain(certs []*x509.Certificate) string {
+	var parts []string
+	for i, cert := range certs {
+		s := cert.Subject.String()
+		if i == 0 {
+			var sans []string
+			sans = append(sans, cert.DNSNames...)
+			for _, ip := range cert.IPAddresses {
+				sans = append(sans, ip.String())
+			}
+			if len(sans) > 0 {
+				s += fmt.Sprintf(" [SANs: %s]", strings.Join(sans, ","))
+			}
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, " <- ")
+}
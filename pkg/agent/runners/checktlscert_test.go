@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runners
+
+import (
+	"crypto/x509"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"time"
+
+	"github.com/gardener/network-problem-detector/pkg/common/config"
+	"github.com/gardener/network-problem-detector/pkg/common/nwpd"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("checkTLSCert", func() {
+	var (
+		server  *httptest.Server
+		rootCAs *x509.CertPool
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewUnstartedServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {}))
+		// the check closes the connection after the handshake
+		server.Config.ErrorLog = log.New(io.Discard, "", 0)
+		server.StartTLS()
+		rootCAs = x509.NewCertPool()
+		rootCAs.AddCert(server.Certificate())
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	endpoint := func() config.Endpoint {
+		host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+		Expect(err).To(BeNil())
+		p, err := strconv.Atoi(port)
+		Expect(err).To(BeNil())
+		return config.Endpoint{Hostname: "example.com.", IP: host, Port: p}
+	}
+
+	defaultOptions := func() TLSCertOptions {
+		return TLSCertOptions{
+			RootCAs:      rootCAs,
+			MinDaysValid: 14,
+			Timeout:      5 * time.Second,
+		}
+	}
+
+	It("should report TLS version, chain and remaining lifetime", func() {
+		obs := &nwpd.Observation{}
+		now := server.Certificate().NotAfter.Add(-100*24*time.Hour - time.Hour)
+		result, err := checkTLSCertFunc(endpoint(), defaultOptions(), now, obs)
+		Expect(err).To(BeNil())
+		Expect(result).To(HavePrefix("TLS 1.3, chain O=Acme Co [SANs: example.com,*.example.com,127.0.0.1,::1], verified, expires in 100 days"))
+		Expect(obs.CertRemainingLifetime.AsDuration()).To(Equal(100*24*time.Hour + time.Hour))
+	})
+
+	It("should fail if the certificate expires within the threshold", func() {
+		obs := &nwpd.Observation{}
+		now := server.Certificate().NotAfter.Add(-10 * 24 * time.Hour)
+		_, err := checkTLSCertFunc(endpoint(), defaultOptions(), now, obs)
+		Expect(err).NotTo(BeNil())
+		Expect(err.Error()).To(ContainSubstring("certificate O=Acme Co expires in less than 14 days"))
+		Expect(obs.CertRemainingLifetime.AsDuration()).To(Equal(10 * 24 * time.Hour))
+	})
+
+	It("should fail if the certificate is expired", func() {
+		now := server.Certificate().NotAfter.Add(time.Hour)
+		_, err := checkTLSCertFunc(endpoint(), defaultOptions(), now, &nwpd.Observation{})
+		Expect(err).NotTo(BeNil())
+		Expect(err.Error()).To(ContainSubstring("certificate validation failed"))
+		Expect(err.Error()).To(ContainSubstring("expires in -1 days"))
+	})
+
+	It("should fail with unknown CA", func() {
+		options := defaultOptions()
+		options.RootCAs = x509.NewCertPool()
+		_, err := checkTLSCertFunc(endpoint(), options, time.Now(), &nwpd.Observation{})
+		Expect(err).NotTo(BeNil())
+		Expect(err.Error()).To(ContainSubstring("certificate validation failed: x509: certificate signed by unknown authority"))
+	})
+
+	It("should fail if the certificate is not valid for the server name", func() {
+		options := defaultOptions()
+		options.ServerName = "foo.bar"
+		_, err := checkTLSCertFunc(endpoint(), options, time.Now(), &nwpd.Observation{})
+		Expect(err).NotTo(BeNil())
+		Expect(err.Error()).To(ContainSubstring("certificate is valid for"))
+	})
+
+	It("should only check the expiry if verification is skipped", func() {
+		options := defaultOptions()
+		options.RootCAs = x509.NewCertPool()
+		options.InsecureSkipVerify = true
+		result, err := checkTLSCertFunc(endpoint(), options, time.Now(), &nwpd.Observation{})
+		Expect(err).To(BeNil())
+		Expect(result).To(ContainSubstring(", not verified, "))
+	})
+})
@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runners
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/gardener/network-problem-detector/pkg/common/config"
+	"github.com/gardener/network-problem-detector/pkg/common/nwpd"
+
+	"github.com/spf13/cobra"
+)
+
+// UDPEchoPrefix is the prefix of UDP echo requests. The UDP echo responder of the agent only answers packets with this prefix.
+const UDPEchoPrefix = "nwpd-udp-echo:"
+
+// defaultUDPEchoTimeout is the default time to wait for the echo response.
+const defaultUDPEchoTimeout = 2 * time.Second
+
+type checkUDPPortArgs struct {
+	endpointArgs
+}
+
+func (a *checkUDPPortArgs) createRunner(_ *cobra.Command, _ []string) error {
+	endpoints, err := a.buildEndpoints()
+	if err != nil {
+		return err
+	}
+
+	config := a.runnerArgs.prepareConfig()
+	if r := NewCheckUDPPort(endpoints, config); r != nil {
+		a.runnerArgs.runner = r
+	}
+	return nil
+}
+
+func createCheckUDPPortCmd(ra *runnerArgs) *cobra.Command {
+	a := &checkUDPPortArgs{endpointArgs{runnerArgs: ra}}
+	cmd := &cobra.Command{
+		Use:   "checkUDPPort",
+		Short: "sends UDP packet to the echo responder of an agent and waits for the response",
+		RunE:  a.createRunner,
+	}
+	a.addFlags(cmd)
+	return cmd
+}
+
+func NewCheckUDPPort(endpoints []config.Endpoint, rconfig RunnerConfig) Runner {
+	if len(endpoints) == 0 {
+		return nil
+	}
+	return &checkUDPPort{
+		robinRound[config.Endpoint]{
+			itemsName: "endpoints",
+			items:     config.CloneAndShuffle(endpoints),
+			runFunc: func(endpoint config.Endpoint, _ *nwpd.Observation) (string, error) {
+				return checkUDPPortFunc(endpoint, rconfig.timeoutOr(defaultUDPEchoTimeout))
+			},
+			config: rconfig,
+		},
+	}
+}
+
+type checkUDPPort struct {
+	robinRound[config.Endpoint]
+}
+
+var _ Runner = &checkUDPPort{}
+
+func checkUDPPortFunc(endpoint config.Endpoint, timeout time.Duration) (string, error) {
+	addr := net.JoinHostPort(endpoint.DialHost(), strconv.Itoa(endpoint.Port))
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	n, err := udpEcho(conn, 0, timeout)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("echoed %d bytes", n), nil
+}
+
+// udpEcho sends an echo request padded to the given payload size and waits for the identical response.
+func udpEcho(conn net.Conn, size int, timeout time.Duration) (int, error) {
+	token := make([]byte, 8)
+	if _, err := rand.Read(token); err != nil {
+		return 0, err
+	}
+	request := []byte(UDPEchoPrefix + hex.EncodeToString(token))
+	if len(request) < size {
+		request = append(request, bytes.Repeat([]byte{'.'}, size-len(request))...)
+	}
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return 0, err
+	}
+	if _, err := conn.Write(request); err != nil {
+		return 0, err
+	}
+	response := make([]byte, len(request)+1)
+	for {
+		n, err := conn.Read(response)
+		if err != nil {
+			return 0, err
+		}
+		// ignore outdated responses of former requests
+		if bytes.Equal(response[:n], request) {
+			return n, nil
+		}
+	}
+}
@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runners
+
+import (
+	"net"
+	"time"
+
+	"github.com/gardener/network-problem-detector/pkg/common/config"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("checkUDPPort", func() {
+	var conn net.PacketConn
+
+	BeforeEach(func() {
+		var err error
+		conn, err = net.ListenPacket("udp", "127.0.0.1:0")
+		Expect(err).To(BeNil())
+	})
+
+	AfterEach(func() {
+		_ = conn.Close()
+	})
+
+	// respond answers each request with the given responses, where an empty response stands for the echo.
+	respond := func(responses ...string) {
+		go func() {
+			buf := make([]byte, 1024)
+			for {
+				n, addr, err := conn.ReadFrom(buf)
+				if err != nil {
+					return
+				}
+				for _, response := range responses {
+					if response == "" {
+						response = string(buf[:n])
+					}
+					_, _ = conn.WriteTo([]byte(response), addr)
+				}
+			}
+		}()
+	}
+
+	endpoint := func() config.Endpoint {
+		return config.Endpoint{Hostname: "local", IP: "127.0.0.1", Port: conn.LocalAddr().(*net.UDPAddr).Port}
+	}
+
+	DescribeTable("echo",
+		func(responses []string, expectedErr bool) {
+			if responses != nil {
+				respond(responses...)
+			}
+			result, err := checkUDPPortFunc(endpoint(), 300*time.Millisecond)
+			if expectedErr {
+				Expect(err).NotTo(BeNil())
+				Expect(err.(net.Error).Timeout()).To(BeTrue())
+			} else {
+				Expect(err).To(BeNil())
+				Expect(result).To(Equal("echoed 30 bytes"))
+			}
+		},
+		Entry("echoed", []string{""}, false),
+		Entry("outdated response before echo", []string{UDPEchoPrefix + "0000000000000000", ""}, false),
+		Entry("no response", nil, true),
+		Entry("wrong response", []string{"pong"}, true),
+	)
+})
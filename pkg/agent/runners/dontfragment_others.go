@@ -0,0 +1,17 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !linux
+
+package runners
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// setDontFragment is only supported on Linux.
+func setDontFragment(_, _ string, _ syscall.RawConn) error {
+	return fmt.Errorf("don't-fragment bit not supported on this platform")
+}
@@ -0,0 +1,195 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runners
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gardener/network-problem-detector/pkg/common/config"
+
+	"github.com/spf13/cobra"
+)
+
+// endpointArgs contains the target selection flags shared by runners checking IP/port endpoints.
+type endpointArgs struct {
+	runnerArgs   *runnerArgs
+	nodePort     int
+	nodePortIPv6 int
+	podDS        bool
+	podDSIPv6    bool
+	internalKAPI bool
+	externalKAPI bool
+	endpoints    []string
+}
+
+func (a *endpointArgs) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&a.endpoints, "endpoints", nil, "endpoints in format <hostname>:<ip>:<port> (the hostname is used if the ip is empty).")
+	cmd.Flags().IntVar(&a.nodePort, "node-port", 0, "port on nodes as alternative to specifying endpoints.")
+	cmd.Flags().IntVar(&a.nodePortIPv6, "node-port-ipv6", 0, "port on nodes via ipv6 address as alternative to specifying endpoints.")
+	cmd.Flags().BoolVar(&a.podDS, "endpoints-of-pod-ds", false, "uses known pod endpoints of the 'nwpd-agent-pod-net' service.")
+	cmd.Flags().BoolVar(&a.podDSIPv6, "endpoints-of-pod-ds-ipv6", false, "uses known pod ipv6 endpoints of the 'nwpd-agent-pod-net' service.")
+	cmd.Flags().BoolVar(&a.internalKAPI, "endpoint-internal-kube-apiserver", false, "uses known internal endpoint of kube-apiserver.")
+	cmd.Flags().BoolVar(&a.externalKAPI, "endpoint-external-kube-apiserver", false, "uses known external endpoint of kube-apiserver.")
+}
+
+// buildEndpoints resolves the selected endpoints from the flags and the cluster configuration.
+func (a *endpointArgs) buildEndpoints() ([]config.Endpoint, error) {
+	allowEmpty := false
+	var endpoints []config.Endpoint
+	switch {
+	case len(a.endpoints) > 0:
+		for _, ep := range a.endpoints {
+			parts := strings.SplitN(ep, ":", 3)
+			if len(parts) != 3 {
+				return nil, fmt.Errorf("invalid endpoint %s", ep)
+			}
+			port, err := strconv.Atoi(parts[2])
+			if err != nil {
+				return nil, fmt.Errorf("invalid endpoint port %s", parts[2])
+			}
+			endpoints = append(endpoints, config.Endpoint{
+				Hostname: parts[0],
+				IP:       parts[1],
+				Port:     port,
+			})
+		}
+	case a.nodePort != 0:
+		allowEmpty = true
+		for _, n := range a.runnerArgs.clusterCfg.Nodes {
+			for _, ip := range n.InternalIPs {
+				endpoints = append(endpoints, config.Endpoint{
+					Hostname: n.Hostname,
+					IP:       ip,
+					Port:     a.nodePort,
+				})
+			}
+		}
+	case a.nodePortIPv6 != 0:
+		allowEmpty = true
+		for _, n := range a.runnerArgs.clusterCfg.Nodes {
+			for _, ip := range n.InternalIPsV6 {
+				endpoints = append(endpoints, config.Endpoint{
+					Hostname: n.Hostname,
+					IP:       ip,
+					Port:     a.nodePortIPv6,
+				})
+			}
+		}
+	case a.podDS:
+		allowEmpty = true
+		for _, pe := range a.runnerArgs.clusterCfg.PodEndpoints {
+			endpoints = append(endpoints, config.Endpoint{
+				Hostname: pe.Nodename,
+				IP:       pe.PodIP,
+				Port:     int(pe.Port),
+			})
+		}
+	case a.podDSIPv6:
+		allowEmpty = true
+		for _, pe := range a.runnerArgs.clusterCfg.PodEndpointsV6 {
+			endpoints = append(endpoints, config.Endpoint{
+				Hostname: pe.Nodename,
+				IP:       pe.PodIP,
+				Port:     int(pe.Port),
+			})
+		}
+	case a.internalKAPI:
+		allowEmpty = true
+		if pe := a.runnerArgs.clusterCfg.InternalKubeAPIServer; pe != nil {
+			endpoints = append(endpoints, *pe)
+		}
+	case a.externalKAPI:
+		allowEmpty = true
+		if pe := a.runnerArgs.clusterCfg.KubeAPIServer; pe != nil {
+			endpoints = append(endpoints, *pe)
+		}
+	}
+
+	if !allowEmpty && len(endpoints) == 0 {
+		return nil, fmt.Errorf("no endpoints")
+	}
+	return endpoints, nil
+}
+
+// PeerEndpoint is the endpoint of a peer agent with its expected identity.
+type PeerEndpoint struct {
+	config.Endpoint
+	// NodeName is the expected node name of the peer agent. If empty, the hostname of the endpoint is expected.
+	NodeName string
+	// Podname is the expected pod name of the peer agent. If empty, only the node name is checked.
+	Podname string
+}
+
+// peerEndpointArgs contains the target selection flags shared by runners calling the HTTP server of peer agents.
+type peerEndpointArgs struct {
+	runnerArgs   *runnerArgs
+	nodePort     int
+	nodePortIPv6 int
+	podDS        bool
+	podDSIPv6    bool
+}
+
+func (a *peerEndpointArgs) addFlags(cmd *cobra.Command) {
+	cmd.Flags().IntVar(&a.nodePort, "node-port", 0, "HTTP port of the agents in the host network on the nodes.")
+	cmd.Flags().IntVar(&a.nodePortIPv6, "node-port-ipv6", 0, "HTTP port of the agents in the host network on the nodes via ipv6 address.")
+	cmd.Flags().BoolVar(&a.podDS, "endpoints-of-pod-ds", false, "uses known pod endpoints of the 'nwpd-agent-pod-net' service.")
+	cmd.Flags().BoolVar(&a.podDSIPv6, "endpoints-of-pod-ds-ipv6", false, "uses known pod ipv6 endpoints of the 'nwpd-agent-pod-net' service.")
+}
+
+// buildPeerEndpoints resolves the selected peer agent endpoints from the flags and the cluster configuration.
+func (a *peerEndpointArgs) buildPeerEndpoints() ([]PeerEndpoint, error) {
+	var endpoints []PeerEndpoint
+	switch {
+	case a.nodePort != 0:
+		endpoints = nodePeerEndpoints(a.runnerArgs.clusterCfg.Nodes, false, a.nodePort)
+	case a.nodePortIPv6 != 0:
+		endpoints = nodePeerEndpoints(a.runnerArgs.clusterCfg.Nodes, true, a.nodePortIPv6)
+	case a.podDS:
+		endpoints = podPeerEndpoints(a.runnerArgs.clusterCfg.PodEndpoints)
+	case a.podDSIPv6:
+		endpoints = podPeerEndpoints(a.runnerArgs.clusterCfg.PodEndpointsV6)
+	default:
+		return nil, fmt.Errorf("no endpoints")
+	}
+	return endpoints, nil
+}
+
+func nodePeerEndpoints(nodes []config.Node, ipv6 bool, port int) []PeerEndpoint {
+	var endpoints []PeerEndpoint
+	for _, n := range nodes {
+		ips := n.InternalIPs
+		if ipv6 {
+			ips = n.InternalIPsV6
+		}
+		for _, ip := range ips {
+			endpoints = append(endpoints, PeerEndpoint{
+				Endpoint: config.Endpoint{Hostname: n.Hostname, IP: ip, Port: port},
+				NodeName: n.Name,
+			})
+		}
+	}
+	return endpoints
+}
+
+// expectedNodeName returns the node name the peer agent is expected to report.
+func (e PeerEndpoint) expectedNodeName() string {
+	if e.NodeName != "" {
+		return normalise(e.NodeName)
+	}
+	return normalise(e.Hostname)
+}
+
+func podPeerEndpoints(podEndpoints []config.PodEndpoint) []PeerEndpoint {
+	var endpoints []PeerEndpoint
+	for _, pe := range podEndpoints {
+		endpoints = append(endpoints, PeerEndpoint{
+			Endpoint: config.Endpoint{Hostname: pe.Nodename, IP: pe.PodIP, Port: int(pe.Port)},
+			Podname:  pe.Podname,
+		})
+	}
+	return endpoints
+}
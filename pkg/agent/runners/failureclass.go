@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runners
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"os"
+	"syscall"
+
+	"github.com/gardener/network-problem-detector/pkg/common/nwpd"
+)
+
+// classifiedError is an error with a failure class determined by the runner.
+type classifiedError struct {
+	class nwpd.FailureClass
+	err   error
+}
+
+func (e *classifiedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *classifiedError) Unwrap() error {
+	return e.err
+}
+
+// withFailureClass annotates an error with a failure class, which takes precedence over the classification of the error chain.
+func withFailureClass(class nwpd.FailureClass, err error) error {
+	return &classifiedError{class: class, err: err}
+}
+
+// classifyFailure derives the failure class from the error of a check. It is unspecified if there is no error.
+func classifyFailure(err error) nwpd.FailureClass {
+	var (
+		classifiedErr  *classifiedError
+		dnsErr         *net.DNSError
+		tlsRecordErr   tls.RecordHeaderError
+		tlsAlertErr    tls.AlertError
+		tlsVerifyErr   *tls.CertificateVerificationError
+		unknownAuthErr x509.UnknownAuthorityError
+		hostnameErr    x509.HostnameError
+		certInvalidErr x509.CertificateInvalidError
+		netErr         net.Error
+	)
+	switch {
+	case err == nil:
+		return nwpd.FailureClass_FAILURE_CLASS_UNSPECIFIED
+	case errors.As(err, &classifiedErr):
+		return classifiedErr.class
+	case errors.As(err, &dnsErr):
+		if dnsErr.IsNotFound {
+			return nwpd.FailureClass_FAILURE_CLASS_DNS_NOT_FOUND
+		}
+		return nwpd.FailureClass_FAILURE_CLASS_DNS
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return nwpd.FailureClass_FAILURE_CLASS_CONNECTION_REFUSED
+	case errors.Is(err, syscall.ECONNRESET), errors.Is(err, syscall.EPIPE):
+		return nwpd.FailureClass_FAILURE_CLASS_CONNECTION_RESET
+	case errors.Is(err, syscall.EHOSTUNREACH), errors.Is(err, syscall.ENETUNREACH):
+		return nwpd.FailureClass_FAILURE_CLASS_UNREACHABLE
+	case errors.As(err, &tlsRecordErr), errors.As(err, &tlsAlertErr), errors.As(err, &tlsVerifyErr),
+		errors.As(err, &unknownAuthErr), errors.As(err, &hostnameErr), errors.As(err, &certInvalidErr):
+		return nwpd.FailureClass_FAILURE_CLASS_TLS
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, os.ErrDeadlineExceeded),
+		errors.As(err, &netErr) && netErr.Timeout():
+		return nwpd.FailureClass_FAILURE_CLASS_TIMEOUT
+	default:
+		return nwpd.FailureClass_FAILURE_CLASS_OTHER
+	}
+}
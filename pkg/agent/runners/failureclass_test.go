@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runners
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+
+	"github.com/gardener/network-problem-detector/pkg/common/nwpd"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("classifyFailure", func() {
+	DescribeTable("should classify errors",
+		func(err error, expected nwpd.FailureClass) {
+			Expect(classifyFailure(err)).To(Equal(expected))
+		},
+		Entry("no error", nil, nwpd.FailureClass_FAILURE_CLASS_UNSPECIFIED),
+		Entry("unknown error", fmt.Errorf("something failed"), nwpd.FailureClass_FAILURE_CLASS_OTHER),
+		Entry("connection refused", &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)},
+			nwpd.FailureClass_FAILURE_CLASS_CONNECTION_REFUSED),
+		Entry("connection reset", fmt.Errorf("read failed: %w", &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}),
+			nwpd.FailureClass_FAILURE_CLASS_CONNECTION_RESET),
+		Entry("no route to host", &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.EHOSTUNREACH)},
+			nwpd.FailureClass_FAILURE_CLASS_UNREACHABLE),
+		Entry("dial timeout", &net.OpError{Op: "dial", Net: "tcp", Err: os.ErrDeadlineExceeded}, nwpd.FailureClass_FAILURE_CLASS_TIMEOUT),
+		Entry("context deadline", fmt.Errorf("request failed: %w", context.DeadlineExceeded), nwpd.FailureClass_FAILURE_CLASS_TIMEOUT),
+		Entry("name not found", &net.DNSError{Err: "no such host", Name: "canary", IsNotFound: true}, nwpd.FailureClass_FAILURE_CLASS_DNS_NOT_FOUND),
+		Entry("DNS timeout", &net.DNSError{Err: "i/o timeout", Name: "canary", IsTimeout: true}, nwpd.FailureClass_FAILURE_CLASS_DNS),
+		Entry("unknown authority", fmt.Errorf("tls: %w", x509.UnknownAuthorityError{}), nwpd.FailureClass_FAILURE_CLASS_TLS),
+		Entry("classified by runner", withFailureClass(nwpd.FailureClass_FAILURE_CLASS_HTTP_STATUS, fmt.Errorf("unexpected status 503")),
+			nwpd.FailureClass_FAILURE_CLASS_HTTP_STATUS),
+		Entry("classification of runner takes precedence", withFailureClass(nwpd.FailureClass_FAILURE_CLASS_TLS, context.DeadlineExceeded),
+			nwpd.FailureClass_FAILURE_CLASS_TLS),
+	)
+})
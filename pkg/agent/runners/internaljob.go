@@ -7,6 +7,9 @@
 package runners
 
 import (
+	"context"
+	"fmt"
+	"sync"
 	"time"
 
 	"go.uber.org/atomic"
@@ -18,10 +21,35 @@ import (
 type RunnerConfig struct {
 	config.Job
 	Period time.Duration
+	// FanOut is the number of items checked concurrently per run. If <= 1, a single item is checked per run in round robin order.
+	// If negative, all items are checked per run.
+	FanOut int
+	// MaxParallel limits the number of concurrent checks of a fan-out run.
+	MaxParallel int
+	// Stagger is the delay between the starts of the checks of a fan-out run.
+	Stagger time.Duration
+	// Timeout is the timeout of a single check attempt. If 0, the default timeout of the job type is used.
+	Timeout time.Duration
+	// Retries is the number of retries of a failed check.
+	Retries int
+	// RetryDelay is the delay before retrying a failed check.
+	RetryDelay time.Duration
+	// Limiter limits the number of concurrent checks of all jobs. A scheduled run holds one slot for its first check,
+	// additional concurrent checks of a fan-out run or a probe need further slots.
+	Limiter *Limiter
+}
+
+// timeoutOr returns the configured timeout or the given default timeout of the job type.
+func (c RunnerConfig) timeoutOr(defaultTimeout time.Duration) time.Duration {
+	if c.Timeout > 0 {
+		return c.Timeout
+	}
+	return defaultTimeout
 }
 
 type Runner interface {
 	Run(nodeName string, ch chan<- *nwpd.Observation)
+	RunAll(nodeName string, filter func(destHost string) bool, ch chan<- *nwpd.Observation)
 	Config() RunnerConfig
 	Description() string
 	TestData() any
@@ -30,15 +58,19 @@ type Runner interface {
 
 type InternalJob struct {
 	runner        Runner
+	args          []string
 	peerNodeCount int
-	active        atomic.Bool
+	active        *atomic.Bool
 	lastRun       atomic.Value
+	resultsLock   sync.Mutex
+	lastResults   map[string]*nwpd.Observation
 }
 
 func NewInternalJob(runner Runner, peerNodeCount int) *InternalJob {
 	return &InternalJob{
 		runner:        runner,
 		peerNodeCount: peerNodeCount,
+		active:        atomic.NewBool(false),
 	}
 }
 
@@ -46,6 +78,19 @@ func (j *InternalJob) JobID() string {
 	return j.runner.Config().JobID
 }
 
+// Args returns the command line style arguments of the job, which are built from the typed job spec if given.
+func (j *InternalJob) Args() []string {
+	return j.args
+}
+
+// Labels returns the labels of the job spec describing the job.
+func (j *InternalJob) Labels() map[string]string {
+	if spec := j.runner.Config().Spec; spec != nil {
+		return spec.Labels
+	}
+	return nil
+}
+
 func (j *InternalJob) Period() time.Duration {
 	return j.runner.Config().Period
 }
@@ -58,6 +103,11 @@ func (j *InternalJob) Description() string {
 	return j.runner.Description()
 }
 
+// ChecksPerRun returns the number of checks of a single run of the job.
+func (j *InternalJob) ChecksPerRun() int {
+	return itemsPerRun(j.runner.Config().FanOut, len(j.runner.DestHosts()))
+}
+
 func (j *InternalJob) PeerNodeCount() int {
 	return j.peerNodeCount
 }
@@ -70,20 +120,104 @@ func (j *InternalJob) SetLastRun(lastRun *time.Time) {
 	j.lastRun.Store(lastRun)
 }
 
-func (j *InternalJob) Tick(nodeName string, ch chan<- *nwpd.Observation) error {
-	if j.runner == nil || j.active.Load() {
-		return nil
+// Start marks the job as active and stores the start time as last run.
+// It returns false if the job has no runner or a run is still active.
+func (j *InternalJob) Start(now time.Time) bool {
+	if j.runner == nil || !j.active.CompareAndSwap(false, true) {
+		return false
 	}
+	j.lastRun.Store(&now)
+	return true
+}
 
-	now := time.Now()
-	if now.After(j.getNextRun()) && j.active.CompareAndSwap(false, true) {
-		j.lastRun.Store(&now)
-		go func() {
-			defer j.active.Store(false)
-			j.runner.Run(nodeName, ch)
-		}()
+// Run runs the job and marks it as inactive afterwards. It must only be called after a successful Start.
+func (j *InternalJob) Run(nodeName string, ch chan<- *nwpd.Observation) {
+	defer j.active.Store(false)
+	j.runner.Run(nodeName, ch)
+}
+
+// Probe checks all destinations of the job once, optionally restricted to the given destination hosts, and waits for the
+// observations until all checks are finished or the context is done. The observations are tagged as ad-hoc.
+// It returns the observations and the number of pending checks.
+func (j *InternalJob) Probe(ctx context.Context, nodeName string, restrictToDestHosts []string) ([]*nwpd.Observation, int, error) {
+	var filter func(destHost string) bool
+	if len(restrictToDestHosts) > 0 {
+		allowed := map[string]struct{}{}
+		for _, host := range restrictToDestHosts {
+			allowed[normalise(host)] = struct{}{}
+		}
+		filter = func(destHost string) bool {
+			_, ok := allowed[destHost]
+			return ok
+		}
 	}
-	return nil
+	count := 0
+	for _, host := range j.runner.DestHosts() {
+		if filter == nil || filter(normalise(host)) {
+			count++
+		}
+	}
+	if count == 0 {
+		return nil, 0, fmt.Errorf("no destination hosts matching %v", restrictToDestHosts)
+	}
+
+	// buffered for all observations, so that checks finishing after the timeout don't block
+	ch := make(chan *nwpd.Observation, count)
+	go j.runner.RunAll(nodeName, filter, ch)
+	var observations []*nwpd.Observation
+	for len(observations) < count {
+		select {
+		case obs := <-ch:
+			obs.AdHoc = true
+			observations = append(observations, obs)
+		case <-ctx.Done():
+			return observations, count - len(observations), nil
+		}
+	}
+	return observations, 0, nil
+}
+
+// IsActive returns true if a run of the job is in progress.
+func (j *InternalJob) IsActive() bool {
+	return j.active.Load()
+}
+
+// SetLastResult stores the observation as last result for its destination host.
+func (j *InternalJob) SetLastResult(obs *nwpd.Observation) {
+	j.resultsLock.Lock()
+	defer j.resultsLock.Unlock()
+	if j.lastResults == nil {
+		j.lastResults = map[string]*nwpd.Observation{}
+	}
+	j.lastResults[obs.DestHost] = obs
+}
+
+// LastResults returns the last observation per destination host.
+func (j *InternalJob) LastResults() map[string]*nwpd.Observation {
+	j.resultsLock.Lock()
+	defer j.resultsLock.Unlock()
+	results := make(map[string]*nwpd.Observation, len(j.lastResults))
+	for host, obs := range j.lastResults {
+		results[host] = obs
+	}
+	return results
+}
+
+// KeepLastResults takes over the last results of the old job for the destination hosts of this job.
+func (j *InternalJob) KeepLastResults(oldJob *InternalJob) {
+	oldResults := oldJob.LastResults()
+	for _, host := range j.DestHosts() {
+		if obs := oldResults[normalise(host)]; obs != nil {
+			j.SetLastResult(obs)
+		}
+	}
+}
+
+// KeepRunState takes over the run state of the old job. The active flag is shared, so that no run of this job is started
+// while a run of the old job is still in progress.
+func (j *InternalJob) KeepRunState(oldJob *InternalJob) {
+	j.active = oldJob.active
+	j.SetLastRun(oldJob.GetLastRun())
 }
 
 func (j *InternalJob) GetLastRun() *time.Time {
@@ -93,11 +227,3 @@ func (j *InternalJob) GetLastRun() *time.Time {
 	}
 	return v.(*time.Time)
 }
-
-func (j *InternalJob) getNextRun() time.Time {
-	last := j.GetLastRun()
-	if last == nil {
-		return time.Time{}
-	}
-	return last.Add(j.Period())
-}
@@ -0,0 +1,429 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runners
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gardener/network-problem-detector/pkg/common/config"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// durationPattern is the JSON schema pattern of a Go duration.
+const durationPattern = `^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
+
+// specFieldFlags are the flags set by dedicated fields of the job spec instead of params.
+var specFieldFlags = map[string]string{
+	"period":       "period",
+	"scale-period": "scalePeriod",
+	"timeout":      "timeout",
+}
+
+// jobTargetSelector maps a field of the job targets to the flag of the job types.
+type jobTargetSelector struct {
+	// name is the JSON name of the field.
+	name string
+	// flags are the candidate flag names, the first one supported by the job type is used.
+	flags []string
+	// values returns the flag values if the field is set.
+	values func(t *config.JobTargets) []string
+}
+
+var jobTargetSelectors = []jobTargetSelector{
+	{name: "endpoints", flags: []string{"endpoints"}, values: func(t *config.JobTargets) []string { return t.Endpoints }},
+	{name: "hosts", flags: []string{"hosts"}, values: func(t *config.JobTargets) []string { return t.Hosts }},
+	{name: "names", flags: []string{"names"}, values: func(t *config.JobTargets) []string { return t.Names }},
+	{name: "servers", flags: []string{"servers"}, values: func(t *config.JobTargets) []string { return t.Servers }},
+	{name: "nodePort", flags: []string{"node-port"}, values: func(t *config.JobTargets) []string { return intTarget(t.NodePort) }},
+	{name: "nodePortIPv6", flags: []string{"node-port-ipv6"}, values: func(t *config.JobTargets) []string { return intTarget(t.NodePortIPv6) }},
+	{name: "podDaemonSet", flags: []string{"endpoints-of-pod-ds"}, values: func(t *config.JobTargets) []string { return boolTarget(t.PodDaemonSet) }},
+	{name: "podDaemonSetIPv6", flags: []string{"endpoints-of-pod-ds-ipv6"}, values: func(t *config.JobTargets) []string { return boolTarget(t.PodDaemonSetIPv6) }},
+	{
+		name: "internalKubeAPIServer", flags: []string{"endpoint-internal-kube-apiserver", "name-internal-kube-apiserver"},
+		values: func(t *config.JobTargets) []string { return boolTarget(t.InternalKubeAPIServer) },
+	},
+	{
+		name: "externalKubeAPIServer", flags: []string{"endpoint-external-kube-apiserver", "name-external-kube-apiserver"},
+		values: func(t *config.JobTargets) []string { return boolTarget(t.ExternalKubeAPIServer) },
+	},
+	{name: "kubeDNSService", flags: []string{"servers-kube-dns-service"}, values: func(t *config.JobTargets) []string { return boolTarget(t.KubeDNSService) }},
+	{name: "kubeDNSPods", flags: []string{"servers-kube-dns-pods"}, values: func(t *config.JobTargets) []string { return boolTarget(t.KubeDNSPods) }},
+}
+
+func intTarget(value int) []string {
+	if value == 0 {
+		return nil
+	}
+	return []string{strconv.Itoa(value)}
+}
+
+func boolTarget(value bool) []string {
+	if !value {
+		return nil
+	}
+	return []string{"true"}
+}
+
+// flag returns the flag of the job type used for the target selector or nil if not supported.
+func (s *jobTargetSelector) flag(cmd *cobra.Command) *pflag.Flag {
+	for _, name := range s.flags {
+		if f := lookupFlag(cmd, name); f != nil {
+			return f
+		}
+	}
+	return nil
+}
+
+// isTargetFlag returns true if the flag is set by a target selector.
+func isTargetFlag(name string) bool {
+	for _, s := range jobTargetSelectors {
+		for _, f := range s.flags {
+			if f == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func lookupFlag(cmd *cobra.Command, name string) *pflag.Flag {
+	if f := cmd.LocalFlags().Lookup(name); f != nil {
+		return f
+	}
+	return cmd.InheritedFlags().Lookup(name)
+}
+
+// paramFlags returns the flags of the job type which can be set as params, sorted by name.
+func paramFlags(cmd *cobra.Command) []*pflag.Flag {
+	var flags []*pflag.Flag
+	visit := func(f *pflag.Flag) {
+		if _, ok := specFieldFlags[f.Name]; ok || f.Name == "help" || isTargetFlag(f.Name) {
+			return
+		}
+		flags = append(flags, f)
+	}
+	cmd.LocalFlags().VisitAll(visit)
+	cmd.InheritedFlags().VisitAll(visit)
+	sort.Slice(flags, func(i, j int) bool { return flags[i].Name < flags[j].Name })
+	return flags
+}
+
+func jobTypeCmd(root *cobra.Command, jobType string) *cobra.Command {
+	for _, cmd := range root.Commands() {
+		if cmd.Name() == jobType {
+			return cmd
+		}
+	}
+	return nil
+}
+
+// JobTypes returns the names of all job types.
+func JobTypes() []string {
+	var types []string
+	for _, cmd := range GetNewRoot(&runnerArgs{}).Commands() {
+		types = append(types, cmd.Name())
+	}
+	sort.Strings(types)
+	return types
+}
+
+// JobArgs returns the command line style arguments of a job, which are either given by `Args` or built from the typed job spec.
+func JobArgs(job config.Job) ([]string, error) {
+	switch {
+	case job.Spec != nil && len(job.Args) > 0:
+		return nil, fmt.Errorf("args and spec are mutually exclusive")
+	case job.Spec != nil:
+		return specArgs(job.Spec)
+	case len(job.Args) == 0:
+		return nil, fmt.Errorf("no job args")
+	default:
+		return job.Args, nil
+	}
+}
+
+func specArgs(spec *config.JobSpec) ([]string, error) {
+	cmd := jobTypeCmd(GetNewRoot(&runnerArgs{}), spec.Type)
+	if cmd == nil {
+		return nil, fmt.Errorf("unknown job type %q (allowed types: %s)", spec.Type, strings.Join(JobTypes(), ", "))
+	}
+
+	args := []string{spec.Type}
+	if spec.Targets != nil {
+		for _, s := range jobTargetSelectors {
+			values := s.values(spec.Targets)
+			if len(values) == 0 {
+				continue
+			}
+			f := s.flag(cmd)
+			if f == nil {
+				return nil, fmt.Errorf("target %s not supported by job type %s", s.name, spec.Type)
+			}
+			for _, v := range values {
+				args = append(args, fmt.Sprintf("--%s=%s", f.Name, v))
+			}
+		}
+	}
+
+	names := make([]string, 0, len(spec.Params))
+	for name := range spec.Params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if field, ok := specFieldFlags[name]; ok {
+			return nil, fmt.Errorf("parameter %s not allowed, use field %s of the job spec", name, field)
+		}
+		f := lookupFlag(cmd, name)
+		if f == nil || name == "help" || isTargetFlag(name) {
+			return nil, fmt.Errorf("unknown parameter %s for job type %s", name, spec.Type)
+		}
+		values, err := paramValues(f, spec.Params[name])
+		if err != nil {
+			return nil, fmt.Errorf("invalid parameter %s: %w", name, err)
+		}
+		for _, v := range values {
+			args = append(args, fmt.Sprintf("--%s=%s", name, v))
+		}
+	}
+
+	if spec.Period != nil {
+		if spec.Period.Duration <= 0 {
+			return nil, fmt.Errorf("invalid period %s", spec.Period.Duration)
+		}
+		args = append(args, "--period="+spec.Period.Duration.String())
+	}
+	if spec.ScalePeriod {
+		args = append(args, "--scale-period")
+	}
+	if spec.Timeout != nil {
+		args = append(args, "--timeout="+spec.Timeout.Duration.String())
+	}
+	keys := make([]string, 0, len(spec.Labels))
+	for key := range spec.Labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := spec.Labels[key]
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid label key %q: %s", key, strings.Join(errs, "; "))
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid label value %q: %s", value, strings.Join(errs, "; "))
+		}
+	}
+	return args, nil
+}
+
+// paramValues converts a parameter value to the flag values, checking its type strictly.
+func paramValues(f *pflag.Flag, value any) ([]string, error) {
+	switch typ := f.Value.Type(); typ {
+	case "bool":
+		if b, ok := value.(bool); ok {
+			return []string{strconv.FormatBool(b)}, nil
+		}
+		return nil, fmt.Errorf("expected boolean")
+	case "int", "int64":
+		if i, ok := toInteger(value); ok {
+			return []string{strconv.FormatInt(i, 10)}, nil
+		}
+		return nil, fmt.Errorf("expected integer")
+	case "float64":
+		if v, ok := toNumber(value); ok {
+			return []string{strconv.FormatFloat(v, 'g', -1, 64)}, nil
+		}
+		return nil, fmt.Errorf("expected number")
+	case "string":
+		if s, ok := value.(string); ok {
+			return []string{s}, nil
+		}
+		return nil, fmt.Errorf("expected string")
+	case "duration":
+		if s, ok := value.(string); ok {
+			if _, err := time.ParseDuration(s); err == nil {
+				return []string{s}, nil
+			}
+		}
+		return nil, fmt.Errorf("expected duration string, e.g. 10s")
+	case "stringSlice", "stringArray":
+		items, ok := toList(value)
+		if !ok {
+			return nil, fmt.Errorf("expected list of strings")
+		}
+		var values []string
+		for _, item := range items {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("expected list of strings")
+			}
+			if typ == "stringSlice" && strings.ContainsAny(s, ",\"") {
+				return nil, fmt.Errorf("list item %q must not contain commas or quotes", s)
+			}
+			values = append(values, s)
+		}
+		return values, nil
+	case "intSlice":
+		items, ok := toList(value)
+		if !ok {
+			return nil, fmt.Errorf("expected list of integers")
+		}
+		var values []string
+		for _, item := range items {
+			i, ok := toInteger(item)
+			if !ok {
+				return nil, fmt.Errorf("expected list of integers")
+			}
+			values = append(values, strconv.FormatInt(i, 10))
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("unsupported type %s", typ)
+	}
+}
+
+func toInteger(value any) (int64, bool) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	case float64:
+		// numbers decoded from JSON
+		if v == math.Trunc(v) && math.Abs(v) < 1<<53 {
+			return int64(v), true
+		}
+	}
+	return 0, false
+}
+
+func toNumber(value any) (float64, bool) {
+	if v, ok := value.(float64); ok {
+		return v, true
+	}
+	if i, ok := toInteger(value); ok {
+		return float64(i), true
+	}
+	return 0, false
+}
+
+func toList(value any) ([]any, bool) {
+	switch v := value.(type) {
+	case []any:
+		return v, true
+	case []string:
+		items := make([]any, len(v))
+		for i := range v {
+			items[i] = v[i]
+		}
+		return items, true
+	case []int:
+		items := make([]any, len(v))
+		for i := range v {
+			items[i] = v[i]
+		}
+		return items, true
+	}
+	return nil, false
+}
+
+// ValidateJob validates a job strictly without resolving its targets from the cluster configuration.
+func ValidateJob(job config.Job) error {
+	if job.JobID == "" {
+		return fmt.Errorf("missing job ID")
+	}
+	args, err := JobArgs(job)
+	if err != nil {
+		return fmt.Errorf("invalid job %s: %w", job.JobID, err)
+	}
+	ra := &runnerArgs{}
+	if _, _, err := parseFlags(GetNewRoot(ra), ra, job, args); err != nil {
+		return fmt.Errorf("invalid job %s: %w", job.JobID, err)
+	}
+	return nil
+}
+
+// JobSchema returns the JSON schema of a job of the agent configuration.
+func JobSchema() map[string]any {
+	root := GetNewRoot(&runnerArgs{})
+	defs := map[string]any{}
+	var specs []any
+	for _, jobType := range JobTypes() {
+		cmd := jobTypeCmd(root, jobType)
+		targets := map[string]any{}
+		for _, s := range jobTargetSelectors {
+			if f := s.flag(cmd); f != nil {
+				targets[s.name] = flagSchema(f)
+			}
+		}
+		params := map[string]any{}
+		for _, f := range paramFlags(cmd) {
+			params[f.Name] = flagSchema(f)
+		}
+		defs[jobType] = map[string]any{
+			"description":          cmd.Short,
+			"type":                 "object",
+			"additionalProperties": false,
+			"required":             []string{"type"},
+			"properties": map[string]any{
+				"type":        map[string]any{"const": jobType},
+				"targets":     map[string]any{"type": "object", "additionalProperties": false, "properties": targets},
+				"params":      map[string]any{"type": "object", "additionalProperties": false, "properties": params},
+				"period":      map[string]any{"type": "string", "pattern": durationPattern, "description": "overwrites default execution period"},
+				"scalePeriod": map[string]any{"type": "boolean", "description": "scales period by number of nodes"},
+				"timeout":     map[string]any{"type": "string", "pattern": durationPattern, "description": "timeout of a single check attempt"},
+				"labels":      map[string]any{"type": "object", "additionalProperties": map[string]any{"type": "string"}},
+			},
+		}
+		specs = append(specs, map[string]any{"$ref": "#/$defs/" + jobType})
+	}
+	return map[string]any{
+		"$schema":              "https://json-schema.org/draft/2020-12/schema",
+		"title":                "Job",
+		"description":          "job of the network problem detector agent, defined either by args or by the typed spec",
+		"type":                 "object",
+		"additionalProperties": false,
+		"required":             []string{"jobID"},
+		"not":                  map[string]any{"required": []string{"args", "spec"}},
+		"properties": map[string]any{
+			"jobID":  map[string]any{"type": "string", "minLength": 1},
+			"args":   map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+			"expect": map[string]any{"enum": []string{config.ExpectReachable, config.ExpectBlocked}},
+			"spec":   map[string]any{"oneOf": specs},
+		},
+		"$defs": defs,
+	}
+}
+
+func flagSchema(f *pflag.Flag) map[string]any {
+	var schema map[string]any
+	switch f.Value.Type() {
+	case "bool":
+		schema = map[string]any{"type": "boolean"}
+	case "int", "int64":
+		schema = map[string]any{"type": "integer"}
+	case "float64":
+		schema = map[string]any{"type": "number"}
+	case "duration":
+		schema = map[string]any{"type": "string", "pattern": durationPattern}
+	case "stringSlice", "stringArray":
+		schema = map[string]any{"type": "array", "items": map[string]any{"type": "string"}}
+	case "intSlice":
+		schema = map[string]any{"type": "array", "items": map[string]any{"type": "integer"}}
+	default:
+		schema = map[string]any{"type": "string"}
+	}
+	schema["description"] = f.Usage
+	return schema
+}
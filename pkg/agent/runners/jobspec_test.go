@@ -0,0 +1,148 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runners
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gardener/network-problem-detector/pkg/common/config"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+var _ = Describe("job spec", func() {
+	DescribeTable("should build job args",
+		func(job config.Job, expected any) {
+			args, err := JobArgs(job)
+			switch v := expected.(type) {
+			case []string:
+				Expect(err).To(BeNil())
+				Expect(args).To(Equal(v))
+			case string:
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(ContainSubstring(v))
+			default:
+				Fail("unexpected type")
+			}
+		},
+		Entry("args", config.Job{JobID: "test", Args: []string{"checkTCPPort", "--node-port", "1234"}},
+			[]string{"checkTCPPort", "--node-port", "1234"}),
+		Entry("spec with period and timeout", config.Job{JobID: "test", Spec: &config.JobSpec{
+			Type:        "checkTCPPort",
+			Targets:     &config.JobTargets{NodePort: 1234},
+			Period:      &metav1.Duration{Duration: time.Minute},
+			ScalePeriod: true,
+			Timeout:     &metav1.Duration{Duration: 3 * time.Second},
+			Params:      map[string]any{"retries": 2},
+		}}, []string{"checkTCPPort", "--node-port=1234", "--retries=2", "--period=1m0s", "--scale-period", "--timeout=3s"}),
+		Entry("spec with endpoint of kube-apiserver", config.Job{JobID: "test", Spec: &config.JobSpec{
+			Type:    "checkHTTP",
+			Targets: &config.JobTargets{InternalKubeAPIServer: true},
+			Params:  map[string]any{"path": "/healthz", "header": []any{"X-A: 1", "X-B: 2"}, "insecure-skip-verify": true},
+		}}, []string{"checkHTTP", "--endpoint-internal-kube-apiserver=true", "--header=X-A: 1", "--header=X-B: 2", "--insecure-skip-verify=true", "--path=/healthz"}),
+		Entry("spec with DNS name of kube-apiserver", config.Job{JobID: "test", Spec: &config.JobSpec{
+			Type:    "nslookup",
+			Targets: &config.JobTargets{Names: []string{"eu.gcr.io", "foo.bar."}, InternalKubeAPIServer: true},
+		}}, []string{"nslookup", "--names=eu.gcr.io", "--names=foo.bar.", "--name-internal-kube-apiserver=true"}),
+		Entry("spec with numbers decoded from JSON", config.Job{JobID: "test", Spec: &config.JobSpec{
+			Type:    "checkPathMTU",
+			Targets: &config.JobTargets{PodDaemonSet: true},
+			Params:  map[string]any{"sizes": []any{float64(1500), float64(9000)}, "mtu-floor": float64(1400)},
+		}}, []string{"checkPathMTU", "--endpoints-of-pod-ds=true", "--mtu-floor=1400", "--sizes=1500", "--sizes=9000"}),
+		Entry("args and spec", config.Job{JobID: "test", Args: []string{"checkTCPPort"}, Spec: &config.JobSpec{Type: "checkTCPPort"}},
+			"args and spec are mutually exclusive"),
+		Entry("neither args nor spec", config.Job{JobID: "test"}, "no job args"),
+		Entry("unknown type", config.Job{JobID: "test", Spec: &config.JobSpec{Type: "checkFTP"}},
+			`unknown job type "checkFTP" (allowed types: checkClockSkew, checkDNS, checkExec, checkHTTP, checkHTTPSGet, checkPathMTU, checkPeerIdentity, checkTCPPort, checkTLSCert, checkThroughput, checkUDPPort, nslookup, pingHost)`),
+		Entry("unsupported target", config.Job{JobID: "test", Spec: &config.JobSpec{Type: "checkTCPPort", Targets: &config.JobTargets{Names: []string{"foo"}}}},
+			"target names not supported by job type checkTCPPort"),
+		Entry("unknown parameter", config.Job{JobID: "test", Spec: &config.JobSpec{Type: "checkTCPPort", Params: map[string]any{"metod": "GET"}}},
+			"unknown parameter metod for job type checkTCPPort"),
+		Entry("target as parameter", config.Job{JobID: "test", Spec: &config.JobSpec{Type: "checkTCPPort", Params: map[string]any{"node-port": 1234}}},
+			"unknown parameter node-port for job type checkTCPPort"),
+		Entry("dedicated field as parameter", config.Job{JobID: "test", Spec: &config.JobSpec{Type: "checkTCPPort", Params: map[string]any{"scale-period": true}}},
+			"parameter scale-period not allowed, use field scalePeriod of the job spec"),
+		Entry("invalid parameter type", config.Job{JobID: "test", Spec: &config.JobSpec{Type: "pingHost", Params: map[string]any{"count": "3"}}},
+			"invalid parameter count: expected integer"),
+		Entry("invalid duration parameter", config.Job{JobID: "test", Spec: &config.JobSpec{Type: "pingHost", Params: map[string]any{"interval": 10}}},
+			"invalid parameter interval: expected duration string, e.g. 10s"),
+		Entry("invalid label", config.Job{JobID: "test", Spec: &config.JobSpec{Type: "checkTCPPort", Labels: map[string]string{"-foo": "bar"}}},
+			`invalid label key "-foo": name part must consist of alphanumeric characters`),
+	)
+
+	DescribeTable("should validate jobs",
+		func(job config.Job, expectedErr string) {
+			err := ValidateJob(job)
+			if expectedErr == "" {
+				Expect(err).To(BeNil())
+				return
+			}
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(Equal(expectedErr))
+		},
+		Entry("valid spec", config.Job{JobID: "test", Spec: &config.JobSpec{Type: "checkUDPPort", Targets: &config.JobTargets{NodePort: 1234}}}, ""),
+		Entry("valid args", config.Job{JobID: "test", Args: []string{"checkUDPPort", "--node-port", "1234"}}, ""),
+		Entry("missing job ID", config.Job{Args: []string{"checkUDPPort"}}, "missing job ID"),
+		Entry("invalid parameter value", config.Job{JobID: "test", Spec: &config.JobSpec{Type: "checkUDPPort", Params: map[string]any{"retries": -1}}},
+			"invalid job test: invalid retries -1"),
+		Entry("unsupported expect", config.Job{JobID: "test", Spec: &config.JobSpec{Type: "nslookup"}, Expect: config.ExpectBlocked},
+			"invalid job test: expect blocked not supported by job type nslookup"),
+		Entry("unknown flag in args", config.Job{JobID: "test", Args: []string{"checkUDPPort", "--node-prot", "1234"}},
+			"invalid job test: unknown flag: --node-prot"),
+		Entry("missing job type in args", config.Job{JobID: "test", Args: []string{"--node-port", "1234"}},
+			"invalid job test: missing job type"),
+	)
+
+	It("should decode job specs strictly", func() {
+		job := config.Job{}
+		Expect(yaml.Unmarshal([]byte(`
+jobID: test
+spec:
+  type: checkHTTP
+  targets:
+    endpoints: ["server:10.0.0.9:8080"]
+  params:
+    scheme: http
+    retries: 2
+  timeout: 5s
+`), &job)).To(Succeed())
+		args, err := JobArgs(job)
+		Expect(err).To(BeNil())
+		Expect(args).To(Equal([]string{"checkHTTP", "--endpoints=server:10.0.0.9:8080", "--retries=2", "--scheme=http", "--timeout=5s"}))
+
+		err = yaml.Unmarshal([]byte(`
+jobID: test
+spec:
+  type: checkHTTP
+  target:
+    endpoints: ["server:10.0.0.9:8080"]
+`), &job)
+		Expect(err).NotTo(BeNil())
+		Expect(err.Error()).To(ContainSubstring(`invalid job spec: json: unknown field "target"`))
+	})
+
+	It("should generate JSON schema", func() {
+		schema := JobSchema()
+		_, err := json.Marshal(schema)
+		Expect(err).To(BeNil())
+		defs := schema["$defs"].(map[string]any)
+		Expect(defs).To(HaveLen(len(JobTypes())))
+		properties := func(jobType, field string) map[string]any {
+			return defs[jobType].(map[string]any)["properties"].(map[string]any)[field].(map[string]any)["properties"].(map[string]any)
+		}
+		Expect(properties("checkHTTP", "params")).To(HaveKey("method"))
+		Expect(properties("checkHTTP", "params")).To(HaveKey("retries"))
+		Expect(properties("checkHTTP", "params")).NotTo(HaveKey("timeout"))
+		Expect(properties("checkHTTP", "params")).NotTo(HaveKey("endpoints"))
+		Expect(properties("checkTCPPort", "targets")).To(HaveKey("nodePort"))
+		Expect(properties("checkTCPPort", "targets")).NotTo(HaveKey("names"))
+		Expect(properties("nslookup", "targets")).To(HaveKey("internalKubeAPIServer"))
+		Expect(properties("checkPathMTU", "params")["sizes"]).To(HaveKeyWithValue("type", "array"))
+	})
+})
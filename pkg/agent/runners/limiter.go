@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runners
+
+import "sync"
+
+// Limiter limits the number of concurrent checks of all scheduled runs and probes of an agent.
+// A nil limiter does not limit.
+type Limiter struct {
+	lock     sync.Mutex
+	slots    chan struct{}
+	released func()
+}
+
+// NewLimiter creates a limiter for the given maximum number of concurrent checks.
+// The optional released function is called whenever a slot is released.
+func NewLimiter(maxConcurrent int, released func()) *Limiter {
+	return &Limiter{
+		slots:    make(chan struct{}, maxConcurrent),
+		released: released,
+	}
+}
+
+// SetMax changes the maximum number of concurrent checks. Checks holding a slot are not affected.
+func (l *Limiter) SetMax(maxConcurrent int) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if cap(l.slots) != maxConcurrent {
+		l.slots = make(chan struct{}, maxConcurrent)
+	}
+}
+
+// TryAcquire acquires a slot without waiting. It returns the function to release the slot or nil if no slot is free.
+func (l *Limiter) TryAcquire() func() {
+	if l == nil {
+		return func() {}
+	}
+	slots := l.currentSlots()
+	select {
+	case slots <- struct{}{}:
+		return l.releaseFunc(slots)
+	default:
+		return nil
+	}
+}
+
+// Acquire waits for a free slot and returns the function to release it.
+func (l *Limiter) Acquire() func() {
+	if l == nil {
+		return func() {}
+	}
+	slots := l.currentSlots()
+	slots <- struct{}{}
+	return l.releaseFunc(slots)
+}
+
+func (l *Limiter) currentSlots() chan struct{} {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.slots
+}
+
+func (l *Limiter) releaseFunc(slots chan struct{}) func() {
+	return func() {
+		<-slots
+		if l.released != nil {
+			l.released()
+		}
+	}
+}
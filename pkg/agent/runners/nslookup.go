@@ -6,14 +6,20 @@ package runners
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"net"
+	"time"
 
 	"github.com/gardener/network-problem-detector/pkg/common/config"
+	"github.com/gardener/network-problem-detector/pkg/common/nwpd"
 
 	"github.com/spf13/cobra"
 )
 
+// defaultLookupTimeout is the default timeout of a DNS lookup.
+const defaultLookupTimeout = 10 * time.Second
+
 type nslookupArgs struct {
 	runnerArgs   *runnerArgs
 	internalKAPI bool
@@ -75,8 +81,10 @@ func NewNSLookup(names []string, rconfig RunnerConfig) Runner {
 		robinRound[dnsName]{
 			itemsName: "names",
 			items:     config.CloneAndShuffle(dnsNames),
-			runFunc:   lookupFunc,
-			config:    rconfig,
+			runFunc: func(name dnsName, _ *nwpd.Observation) (string, error) {
+				return lookupFunc(name, rconfig.timeoutOr(defaultLookupTimeout))
+			},
+			config: rconfig,
 		},
 	}
 }
@@ -93,8 +101,10 @@ type nslookup struct {
 
 var _ Runner = &nslookup{}
 
-func lookupFunc(name dnsName) (string, error) {
-	ips, err := net.LookupIP(string(name))
+func lookupFunc(name dnsName, timeout time.Duration) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	ips, err := net.DefaultResolver.LookupIP(ctx, "ip", string(name))
 	if err != nil {
 		return "", err
 	}
@@ -5,6 +5,7 @@
 package runners
 
 import (
+	"fmt"
 	"math"
 	"time"
 
@@ -13,12 +14,23 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// defaultMaxParallel is the default maximum number of concurrent checks of a fan-out run or a probe.
+const defaultMaxParallel = 8
+
 type runnerArgs struct {
 	args        []string
 	clusterCfg  config.ClusterConfig
 	config      RunnerConfig
 	period      time.Duration
+	periodSet   bool
 	scalePeriod bool
+	fanOut      int
+	maxParallel int
+	stagger     time.Duration
+	timeout     time.Duration
+	timeoutSet  bool
+	retries     int
+	retryDelay  time.Duration
 	runner      Runner
 }
 
@@ -30,9 +42,94 @@ func (ra *runnerArgs) prepareConfig() RunnerConfig {
 	if ra.scalePeriod && len(ra.clusterCfg.Nodes) > 1 {
 		cfg.Period = time.Duration(math.Pow(float64(ra.clusterCfg.NodeCount), float64(0.6)) * float64(cfg.Period))
 	}
+	if ra.fanOut != 0 {
+		cfg.FanOut = ra.fanOut
+		cfg.MaxParallel = ra.maxParallel
+		cfg.Stagger = ra.stagger
+	}
+	if ra.timeoutSet {
+		cfg.Timeout = ra.timeout
+	}
+	if ra.retries > 0 {
+		cfg.Retries = ra.retries
+		cfg.RetryDelay = ra.retryDelay
+	}
 	return cfg
 }
 
+func (ra *runnerArgs) validate() error {
+	if ra.periodSet && ra.period <= 0 {
+		return fmt.Errorf("invalid period %s", ra.period)
+	}
+	if ra.timeoutSet && ra.timeout <= 0 {
+		return fmt.Errorf("invalid timeout %s", ra.timeout)
+	}
+	if ra.retries < 0 {
+		return fmt.Errorf("invalid retries %d", ra.retries)
+	}
+	if ra.retryDelay < 0 {
+		return fmt.Errorf("invalid retry delay %s", ra.retryDelay)
+	}
+	if ra.fanOut == 0 {
+		return nil
+	}
+	if ra.maxParallel < 1 {
+		return fmt.Errorf("invalid max parallel %d", ra.maxParallel)
+	}
+	if ra.stagger < 0 {
+		return fmt.Errorf("invalid stagger %s", ra.stagger)
+	}
+	return nil
+}
+
+// expectBlockedJobTypes are the job types supporting negative checks.
+var expectBlockedJobTypes = map[string]bool{
+	"checkTCPPort": true,
+	"checkUDPPort": true,
+	"checkHTTP":    true,
+	"pingHost":     true,
+}
+
+func validateExpect(jobType string, job config.Job) error {
+	switch job.Expect {
+	case "", config.ExpectReachable:
+		return nil
+	case config.ExpectBlocked:
+		if !expectBlockedJobTypes[jobType] {
+			return fmt.Errorf("expect %s not supported by job type %s", job.Expect, jobType)
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid expect %s (allowed values: %s, %s)", job.Expect, config.ExpectReachable, config.ExpectBlocked)
+	}
+}
+
+// parseFlags finds the command of the job type and parses and validates its flags.
+func parseFlags(root *cobra.Command, ra *runnerArgs, job config.Job, args []string) (*cobra.Command, []string, error) {
+	cmd, flags, err := root.Find(args)
+	if err != nil {
+		return nil, nil, err
+	}
+	if cmd == root {
+		return nil, nil, fmt.Errorf("missing job type")
+	}
+
+	err = cmd.ParseFlags(flags)
+	if err != nil {
+		return nil, nil, cmd.FlagErrorFunc()(cmd, err)
+	}
+	ra.periodSet = cmd.Flags().Changed("period")
+	ra.timeoutSet = cmd.Flags().Changed("timeout")
+	if err := ra.validate(); err != nil {
+		return nil, nil, err
+	}
+
+	if err := validateExpect(cmd.Name(), job); err != nil {
+		return nil, nil, err
+	}
+	return cmd, flags, nil
+}
+
 func GetNewRoot(ra *runnerArgs) *cobra.Command {
 	root := &cobra.Command{
 		Use:   "runner",
@@ -40,27 +137,35 @@ func GetNewRoot(ra *runnerArgs) *cobra.Command {
 	}
 	root.PersistentFlags().DurationVar(&ra.period, "period", 0, "overwrites default execution period")
 	root.PersistentFlags().BoolVar(&ra.scalePeriod, "scale-period", false, "scales period by number of nodes")
+	root.PersistentFlags().DurationVar(&ra.timeout, "timeout", 0, "timeout of a single check attempt (default depends on job type)")
+	root.PersistentFlags().IntVar(&ra.retries, "retries", 0, "number of retries of a failed check before reporting it as failed")
+	root.PersistentFlags().DurationVar(&ra.retryDelay, "retry-delay", 1*time.Second, "delay before retrying a failed check")
+	root.PersistentFlags().IntVar(&ra.fanOut, "fan-out", 0, "number of items checked concurrently per run (-1 for all items, 0 or 1 for one item per run in round robin order)")
+	root.PersistentFlags().IntVar(&ra.maxParallel, "max-parallel", defaultMaxParallel, "maximum number of concurrent checks of a fan-out run")
+	root.PersistentFlags().DurationVar(&ra.stagger, "stagger", 50*time.Millisecond, "delay between the starts of the checks of a fan-out run (limited to spread the starts over half of the period)")
 	root.AddCommand(createPingHostCmd(ra))
 	root.AddCommand(createCheckTCPPortCmd(ra))
+	root.AddCommand(createCheckUDPPortCmd(ra))
+	root.AddCommand(createCheckPathMTUCmd(ra))
 	root.AddCommand(createCheckHTTPSGetArgs(ra))
+	root.AddCommand(createCheckHTTPCmd(ra))
+	root.AddCommand(createCheckTLSCertCmd(ra))
+	root.AddCommand(createCheckPeerIdentityCmd(ra))
+	root.AddCommand(createCheckClockSkewCmd(ra))
+	root.AddCommand(createCheckThroughputCmd(ra))
 	root.AddCommand(createNSLookupCmd(ra))
+	root.AddCommand(createCheckDNSCmd(ra))
+	root.AddCommand(createCheckExecCmd(ra))
 	return root
 }
 
 func Parse(clusterCfg config.ClusterConfig, config RunnerConfig, args []string, sampleCfg *config.SampleConfig) (*InternalJob, error) {
 	ra := &runnerArgs{}
-	root := GetNewRoot(ra)
-
-	cmd, flags, err := root.Find(args)
+	cmd, flags, err := parseFlags(GetNewRoot(ra), ra, config.Job, args)
 	if err != nil {
 		return nil, err
 	}
 
-	err = cmd.ParseFlags(flags)
-	if err != nil {
-		return nil, cmd.FlagErrorFunc()(cmd, err)
-	}
-
 	ra.args = args
 	ra.clusterCfg = sampleCfg.ShuffledSample(clusterCfg)
 	ra.config = config
@@ -72,5 +177,7 @@ func Parse(clusterCfg config.ClusterConfig, config RunnerConfig, args []string,
 	if ra.runner == nil {
 		return nil, nil
 	}
-	return NewInternalJob(ra.runner, len(ra.clusterCfg.Nodes)), nil
+	job := NewInternalJob(ra.runner, len(ra.clusterCfg.Nodes))
+	job.args = args
+	return job, nil
 }
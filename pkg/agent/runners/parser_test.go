@@ -18,6 +18,11 @@ func init() {
 	config.DisableShuffleForTesting = true
 }
 
+// testOptions is implemented by runners with options to compare them in tests.
+type testOptions interface {
+	TestOptions() any
+}
+
 var _ = Describe("parser", func() {
 	var (
 		config1     = RunnerConfig{Job: config.Job{JobID: "test"}, Period: 15 * time.Second}
@@ -36,20 +41,41 @@ var _ = Describe("parser", func() {
 				IP:       "100.64.0.1",
 				Port:     443,
 			},
+			KubeDNSService: &config.Endpoint{
+				Hostname: common.DomainNameKubeDNSService,
+				IP:       "100.64.0.10",
+				Port:     53,
+			},
+			KubeDNSPods: []config.PodEndpoint{
+				{Nodename: "node1", Podname: "coredns-1", PodIP: "10.128.0.21", Port: 8053},
+				{Nodename: "node2", Podname: "coredns-2", PodIP: "10.128.0.22", Port: 8053},
+			},
 			KubeAPIServer: &config.Endpoint{
 				Hostname: "api.shoot.domain.com",
 				IP:       "1.2.3.4",
 				Port:     443,
 			},
 		}
-		config2     = RunnerConfig{Job: config.Job{JobID: "test"}, Period: 10 * time.Second}
-		clusterCfg2 = config.ClusterConfig{
+		config2         = RunnerConfig{Job: config.Job{JobID: "test"}, Period: 10 * time.Second}
+		configBlocked   = RunnerConfig{Job: config.Job{JobID: "test", Expect: config.ExpectBlocked}}
+		endpointsCanary = []config.Endpoint{{Hostname: "canary", Port: 8882}}
+		clusterCfg2     = config.ClusterConfig{
 			NodeCount: 2,
 			Nodes: []config.Node{
 				{Hostname: "node3", InternalIPs: []string{"10.0.0.13"}},
 				{Hostname: "node4", InternalIPs: []string{"10.0.0.14"}},
 			},
 		}
+		clusterCfgDualStack = config.ClusterConfig{
+			NodeCount: 2,
+			Nodes: []config.Node{
+				{Hostname: "node1", InternalIPs: []string{"10.0.0.11"}, InternalIPsV6: []string{"fd00::11"}},
+				{Hostname: "node2", InternalIPs: []string{"10.0.0.12"}},
+			},
+		}
+		nodesIPv6 = []config.Node{
+			{Hostname: "node1", InternalIPs: []string{"10.0.0.11"}, InternalIPsV6: []string{"fd00::11"}},
+		}
 		endpoints1 = []config.Endpoint{
 			{Hostname: "server", IP: "10.0.0.9", Port: 55555},
 		}
@@ -61,6 +87,14 @@ var _ = Describe("parser", func() {
 			{Hostname: "node1", IP: "10.128.0.11", Port: 1234},
 			{Hostname: "node2", IP: "10.128.0.12", Port: 1234},
 		}
+		peerEndpointsNodes = []PeerEndpoint{
+			{Endpoint: config.Endpoint{Hostname: "node1", IP: "10.0.0.11", Port: 12996}},
+			{Endpoint: config.Endpoint{Hostname: "node2", IP: "10.0.0.12", Port: 12996}},
+		}
+		peerEndpointsPods = []PeerEndpoint{
+			{Endpoint: config.Endpoint{Hostname: "node1", IP: "10.128.0.11", Port: 1234}, Podname: "pod1"},
+			{Endpoint: config.Endpoint{Hostname: "node2", IP: "10.128.0.12", Port: 1234}, Podname: "pod2"},
+		}
 		endpointsInternalKubeAPIServer = []config.Endpoint{
 			{Hostname: common.DomainNameKubernetesService, IP: "100.64.0.1", Port: 443},
 		}
@@ -75,6 +109,17 @@ var _ = Describe("parser", func() {
 		httpsEndpointsInternalKubeAPIServer = []config.Endpoint{
 			{Hostname: common.DomainNameKubernetesService, IP: "", Port: 443},
 		}
+		dnsServers = []config.Endpoint{
+			{Hostname: "dns1", IP: "10.0.0.53", Port: 53},
+			{Hostname: "dns2", IP: "10.0.0.54", Port: 5353},
+		}
+		dnsServersKubeDNSService = []config.Endpoint{
+			{Hostname: common.DomainNameKubeDNSService, IP: "100.64.0.10", Port: 53},
+		}
+		dnsServersKubeDNSPods = []config.Endpoint{
+			{Hostname: "coredns-1", IP: "10.128.0.21", Port: 8053},
+			{Hostname: "coredns-2", IP: "10.128.0.22", Port: 8053},
+		}
 		dnsnames = []string{
 			"eu.gcr.io.", "foo.bar.", common.DomainNameKubernetesService, "api.shoot.domain.com.",
 		}
@@ -88,6 +133,9 @@ var _ = Describe("parser", func() {
 				Expect(err).To(BeNil())
 				Expect(actual.Config()).To(Equal(v.Config()))
 				Expect(actual.runner.TestData()).To(Equal(v.TestData()))
+				if expectedOptions, ok := v.(testOptions); ok {
+					Expect(actual.runner.(testOptions).TestOptions()).To(Equal(expectedOptions.TestOptions()))
+				}
 			case string:
 				Expect(err).NotTo(BeNil())
 				Expect(err.Error()).To(ContainSubstring(v))
@@ -97,15 +145,33 @@ var _ = Describe("parser", func() {
 		},
 
 		Entry("pingHost", clusterCfg1, config1,
-			[]string{"pingHost"}, NewPingHost(clusterCfg1.Nodes, config1)),
+			[]string{"pingHost"}, NewPingHost(clusterCfg1.Nodes, DefaultPingOptions(), config1)),
 		Entry("pingHost with hosts and custom period", clusterCfg1, config1,
-			[]string{"pingHost", "--period", "10s", "--hosts", "node3:10.0.0.13,node4:10.0.0.14"}, NewPingHost(clusterCfg2.Nodes, config2)),
+			[]string{"pingHost", "--period", "10s", "--hosts", "node3:10.0.0.13,node4:10.0.0.14"}, NewPingHost(clusterCfg2.Nodes, DefaultPingOptions(), config2)),
+		Entry("pingHost with multiple packets", clusterCfg1, config1,
+			[]string{"pingHost", "--count", "5", "--interval", "100ms", "--size", "56", "--max-loss-percent", "20"},
+			NewPingHost(clusterCfg1.Nodes, PingOptions{Count: 5, Interval: 100 * time.Millisecond, Size: 56, MaxLossPercent: 20}, config1)),
+		Entry("pingHost with IPv6", clusterCfgDualStack, config1,
+			[]string{"pingHost", "--ipv6"}, NewPingHost(nodesIPv6, PingOptions{Count: 1, Interval: 200 * time.Millisecond, Size: 24, IPv6: true}, config1)),
+		Entry("pingHost with IPv6 hosts", clusterCfg1, config1,
+			[]string{"pingHost", "--ipv6", "--hosts", "node3:10.0.0.13,node4:fd00::14"},
+			NewPingHost([]config.Node{{Hostname: "node4", InternalIPsV6: []string{"fd00::14"}}}, PingOptions{Count: 1, Interval: 200 * time.Millisecond, Size: 24, IPv6: true}, config1)),
+		Entry("pingHost - invalid count", clusterCfg1, config1,
+			[]string{"pingHost", "--count", "0"}, "invalid count 0"),
+		Entry("pingHost - invalid max loss", clusterCfg1, config1,
+			[]string{"pingHost", "--max-loss-percent", "100"}, "invalid max-loss-percent"),
 		Entry("pingHost - invalid option", clusterCfg1, config1,
 			[]string{"pingHost", "--foo"}, "unknown flag: --foo"),
 		Entry("pingHost - invalid host", clusterCfg1, config1,
 			[]string{"pingHost", "--hosts", "node3"}, "invalid host node3"),
 		Entry("checkTCPPort", clusterCfg1, config1,
 			[]string{"checkTCPPort", "--period", "10s", "--endpoints", "server:10.0.0.9:55555"}, NewCheckTCPPort(endpoints1, config2)),
+		Entry("checkTCPPort expecting blocked", clusterCfg1, configBlocked,
+			[]string{"checkTCPPort", "--endpoints", "canary::8882"}, NewCheckTCPPort(endpointsCanary, configBlocked)),
+		Entry("checkTCPPort - invalid expect", clusterCfg1, RunnerConfig{Job: config.Job{JobID: "test", Expect: "maybe"}},
+			[]string{"checkTCPPort", "--endpoints", "canary::8882"}, "invalid expect maybe (allowed values: reachable, blocked)"),
+		Entry("checkDNS - expect blocked not supported", clusterCfg1, configBlocked,
+			[]string{"checkDNS", "--servers-kube-dns-service", "--name-internal-kube-apiserver"}, "expect blocked not supported by job type checkDNS"),
 		Entry("checkTCPPort - missing endpoints", clusterCfg1, config1,
 			[]string{"checkTCPPort"}, "no endpoints"),
 		Entry("checkTCPPort - invalid endpoint", clusterCfg1, config1,
@@ -118,6 +184,58 @@ var _ = Describe("parser", func() {
 			[]string{"checkTCPPort", "--endpoint-internal-kube-apiserver"}, NewCheckTCPPort(endpointsInternalKubeAPIServer, config1)),
 		Entry("checkTCPPort with external kube-apiserver endpoints", clusterCfg1, config1,
 			[]string{"checkTCPPort", "--endpoint-external-kube-apiserver"}, NewCheckTCPPort(endpointsKubeAPIServer, config1)),
+		Entry("checkTCPPort with fan-out", clusterCfg1, config1,
+			[]string{"checkTCPPort", "--node-port", "55555", "--fan-out", "-1", "--max-parallel", "4"},
+			NewCheckTCPPort(endpoints2, RunnerConfig{Job: config1.Job, Period: config1.Period, FanOut: -1, MaxParallel: 4, Stagger: 50 * time.Millisecond})),
+		Entry("checkTCPPort - invalid max parallel", clusterCfg1, config1,
+			[]string{"checkTCPPort", "--node-port", "55555", "--fan-out", "5", "--max-parallel", "0"}, "invalid max parallel 0"),
+		Entry("checkTCPPort - invalid stagger", clusterCfg1, config1,
+			[]string{"checkTCPPort", "--node-port", "55555", "--fan-out", "5", "--stagger", "-1s"}, "invalid stagger -1s"),
+		Entry("checkTCPPort with timeout and retries", clusterCfg1, config1,
+			[]string{"checkTCPPort", "--node-port", "55555", "--timeout", "3s", "--retries", "2"},
+			NewCheckTCPPort(endpoints2, RunnerConfig{Job: config1.Job, Period: config1.Period, Timeout: 3 * time.Second, Retries: 2, RetryDelay: 1 * time.Second})),
+		Entry("checkTCPPort keeps configured timeout without timeout flag", clusterCfg1,
+			RunnerConfig{Job: config1.Job, Period: config1.Period, Timeout: 4 * time.Second},
+			[]string{"checkTCPPort", "--node-port", "55555"},
+			NewCheckTCPPort(endpoints2, RunnerConfig{Job: config1.Job, Period: config1.Period, Timeout: 4 * time.Second})),
+		Entry("checkTCPPort - negative period", clusterCfg1, config1,
+			[]string{"checkTCPPort", "--node-port", "55555", "--period", "-1s"}, "invalid period -1s"),
+		Entry("checkTCPPort - zero period", clusterCfg1, config1,
+			[]string{"checkTCPPort", "--node-port", "55555", "--period", "0s"}, "invalid period 0s"),
+		Entry("checkTCPPort - invalid timeout", clusterCfg1, config1,
+			[]string{"checkTCPPort", "--node-port", "55555", "--timeout", "0s"}, "invalid timeout 0s"),
+		Entry("checkTCPPort - invalid retries", clusterCfg1, config1,
+			[]string{"checkTCPPort", "--node-port", "55555", "--retries", "-1"}, "invalid retries -1"),
+		Entry("checkUDPPort", clusterCfg1, config1,
+			[]string{"checkUDPPort", "--period", "10s", "--endpoints", "server:10.0.0.9:55555"}, NewCheckUDPPort(endpoints1, config2)),
+		Entry("checkUDPPort - missing endpoints", clusterCfg1, config1,
+			[]string{"checkUDPPort"}, "no endpoints"),
+		Entry("checkUDPPort with node port", clusterCfg1, config1,
+			[]string{"checkUDPPort", "--node-port", "55555"}, NewCheckUDPPort(endpoints2, config1)),
+		Entry("checkUDPPort with pod endpoints", clusterCfg1, config1,
+			[]string{"checkUDPPort", "--endpoints-of-pod-ds"}, NewCheckUDPPort(endpointsPods, config1)),
+		Entry("checkPathMTU with node port", clusterCfg1, config1,
+			[]string{"checkPathMTU", "--node-port", "55555"}, NewCheckPathMTU(endpoints2, DefaultPathMTUOptions(), config1)),
+		Entry("checkPathMTU with pod endpoints and sizes", clusterCfg1, config1,
+			[]string{"checkPathMTU", "--endpoints-of-pod-ds", "--sizes", "1500,1400,1450", "--mtu-floor", "1400"},
+			NewCheckPathMTU(endpointsPods, PathMTUOptions{Sizes: []int{1500, 1400, 1450}, MinMTU: 1200, MaxMTU: 1500, MTUFloor: 1400, ProbeTimeout: 500 * time.Millisecond}, config1)),
+		Entry("checkPathMTU with disabled floor", clusterCfg1, config1,
+			[]string{"checkPathMTU", "--node-port", "55555", "--mtu-floor", "0"},
+			NewCheckPathMTU(endpoints2, PathMTUOptions{MinMTU: 1200, MaxMTU: 1500, ProbeTimeout: 500 * time.Millisecond}, config1)),
+		Entry("checkPathMTU with timeout", clusterCfg1, config1,
+			[]string{"checkPathMTU", "--node-port", "55555", "--timeout", "2s"},
+			NewCheckPathMTU(endpoints2, PathMTUOptions{MinMTU: 1200, MaxMTU: 1500, MTUFloor: 1280, ProbeTimeout: 2 * time.Second},
+				RunnerConfig{Job: config.Job{JobID: "test"}, Period: 15 * time.Second, Timeout: 2 * time.Second})),
+		Entry("checkPathMTU - probe timeout flag removed", clusterCfg1, config1,
+			[]string{"checkPathMTU", "--node-port", "55555", "--probe-timeout", "2s"}, "unknown flag: --probe-timeout"),
+		Entry("checkPathMTU - invalid floor", clusterCfg1, config1,
+			[]string{"checkPathMTU", "--node-port", "55555", "--mtu-floor", "-1"}, "invalid MTU floor -1"),
+		Entry("checkPathMTU - missing endpoints", clusterCfg1, config1,
+			[]string{"checkPathMTU"}, "no endpoints"),
+		Entry("checkPathMTU - invalid range", clusterCfg1, config1,
+			[]string{"checkPathMTU", "--node-port", "55555", "--min-mtu", "1500", "--max-mtu", "1400"}, "invalid MTU range [1500,1400]"),
+		Entry("checkPathMTU - too small size", clusterCfg1, config1,
+			[]string{"checkPathMTU", "--node-port", "55555", "--sizes", "100,1500"}, "invalid MTU size 100"),
 		Entry("checkHTTPSGet", clusterCfg1, config1,
 			[]string{"checkHTTPSGet", "--period", "10s", "--endpoints", "server:55555,server2"}, NewCheckTCPPort(httpsEndpoints1, config2)),
 		Entry("checkHTTPSGet - missing endpoints", clusterCfg1, config1,
@@ -128,8 +246,93 @@ var _ = Describe("parser", func() {
 			[]string{"checkHTTPSGet", "--endpoint-internal-kube-apiserver"}, NewCheckHTTPSGet(httpsEndpointsInternalKubeAPIServer, config1)),
 		Entry("checkHTTPSGet with external kube-apiserver endpoints", clusterCfg1, config1,
 			[]string{"checkHTTPSGet", "--endpoint-external-kube-apiserver"}, NewCheckHTTPSGet(endpointsKubeAPIServer, config1)),
+		Entry("checkHTTP", clusterCfg1, config1,
+			[]string{"checkHTTP", "--period", "10s", "--endpoints", "server:10.0.0.9:55555", "--scheme", "http", "--method", "head",
+				"--path", "/healthz", "--header", "X-Foo: a, b", "--expected-status", "200-204", "--body-regex", "^ok$"},
+			NewCheckHTTP(endpoints1, HTTPOptions{}, config2)),
+		Entry("checkHTTP with internal kube-apiserver endpoints", clusterCfg1, config1,
+			[]string{"checkHTTP", "--endpoint-internal-kube-apiserver", "--path", "/livez", "--expected-status", "401"},
+			NewCheckHTTP(endpointsInternalKubeAPIServer, HTTPOptions{}, config1)),
+		Entry("checkHTTP - missing endpoints", clusterCfg1, config1,
+			[]string{"checkHTTP"}, "no endpoints"),
+		Entry("checkHTTP - invalid scheme", clusterCfg1, config1,
+			[]string{"checkHTTP", "--node-port", "55555", "--scheme", "ftp"}, "unsupported scheme ftp"),
+		Entry("checkHTTP - invalid header", clusterCfg1, config1,
+			[]string{"checkHTTP", "--node-port", "55555", "--header", "foo"}, "invalid header foo"),
+		Entry("checkHTTP - invalid status range", clusterCfg1, config1,
+			[]string{"checkHTTP", "--node-port", "55555", "--expected-status", "400-200"}, "invalid expected status 400-200"),
+		Entry("checkHTTP - invalid body regex", clusterCfg1, config1,
+			[]string{"checkHTTP", "--node-port", "55555", "--body-regex", "("}, "invalid body regex"),
+		Entry("checkHTTP - missing CA bundle", clusterCfg1, config1,
+			[]string{"checkHTTP", "--node-port", "55555", "--ca-bundle", "/not/existing/ca.crt"}, "reading CA bundle failed"),
+		Entry("checkTLSCert with internal kube-apiserver endpoints", clusterCfg1, config1,
+			[]string{"checkTLSCert", "--endpoint-internal-kube-apiserver", "--min-days-valid", "30"},
+			NewCheckTLSCert(endpointsInternalKubeAPIServer, TLSCertOptions{}, config1)),
+		Entry("checkTLSCert with external kube-apiserver endpoints", clusterCfg1, config1,
+			[]string{"checkTLSCert", "--period", "10s", "--endpoint-external-kube-apiserver", "--insecure-skip-verify"},
+			NewCheckTLSCert(endpointsKubeAPIServer, TLSCertOptions{}, config2)),
+		Entry("checkTLSCert - missing endpoints", clusterCfg1, config1,
+			[]string{"checkTLSCert"}, "no endpoints"),
+		Entry("checkTLSCert - invalid minimal days valid", clusterCfg1, config1,
+			[]string{"checkTLSCert", "--endpoint-internal-kube-apiserver", "--min-days-valid", "-1"}, "invalid minimal days valid -1"),
+		Entry("checkTLSCert - missing CA bundle", clusterCfg1, config1,
+			[]string{"checkTLSCert", "--endpoint-internal-kube-apiserver", "--ca-bundle", "/not/existing/ca.crt"}, "reading CA bundle failed"),
+		Entry("checkPeerIdentity with node port", clusterCfg1, config1,
+			[]string{"checkPeerIdentity", "--node-port", "12996"},
+			NewCheckPeerIdentity(peerEndpointsNodes, PeerIdentityOptions{}, config1)),
+		Entry("checkPeerIdentity with pod endpoints", clusterCfg1, config1,
+			[]string{"checkPeerIdentity", "--period", "10s", "--endpoints-of-pod-ds", "--check-source-ip"},
+			NewCheckPeerIdentity(peerEndpointsPods, PeerIdentityOptions{}, config2)),
+		Entry("checkPeerIdentity - missing endpoints", clusterCfg1, config1,
+			[]string{"checkPeerIdentity"}, "no endpoints"),
+		Entry("checkPeerIdentity - invalid timeout", clusterCfg1, config1,
+			[]string{"checkPeerIdentity", "--node-port", "12996", "--timeout", "0s"}, "invalid timeout 0s"),
+		Entry("checkClockSkew with node port", clusterCfg1, config1,
+			[]string{"checkClockSkew", "--node-port", "12996", "--samples", "2"},
+			NewCheckClockSkew(peerEndpointsNodes, ClockSkewOptions{}, config1)),
+		Entry("checkClockSkew - invalid samples", clusterCfg1, config1,
+			[]string{"checkClockSkew", "--node-port", "12996", "--samples", "0"}, "invalid samples 0"),
+		Entry("checkThroughput with pod endpoints", clusterCfg1, config1,
+			[]string{"checkThroughput", "--period", "5m", "--endpoints-of-pod-ds", "--direction", "upload", "--max-mbps", "50"},
+			NewCheckThroughput(peerEndpointsPods, ThroughputOptions{}, RunnerConfig{Job: config.Job{JobID: "test"}, Period: 5 * time.Minute})),
+		Entry("checkThroughput - invalid direction", clusterCfg1, config1,
+			[]string{"checkThroughput", "--node-port", "12996", "--direction", "sideways"}, "invalid direction sideways"),
+		Entry("checkThroughput - invalid bytes", clusterCfg1, config1,
+			[]string{"checkThroughput", "--node-port", "12996", "--bytes", "100000000"}, "invalid bytes 100000000 (allowed range: 1-67108864)"),
+		Entry("checkThroughput - invalid min rate", clusterCfg1, config1,
+			[]string{"checkThroughput", "--node-port", "12996", "--min-mbps", "200"}, "invalid min rate 200 Mbit/s"),
+		Entry("checkThroughput - timeout too short", clusterCfg1, config1,
+			[]string{"checkThroughput", "--node-port", "12996", "--max-mbps", "1", "--timeout", "10s"}, "timeout 10s too short for transferring 10000000 bytes with max rate 1 Mbit/s"),
+		Entry("checkThroughput - period too short", clusterCfg1, config1,
+			[]string{"checkThroughput", "--node-port", "12996", "--period", "1m"}, "period 1m0s too short for throughput checks (minimum 5m0s)"),
+		Entry("checkThroughput - default period too short", clusterCfg1, config1,
+			[]string{"checkThroughput", "--node-port", "12996"}, "period 15s too short for throughput checks (minimum 5m0s)"),
 		Entry("nslookup with host names", clusterCfg1, config1,
 			[]string{"nslookup", "--names", "eu.gcr.io,foo.bar.", "--name-internal-kube-apiserver", "--name-external-kube-apiserver"},
 			NewNSLookup(dnsnames, config1)),
+		Entry("checkDNS with servers", clusterCfg1, config1,
+			[]string{"checkDNS", "--servers", "dns1:10.0.0.53,dns2:10.0.0.54:5353", "--names", "eu.gcr.io", "--types", "A,AAAA,SRV", "--protocol", "tcp"},
+			NewCheckDNS(dnsServers, DNSQueryOptions{}, config1)),
+		Entry("checkDNS with kube-dns service", clusterCfg1, config1,
+			[]string{"checkDNS", "--servers-kube-dns-service", "--name-internal-kube-apiserver", "--expect", "100.64.0.1"},
+			NewCheckDNS(dnsServersKubeDNSService, DNSQueryOptions{}, config1)),
+		Entry("checkDNS with kube-dns pods", clusterCfg1, config1,
+			[]string{"checkDNS", "--servers-kube-dns-pods", "--name-internal-kube-apiserver"},
+			NewCheckDNS(dnsServersKubeDNSPods, DNSQueryOptions{}, config1)),
+		Entry("checkDNS - missing servers", clusterCfg1, config1,
+			[]string{"checkDNS", "--names", "eu.gcr.io"}, "no DNS servers"),
+		Entry("checkDNS - missing names", clusterCfg1, config1,
+			[]string{"checkDNS", "--servers-kube-dns-service"}, "no DNS names"),
+		Entry("checkDNS - invalid record type", clusterCfg1, config1,
+			[]string{"checkDNS", "--servers-kube-dns-service", "--name-internal-kube-apiserver", "--types", "MX"}, "unsupported record type MX"),
+		Entry("checkDNS - invalid protocol", clusterCfg1, config1,
+			[]string{"checkDNS", "--servers-kube-dns-service", "--name-internal-kube-apiserver", "--protocol", "quic"}, "unsupported protocol quic"),
+		Entry("checkExec with endpoints", clusterCfg1, config1,
+			[]string{"checkExec", "--period", "10s", "--endpoints", "server:10.0.0.9:55555", "--command", "/bin/probe", "--arg", "{ip}:{port}", "--env", "TARGET={hostname}"},
+			NewCheckExec(endpoints1, ExecOptions{}, config2)),
+		Entry("checkExec - missing command", clusterCfg1, config1,
+			[]string{"checkExec", "--endpoints", "server:10.0.0.9:55555"}, "missing command"),
+		Entry("checkExec - invalid env", clusterCfg1, config1,
+			[]string{"checkExec", "--endpoints", "server:10.0.0.9:55555", "--command", "/bin/probe", "--env", "FOO"}, "invalid env FOO (expected format <name>=<value>)"),
 	)
 })
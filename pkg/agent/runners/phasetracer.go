@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runners
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+
+	"github.com/gardener/network-problem-detector/pkg/common/nwpd"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// phaseTracer records the durations of the phases of an HTTP request.
+// TTFB is measured from writing the request to receiving the first response byte.
+type phaseTracer struct {
+	lock                     sync.Mutex
+	dnsStart, dnsDone        time.Time
+	connectStart, connectEnd time.Time
+	tlsStart, tlsDone        time.Time
+	wroteRequest, firstByte  time.Time
+}
+
+// withTrace returns the request with a context tracing the request phases.
+func (t *phaseTracer) withTrace(req *http.Request) *http.Request {
+	now := func(target *time.Time) {
+		t.lock.Lock()
+		defer t.lock.Unlock()
+		*target = time.Now()
+	}
+	trace := &httptrace.ClientTrace{
+		DNSStart:             func(_ httptrace.DNSStartInfo) { now(&t.dnsStart) },
+		DNSDone:              func(_ httptrace.DNSDoneInfo) { now(&t.dnsDone) },
+		ConnectStart:         func(_, _ string) { now(&t.connectStart) },
+		ConnectDone:          func(_, _ string, _ error) { now(&t.connectEnd) },
+		TLSHandshakeStart:    func() { now(&t.tlsStart) },
+		TLSHandshakeDone:     func(_ tls.ConnectionState, _ error) { now(&t.tlsDone) },
+		WroteRequest:         func(_ httptrace.WroteRequestInfo) { now(&t.wroteRequest) },
+		GotFirstResponseByte: func() { now(&t.firstByte) },
+	}
+	return req.WithContext(httptrace.WithClientTrace(req.Context(), trace))
+}
+
+// apply stores the durations of all completed phases in the observation.
+func (t *phaseTracer) apply(obs *nwpd.Observation) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	timings := &nwpd.PhaseTimings{
+		Dns:     phaseDuration(t.dnsStart, t.dnsDone),
+		Connect: phaseDuration(t.connectStart, t.connectEnd),
+		Tls:     phaseDuration(t.tlsStart, t.tlsDone),
+		Ttfb:    phaseDuration(t.wroteRequest, t.firstByte),
+	}
+	if timings.Dns != nil || timings.Connect != nil || timings.Tls != nil || timings.Ttfb != nil {
+		obs.PhaseTimings = timings
+	}
+}
+
+func phaseDuration(start, end time.Time) *durationpb.Duration {
+	if start.IsZero() || end.IsZero() || end.Before(start) {
+		return nil
+	}
+	return durationpb.New(end.Sub(start))
+}
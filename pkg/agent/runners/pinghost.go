@@ -6,19 +6,50 @@ package runners
 
 import (
 	"fmt"
+	"net"
 	"strings"
 	"time"
 
 	"github.com/gardener/network-problem-detector/pkg/common/config"
+	"github.com/gardener/network-problem-detector/pkg/common/nwpd"
 
 	"github.com/go-ping/ping"
 	"github.com/spf13/cobra"
-	"go.uber.org/atomic"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
+// PingOptions are the options for sending ICMP echo requests to a host.
+type PingOptions struct {
+	// Count is the number of packets to send.
+	Count int
+	// Interval is the time between sending two packets.
+	Interval time.Duration
+	// Size is the payload size of a packet.
+	Size int
+	// IPv6 if the IPv6 addresses of the nodes should be used.
+	IPv6 bool
+	// MaxLossPercent is the maximum packet loss in percent which is still considered as ok.
+	MaxLossPercent float64
+}
+
+// DefaultPingOptions returns the default options sending a single packet.
+func DefaultPingOptions() PingOptions {
+	return PingOptions{
+		Count:    1,
+		Interval: 200 * time.Millisecond,
+		Size:     24,
+	}
+}
+
+// timeout returns the default maximum duration of a ping run.
+func (o PingOptions) timeout() time.Duration {
+	return time.Duration(o.Count-1)*o.Interval + 1*time.Second
+}
+
 type pingHostArgs struct {
 	runnerArgs *runnerArgs
 	hosts      []string
+	options    PingOptions
 }
 
 func (a *pingHostArgs) createRunner(_ *cobra.Command, _ []string) error {
@@ -29,17 +60,36 @@ func (a *pingHostArgs) createRunner(_ *cobra.Command, _ []string) error {
 			if len(parts) != 2 {
 				return fmt.Errorf("invalid job: %s: invalid host %s", strings.Join(a.runnerArgs.args, " "), host)
 			}
-			nodes = append(nodes, config.Node{
-				Hostname:    parts[0],
-				InternalIPs: []string{parts[1]},
-			})
+			node := config.Node{Hostname: parts[0]}
+			if ip := net.ParseIP(parts[1]); ip != nil && ip.To4() == nil {
+				node.InternalIPsV6 = []string{parts[1]}
+			} else {
+				node.InternalIPs = []string{parts[1]}
+			}
+			nodes = append(nodes, node)
 		}
 	} else {
 		nodes = a.runnerArgs.clusterCfg.Nodes
 	}
+	if a.options.Count < 1 {
+		return fmt.Errorf("invalid count %d", a.options.Count)
+	}
+	if a.options.Interval <= 0 {
+		return fmt.Errorf("invalid interval %s", a.options.Interval)
+	}
+	if a.options.MaxLossPercent < 0 || a.options.MaxLossPercent >= 100 {
+		return fmt.Errorf("invalid max-loss-percent %.1f (valid range: [0,100))", a.options.MaxLossPercent)
+	}
+
+	var filtered []config.Node
+	for _, n := range nodes {
+		if len(nodeIPs(n, a.options.IPv6)) > 0 {
+			filtered = append(filtered, n)
+		}
+	}
 
 	config := a.runnerArgs.prepareConfig()
-	if r := NewPingHost(nodes, config); r != nil {
+	if r := NewPingHost(filtered, a.options, config); r != nil {
 		a.runnerArgs.runner = r
 	}
 	return nil
@@ -52,59 +102,113 @@ func createPingHostCmd(ra *runnerArgs) *cobra.Command {
 		Short: "pings a hostname",
 		RunE:  a.createRunner,
 	}
+	defaults := DefaultPingOptions()
 	cmd.Flags().StringSliceVar(&a.hosts, "hosts", nil, "Optional hosts in format <hostname>:<ip>. If not specified, the nodelist is used.")
+	cmd.Flags().IntVar(&a.options.Count, "count", defaults.Count, "number of packets to send per host.")
+	cmd.Flags().DurationVar(&a.options.Interval, "interval", defaults.Interval, "interval between sending two packets.")
+	cmd.Flags().IntVar(&a.options.Size, "size", defaults.Size, "payload size of the packets.")
+	cmd.Flags().BoolVar(&a.options.IPv6, "ipv6", false, "uses the IPv6 addresses of the nodes.")
+	cmd.Flags().Float64Var(&a.options.MaxLossPercent, "max-loss-percent", 0, "maximum packet loss in percent which is still considered as ok.")
 	return cmd
 }
 
-func NewPingHost(nodes []config.Node, rconfig RunnerConfig) Runner {
+func NewPingHost(nodes []config.Node, options PingOptions, rconfig RunnerConfig) Runner {
 	if len(nodes) == 0 {
 		return nil
 	}
 	return &pingHost{
-		robinRound[config.Node]{
+		robinRound: robinRound[config.Node]{
 			itemsName: "nodes",
 			items:     config.CloneAndShuffle(nodes),
-			runFunc:   pingFunc,
-			config:    rconfig,
+			runFunc: func(node config.Node, obs *nwpd.Observation) (string, error) {
+				return pingFunc(node, options, rconfig.timeoutOr(options.timeout()), obs)
+			},
+			config: rconfig,
 		},
+		options: options,
 	}
 }
 
 type pingHost struct {
 	robinRound[config.Node]
+	options PingOptions
+}
+
+// TestOptions returns the options for testing.
+func (r *pingHost) TestOptions() any {
+	return r.options
 }
 
 var _ Runner = &pingHost{}
 
-func pingFunc(node config.Node) (string, error) {
-	for _, ip := range node.InternalIPs {
+func nodeIPs(node config.Node, ipv6 bool) []string {
+	if ipv6 {
+		return node.InternalIPsV6
+	}
+	return node.InternalIPs
+}
+
+func pingFunc(node config.Node, options PingOptions, timeout time.Duration, obs *nwpd.Observation) (string, error) {
+	var stats *ping.Statistics
+	for _, ip := range nodeIPs(node, options.IPv6) {
 		pinger, err := ping.NewPinger(ip)
 		if err != nil {
 			return "", err
 		}
 		pinger.SetPrivileged(true)
-		pinger.Count = 1
-		pinger.Timeout = 1 * time.Second
-
-		result := atomic.String{}
-		pinger.OnRecv = func(pkt *ping.Packet) {
-			result.Store(fmt.Sprintf("%d bytes from %s: icmp_seq=%d time=%v\n",
-				pkt.Nbytes, pkt.IPAddr, pkt.Seq, pkt.Rtt))
-		}
-
-		pinger.OnDuplicateRecv = func(pkt *ping.Packet) {
-			result.Store(fmt.Sprintf("%d bytes from %s: icmp_seq=%d time=%v ttl=%v (DUP!)\n",
-				pkt.Nbytes, pkt.IPAddr, pkt.Seq, pkt.Rtt, pkt.Ttl))
-		}
+		pinger.Count = options.Count
+		pinger.Interval = options.Interval
+		pinger.Size = options.Size
+		pinger.Timeout = timeout
 
 		err = pinger.Run()
 		if err != nil {
 			return "", err
 		}
-		stats := pinger.Statistics()
-		if stats.PacketsRecv == 1 {
-			return result.Load(), nil
+		stats = pinger.Statistics()
+		if stats.PacketsRecv > 0 {
+			break
 		}
 	}
-	return "", fmt.Errorf("ping lost after %d ms", 1*time.Second.Milliseconds())
+	if stats == nil {
+		return "", fmt.Errorf("no IP address")
+	}
+	obs.PingStatistics = toPingStatistics(stats)
+	return pingResult(stats, options.MaxLossPercent, timeout)
+}
+
+// pingResult evaluates the statistics of a ping run. Lost packets are classified as timeout, both if all packets are lost
+// and if the loss exceeds the maximum loss.
+func pingResult(stats *ping.Statistics, maxLossPercent float64, timeout time.Duration) (string, error) {
+	if stats.PacketsRecv == 0 {
+		return "", withFailureClass(nwpd.FailureClass_FAILURE_CLASS_TIMEOUT, fmt.Errorf("%d packets lost after %d ms", stats.PacketsSent, timeout.Milliseconds()))
+	}
+
+	result := fmt.Sprintf("%d/%d packets received from %s, %.1f%% loss, rtt min/avg/max/mdev = %.3f/%.3f/%.3f/%.3f ms",
+		stats.PacketsRecv, stats.PacketsSent, stats.IPAddr, stats.PacketLoss,
+		millis(stats.MinRtt), millis(stats.AvgRtt), millis(stats.MaxRtt), millis(stats.StdDevRtt))
+	if stats.PacketLoss > maxLossPercent {
+		return "", withFailureClass(nwpd.FailureClass_FAILURE_CLASS_TIMEOUT, fmt.Errorf("%s", result))
+	}
+	return result, nil
+}
+
+// toPingStatistics converts the statistics of a ping run. The round trip times are only set if packets have been received.
+func toPingStatistics(stats *ping.Statistics) *nwpd.PingStatistics {
+	s := &nwpd.PingStatistics{
+		PacketsSent: int32(stats.PacketsSent), // #nosec G115 -- number of packets is small
+		PacketsRecv: int32(stats.PacketsRecv), // #nosec G115 -- number of packets is small
+		LossPercent: stats.PacketLoss,
+	}
+	if stats.PacketsRecv > 0 {
+		s.MinRtt = durationpb.New(stats.MinRtt)
+		s.AvgRtt = durationpb.New(stats.AvgRtt)
+		s.MaxRtt = durationpb.New(stats.MaxRtt)
+		s.MdevRtt = durationpb.New(stats.StdDevRtt)
+	}
+	return s
+}
+
+func millis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
 }
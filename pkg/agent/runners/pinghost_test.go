@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runners
+
+import (
+	"net"
+	"time"
+
+	"github.com/gardener/network-problem-detector/pkg/common/nwpd"
+
+	"github.com/go-ping/ping"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("pingHost", func() {
+	It("should convert the statistics of a ping run", func() {
+		s := toPingStatistics(&ping.Statistics{
+			PacketsSent: 4,
+			PacketsRecv: 3,
+			PacketLoss:  25,
+			MinRtt:      1 * time.Millisecond,
+			AvgRtt:      2 * time.Millisecond,
+			MaxRtt:      4 * time.Millisecond,
+			StdDevRtt:   500 * time.Microsecond,
+		})
+		Expect(s.PacketsSent).To(Equal(int32(4)))
+		Expect(s.PacketsRecv).To(Equal(int32(3)))
+		Expect(s.LossPercent).To(Equal(25.0))
+		Expect(s.MinRtt.AsDuration()).To(Equal(1 * time.Millisecond))
+		Expect(s.AvgRtt.AsDuration()).To(Equal(2 * time.Millisecond))
+		Expect(s.MaxRtt.AsDuration()).To(Equal(4 * time.Millisecond))
+		Expect(s.MdevRtt.AsDuration()).To(Equal(500 * time.Microsecond))
+	})
+
+	It("should not report round trip times if all packets are lost", func() {
+		s := toPingStatistics(&ping.Statistics{PacketsSent: 3, PacketLoss: 100})
+		Expect(s.LossPercent).To(Equal(100.0))
+		Expect(s.MinRtt).To(BeNil())
+		Expect(s.AvgRtt).To(BeNil())
+	})
+	DescribeTable("should evaluate the packet loss",
+		func(recv int, loss float64, expectedErr string, expectedClass nwpd.FailureClass) {
+			result, err := pingResult(&ping.Statistics{PacketsSent: 4, PacketsRecv: recv, PacketLoss: loss, IPAddr: &net.IPAddr{IP: net.IPv4(10, 0, 0, 1)}}, 30, time.Second)
+			if expectedErr == "" {
+				Expect(err).To(BeNil())
+				Expect(result).To(HavePrefix("4/4 packets received from 10.0.0.1"))
+			} else {
+				Expect(err).To(MatchError(HavePrefix(expectedErr)))
+			}
+			Expect(classifyFailure(err)).To(Equal(expectedClass))
+		},
+		Entry("no loss", 4, 0.0, "", nwpd.FailureClass_FAILURE_CLASS_UNSPECIFIED),
+		Entry("loss above maximum", 2, 50.0, "2/4 packets received from 10.0.0.1, 50.0% loss", nwpd.FailureClass_FAILURE_CLASS_TIMEOUT),
+		Entry("all packets lost", 0, 100.0, "4 packets lost after 1000 ms", nwpd.FailureClass_FAILURE_CLASS_TIMEOUT),
+	)
+})
@@ -6,6 +6,7 @@ package runners
 
 import (
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/gardener/network-problem-detector/pkg/common/config"
@@ -15,7 +16,10 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-type runFunc[T config.WithDestHost] func(item T) (result string, err error)
+// runFunc performs the check for a single item.
+// The observation is prefilled and may be enriched by the function. If the function sets the duration, it is not overwritten
+// by the measured wall-clock time.
+type runFunc[T config.WithDestHost] func(item T, obs *nwpd.Observation) (result string, err error)
 
 type robinRound[T config.WithDestHost] struct {
 	itemsName string
@@ -30,6 +34,9 @@ func (r *robinRound[T]) Config() RunnerConfig {
 }
 
 func (r *robinRound[T]) Description() string {
+	if n := r.itemsPerRun(); n > 1 {
+		return fmt.Sprintf("%d %s (fan-out %d, max parallel %d)", len(r.items), r.itemsName, n, r.config.MaxParallel)
+	}
 	return fmt.Sprintf("%d %s", len(r.items), r.itemsName)
 }
 
@@ -45,26 +52,170 @@ func (r *robinRound[T]) DestHosts() []string {
 	return hosts
 }
 
+// Run checks the next item in round robin order. In fan-out mode, the next items are checked concurrently
+// with bounded parallelism and staggered start times.
+// The caller holds a slot of the limiter for the run, additional concurrent checks are only started if further slots are free.
 func (r *robinRound[T]) Run(nodeName string, ch chan<- *nwpd.Observation) {
+	n := r.itemsPerRun()
+	if n == 1 {
+		r.runItem(nodeName, r.nextItem(), ch)
+		return
+	}
+
+	// spread the starts over at most half of the period
+	stagger := r.config.Stagger
+	if maxStagger := r.config.Period / time.Duration(2*n); stagger > maxStagger {
+		stagger = maxStagger
+	}
+	items := make(chan T)
+	var wg sync.WaitGroup
+	work := func(release func()) {
+		defer wg.Done()
+		defer release()
+		for item := range items {
+			r.runItem(nodeName, item, ch)
+		}
+	}
+	// the first worker uses the slot of the run
+	wg.Add(1)
+	go work(func() {})
+	workers := 1
+	for i := 0; i < n; i++ {
+		if i > 0 && stagger > 0 {
+			time.Sleep(stagger)
+		}
+		item := r.nextItem()
+		select {
+		case items <- item:
+			continue
+		default:
+		}
+		// all workers are busy
+		if workers < max(r.config.MaxParallel, 1) {
+			if release := r.config.Limiter.TryAcquire(); release != nil {
+				workers++
+				wg.Add(1)
+				go work(release)
+			}
+		}
+		items <- item
+	}
+	close(items)
+	wg.Wait()
+}
+
+// RunAll checks all items accepted by the filter once, concurrently with bounded parallelism.
+// Each check waits for a slot of the limiter. It does not change the round robin order of the regular runs.
+func (r *robinRound[T]) RunAll(nodeName string, filter func(destHost string) bool, ch chan<- *nwpd.Observation) {
+	maxParallel := r.config.MaxParallel
+	if maxParallel < 1 {
+		maxParallel = defaultMaxParallel
+	}
+	parallel := make(chan struct{}, maxParallel)
+	var wg sync.WaitGroup
+	for _, item := range r.items {
+		if filter != nil && !filter(normalise(item.DestHost())) {
+			continue
+		}
+		parallel <- struct{}{}
+		release := r.config.Limiter.Acquire()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() {
+				release()
+				<-parallel
+			}()
+			r.runItem(nodeName, item, ch)
+		}()
+	}
+	wg.Wait()
+}
+
+// itemsPerRun returns the number of items checked per run.
+func (r *robinRound[T]) itemsPerRun() int {
+	return itemsPerRun(r.config.FanOut, len(r.items))
+}
+
+// itemsPerRun returns the number of items checked per run for the given fan-out.
+func itemsPerRun(fanOut, items int) int {
+	if fanOut < 0 || fanOut > items {
+		return items
+	}
+	return max(fanOut, 1)
+}
+
+// itemPeriod returns the period between two checks of the same item.
+func (r *robinRound[T]) itemPeriod() time.Duration {
+	n := r.itemsPerRun()
+	runs := (len(r.items) + n - 1) / n
+	return r.config.Period * time.Duration(runs)
+}
+
+func (r *robinRound[T]) nextItem() T {
 	item := r.items[r.next]
 	r.next = (r.next + 1) % len(r.items)
+	return item
+}
 
-	obs := &nwpd.Observation{
-		SrcHost:   nodeName,
-		DestHost:  normalise(item.DestHost()),
-		Timestamp: timestamppb.Now(),
-		JobID:     r.config.JobID,
-	}
+// runItem checks a single item and retries failed checks if configured.
+func (r *robinRound[T]) runItem(nodeName string, item T, ch chan<- *nwpd.Observation) {
+	timestamp := timestamppb.Now()
+	var obs *nwpd.Observation
+	for attempt := 1; ; attempt++ {
+		// each attempt starts with a fresh observation, so that nothing is left over from a failed attempt
+		obs = &nwpd.Observation{
+			SrcHost:   nodeName,
+			DestHost:  normalise(item.DestHost()),
+			Timestamp: timestamp,
+			JobID:     r.config.JobID,
+			Attempts:  int32(attempt), // #nosec G115 -- number of retries is small
+		}
 
-	start := time.Now()
-	result, err := r.runFunc(item)
-	obs.Duration = durationpb.New(time.Since(start))
-	obs.Period = durationpb.New(r.config.Period * time.Duration(len(r.items)))
-	obs.Ok = err == nil
-	if err != nil {
-		obs.Result = fmt.Sprintf("error: %s", err)
-	} else {
-		obs.Result = result
+		start := time.Now()
+		result, err := r.runFunc(item, obs)
+		if obs.Duration == nil {
+			obs.Duration = durationpb.New(time.Since(start))
+		}
+		if r.config.ExpectsBlocked() {
+			obs.ExpectBlocked = true
+			result, err = invertResult(result, err)
+		}
+		obs.Ok = err == nil
+		if err != nil {
+			obs.Result = fmt.Sprintf("error: %s", err)
+		} else {
+			obs.Result = result
+		}
+		obs.FailureClass = classifyFailure(err)
+		if obs.Ok || attempt > r.config.Retries {
+			break
+		}
+		time.Sleep(r.config.RetryDelay)
+	}
+	if obs.Ok && obs.Attempts > 1 {
+		obs.Result = fmt.Sprintf("%s (succeeded on attempt %d)", obs.Result, obs.Attempts)
 	}
+	obs.Period = durationpb.New(r.itemPeriod())
 	ch <- obs
 }
+
+// invertResult inverts the result of a negative check: it succeeds if the destination is blocked.
+// Only timeouts, refused and reset connections are considered as blocked. A response of the destination (e.g. an unexpected
+// HTTP status or a TLS error) shows that it is reachable. Other errors like DNS errors are returned unchanged, as the
+// destination has not been checked at all.
+func invertResult(result string, err error) (string, error) {
+	if err == nil {
+		return "", withFailureClass(nwpd.FailureClass_FAILURE_CLASS_NOT_BLOCKED, fmt.Errorf("not blocked: %s", result))
+	}
+	switch classifyFailure(err) {
+	case nwpd.FailureClass_FAILURE_CLASS_TIMEOUT,
+		nwpd.FailureClass_FAILURE_CLASS_CONNECTION_REFUSED,
+		nwpd.FailureClass_FAILURE_CLASS_CONNECTION_RESET:
+		return fmt.Sprintf("blocked: %s", err), nil
+	case nwpd.FailureClass_FAILURE_CLASS_HTTP_STATUS, nwpd.FailureClass_FAILURE_CLASS_TLS:
+		return "", withFailureClass(nwpd.FailureClass_FAILURE_CLASS_NOT_BLOCKED, fmt.Errorf("not blocked: %s", err))
+	default:
+		return "", err
+	}
+}
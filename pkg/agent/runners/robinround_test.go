@@ -0,0 +1,249 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package runners
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/gardener/network-problem-detector/pkg/common/config"
+	"github.com/gardener/network-problem-detector/pkg/common/nwpd"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("robinRound", func() {
+	newEndpoints := func(count int) []config.Endpoint {
+		var endpoints []config.Endpoint
+		for i := 0; i < count; i++ {
+			endpoints = append(endpoints, config.Endpoint{Hostname: fmt.Sprintf("node%d", i), IP: fmt.Sprintf("10.0.0.%d", i), Port: 80})
+		}
+		return endpoints
+	}
+
+	DescribeTable("should check items per run",
+		func(fanOut, maxParallel int, expectedObservations int, expectedPeriod time.Duration) {
+			var active, maxActive atomic.Int32
+			r := &robinRound[config.Endpoint]{
+				itemsName: "endpoints",
+				items:     newEndpoints(10),
+				runFunc: func(_ config.Endpoint, _ *nwpd.Observation) (string, error) {
+					n := active.Add(1)
+					defer active.Add(-1)
+					for {
+						m := maxActive.Load()
+						if n <= m || maxActive.CompareAndSwap(m, n) {
+							break
+						}
+					}
+					time.Sleep(20 * time.Millisecond)
+					return "ok", nil
+				},
+				config: RunnerConfig{
+					Job:         config.Job{JobID: "test"},
+					Period:      1 * time.Second,
+					FanOut:      fanOut,
+					MaxParallel: maxParallel,
+					Stagger:     time.Millisecond,
+				},
+			}
+
+			ch := make(chan *nwpd.Observation, 20)
+			r.Run("node", ch)
+			close(ch)
+			hosts := map[string]struct{}{}
+			for obs := range ch {
+				Expect(obs.Ok).To(BeTrue())
+				Expect(obs.JobID).To(Equal("test"))
+				Expect(obs.Period.AsDuration()).To(Equal(expectedPeriod))
+				hosts[obs.DestHost] = struct{}{}
+			}
+			Expect(hosts).To(HaveLen(expectedObservations))
+			Expect(maxActive.Load()).To(BeNumerically("<=", max(maxParallel, 1)))
+			Expect(r.next).To(Equal(expectedObservations % 10))
+		},
+		Entry("round robin", 0, 0, 1, 10*time.Second),
+		Entry("fan-out of 4 items", 4, 2, 4, 3*time.Second),
+		Entry("fan-out of all items", -1, 3, 10, 1*time.Second),
+		Entry("fan-out larger than items", 20, 10, 10, 1*time.Second),
+	)
+
+	It("should limit the stagger to half of the period", func() {
+		r := &robinRound[config.Endpoint]{
+			items: newEndpoints(10),
+			runFunc: func(_ config.Endpoint, _ *nwpd.Observation) (string, error) {
+				return "ok", nil
+			},
+			config: RunnerConfig{Period: 200 * time.Millisecond, FanOut: -1, MaxParallel: 10, Stagger: time.Second},
+		}
+		ch := make(chan *nwpd.Observation, 10)
+		start := time.Now()
+		r.Run("node", ch)
+		Expect(time.Since(start)).To(BeNumerically("<", 200*time.Millisecond))
+		Expect(ch).To(HaveLen(10))
+	})
+	DescribeTable("should share the slots of the limiter",
+		func(probe bool, expectedMaxActive int32) {
+			var active, maxActive atomic.Int32
+			limiter := NewLimiter(3, nil)
+			r := &robinRound[config.Endpoint]{
+				items: newEndpoints(10),
+				runFunc: func(_ config.Endpoint, _ *nwpd.Observation) (string, error) {
+					n := active.Add(1)
+					defer active.Add(-1)
+					for {
+						m := maxActive.Load()
+						if n <= m || maxActive.CompareAndSwap(m, n) {
+							break
+						}
+					}
+					time.Sleep(20 * time.Millisecond)
+					return "ok", nil
+				},
+				config: RunnerConfig{Period: time.Second, FanOut: -1, MaxParallel: 8, Stagger: time.Millisecond, Limiter: limiter},
+			}
+
+			// another run holds a slot
+			release := limiter.Acquire()
+			ch := make(chan *nwpd.Observation, 10)
+			if probe {
+				r.RunAll("node", nil, ch)
+			} else {
+				// the run holds a slot itself
+				runRelease := limiter.Acquire()
+				r.Run("node", ch)
+				runRelease()
+			}
+			Expect(ch).To(HaveLen(10))
+			Expect(maxActive.Load()).To(Equal(expectedMaxActive))
+			release()
+			for i := 0; i < 3; i++ {
+				Expect(limiter.TryAcquire()).NotTo(BeNil())
+			}
+		},
+		Entry("fan-out run", false, int32(2)),
+		Entry("probe", true, int32(2)),
+	)
+
+	DescribeTable("should retry failed checks",
+		func(retries, failures int, expectedOk bool, expectedAttempts int32, expectedResult string) {
+			calls := 0
+			r := &robinRound[config.Endpoint]{
+				items: newEndpoints(1),
+				runFunc: func(_ config.Endpoint, obs *nwpd.Observation) (string, error) {
+					calls++
+					if calls <= failures {
+						obs.PathMTU = 1
+						return "", fmt.Errorf("failure %d", calls)
+					}
+					return "ok", nil
+				},
+				config: RunnerConfig{Period: time.Second, Retries: retries, RetryDelay: time.Millisecond},
+			}
+			ch := make(chan *nwpd.Observation, 1)
+			r.Run("node", ch)
+			obs := <-ch
+			Expect(obs.Ok).To(Equal(expectedOk))
+			Expect(obs.Attempts).To(Equal(expectedAttempts))
+			Expect(obs.Result).To(Equal(expectedResult))
+			Expect(obs.Period.AsDuration()).To(Equal(time.Second))
+			if expectedOk {
+				// nothing is left over from failed attempts
+				Expect(obs.PathMTU).To(BeZero())
+			}
+		},
+		Entry("no retries", 0, 0, true, int32(1), "ok"),
+		Entry("failure without retries", 0, 1, false, int32(1), "error: failure 1"),
+		Entry("succeeded on retry", 2, 1, true, int32(2), "ok (succeeded on attempt 2)"),
+		Entry("failed after all retries", 2, 5, false, int32(3), "error: failure 3"),
+	)
+	DescribeTable("should invert the result of checks expecting blocked destinations",
+		func(checkErr error, expectedOk bool, expectedResult string, expectedClass nwpd.FailureClass) {
+			r := &robinRound[config.Endpoint]{
+				items: newEndpoints(1),
+				runFunc: func(_ config.Endpoint, _ *nwpd.Observation) (string, error) {
+					if checkErr != nil {
+						return "", checkErr
+					}
+					return "ok", nil
+				},
+				config: RunnerConfig{Job: config.Job{Expect: config.ExpectBlocked}, Period: time.Second},
+			}
+			ch := make(chan *nwpd.Observation, 1)
+			r.Run("node", ch)
+			obs := <-ch
+			Expect(obs.ExpectBlocked).To(BeTrue())
+			Expect(obs.Ok).To(Equal(expectedOk))
+			Expect(obs.Result).To(Equal(expectedResult))
+			Expect(obs.FailureClass).To(Equal(expectedClass))
+		},
+		Entry("reachable", nil, false, "error: not blocked: ok", nwpd.FailureClass_FAILURE_CLASS_NOT_BLOCKED),
+		Entry("blocked by timeout", fmt.Errorf("dial tcp: %w", os.ErrDeadlineExceeded), true, "blocked: dial tcp: i/o timeout",
+			nwpd.FailureClass_FAILURE_CLASS_UNSPECIFIED),
+		Entry("blocked by refused connection", fmt.Errorf("dial tcp: %w", syscall.ECONNREFUSED), true, "blocked: dial tcp: connection refused",
+			nwpd.FailureClass_FAILURE_CLASS_UNSPECIFIED),
+		Entry("blocked by reset connection", fmt.Errorf("read tcp: %w", syscall.ECONNRESET), true, "blocked: read tcp: connection reset by peer",
+			nwpd.FailureClass_FAILURE_CLASS_UNSPECIFIED),
+		Entry("unexpected HTTP status", withFailureClass(nwpd.FailureClass_FAILURE_CLASS_HTTP_STATUS, fmt.Errorf("unexpected status 403 Forbidden (expected 200-299)")),
+			false, "error: not blocked: unexpected status 403 Forbidden (expected 200-299)", nwpd.FailureClass_FAILURE_CLASS_NOT_BLOCKED),
+		Entry("TLS error", fmt.Errorf("get: %w", tls.RecordHeaderError{Msg: "first record does not look like a TLS handshake"}),
+			false, "error: not blocked: get: tls: first record does not look like a TLS handshake", nwpd.FailureClass_FAILURE_CLASS_NOT_BLOCKED),
+		Entry("unclassified error", fmt.Errorf("unexpected"), false, "error: unexpected", nwpd.FailureClass_FAILURE_CLASS_OTHER),
+		Entry("DNS lookup failed", &net.DNSError{Err: "no such host", Name: "canary", IsNotFound: true}, false, "error: lookup canary: no such host",
+			nwpd.FailureClass_FAILURE_CLASS_DNS_NOT_FOUND),
+	)
+	DescribeTable("should probe items once",
+		func(restrictToDestHosts []string, slowHost string, expectedHosts []string, expectedPending int) {
+			r := &robinRound[config.Endpoint]{
+				itemsName: "endpoints",
+				items:     newEndpoints(4),
+				runFunc: func(endpoint config.Endpoint, _ *nwpd.Observation) (string, error) {
+					if endpoint.Hostname == slowHost {
+						time.Sleep(time.Second)
+					}
+					return "ok", nil
+				},
+				config: RunnerConfig{Job: config.Job{JobID: "adhoc-checkTCPPort"}, Period: time.Minute},
+			}
+			job := NewInternalJob(r, 0)
+			ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+			defer cancel()
+			observations, pending, err := job.Probe(ctx, "node", restrictToDestHosts)
+			Expect(err).To(BeNil())
+			Expect(pending).To(Equal(expectedPending))
+			var hosts []string
+			for _, obs := range observations {
+				Expect(obs.Ok).To(BeTrue())
+				Expect(obs.AdHoc).To(BeTrue())
+				Expect(obs.JobID).To(Equal("adhoc-checkTCPPort"))
+				hosts = append(hosts, obs.DestHost)
+			}
+			Expect(hosts).To(ConsistOf(expectedHosts))
+			// round robin order of regular runs is unchanged
+			Expect(r.next).To(Equal(0))
+		},
+		Entry("all items", nil, "", []string{"node0", "node1", "node2", "node3"}, 0),
+		Entry("restricted items", []string{"node1", "node3."}, "", []string{"node1", "node3"}, 0),
+		Entry("timeout", []string{"node1", "node2"}, "node2", []string{"node1"}, 1),
+	)
+
+	It("should fail to probe unknown destination hosts", func() {
+		r := &robinRound[config.Endpoint]{
+			items: newEndpoints(2),
+			runFunc: func(_ config.Endpoint, _ *nwpd.Observation) (string, error) {
+				return "ok", nil
+			},
+		}
+		_, _, err := NewInternalJob(r, 0).Probe(context.Background(), "node", []string{"other"})
+		Expect(err).To(MatchError("no destination hosts matching [other]"))
+	})
+})
@@ -5,6 +5,10 @@
 package runners
 
 import (
+	"crypto/x509"
+	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 )
 
@@ -21,3 +25,16 @@ func fullQualified(dnsname string) string {
 	}
 	return dnsname
 }
+
+// loadCABundle reads the PEM encoded CA certificates from the given file.
+func loadCABundle(filename string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(filepath.Clean(filename))
+	if err != nil {
+		return nil, fmt.Errorf("reading CA bundle failed: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in CA bundle %s", filename)
+	}
+	return pool, nil
+}
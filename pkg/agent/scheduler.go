@@ -0,0 +1,249 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"container/heap"
+	"hash/fnv"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/gardener/network-problem-detector/pkg/agent/runners"
+	"github.com/gardener/network-problem-detector/pkg/common/config"
+	"github.com/gardener/network-problem-detector/pkg/common/nwpd"
+)
+
+const (
+	// defaultMaxConcurrentRuns is the default maximum number of concurrent checks of all job runs and probes.
+	defaultMaxConcurrentRuns = 16
+	// defaultJitterPercent is the default maximum random deviation of the time between two runs in percent of the period.
+	defaultJitterPercent = 5
+	// maxSchedulerIdle is the maximum time the scheduler waits if no job is scheduled.
+	maxSchedulerIdle = 1 * time.Hour
+	// minSchedulerPeriod is the minimum time between two runs of a job. Invalid periods are rejected when parsing the job,
+	// this only guards the scheduler against busy looping.
+	minSchedulerPeriod = 100 * time.Millisecond
+)
+
+// scheduler runs the jobs at their due times using a deadline heap.
+// The first run of a job is spread over its period by a phase derived from the node name and the job ID,
+// so that the runs of the jobs neither cluster on a node nor on all nodes. Each following run is planned relative
+// to the planned time of the previous run with a random jitter to avoid drift.
+// A run is skipped if the previous run of the job is still active. The number of concurrent checks is limited globally:
+// a run is only started if a slot of the limiter is free, otherwise the due runs wait for the release of a slot.
+type scheduler struct {
+	lock          sync.Mutex
+	nodeName      string
+	obsChan       chan<- *nwpd.Observation
+	entries       scheduleHeap
+	entriesByID   map[string]*scheduleEntry
+	jitterPercent int
+	limiter       *runners.Limiter
+	wakeup        chan struct{}
+	random        *rand.Rand
+}
+
+type scheduleEntry struct {
+	job   *runners.InternalJob
+	added time.Time
+	next  time.Time
+	index int
+}
+
+// scheduleHeap is a min-heap of the schedule entries ordered by their next run.
+type scheduleHeap []*scheduleEntry
+
+var _ heap.Interface = &scheduleHeap{}
+
+func (h scheduleHeap) Len() int { return len(h) }
+
+func (h scheduleHeap) Less(i, j int) bool { return h[i].next.Before(h[j].next) }
+
+func (h scheduleHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *scheduleHeap) Push(x any) {
+	entry := x.(*scheduleEntry)
+	entry.index = len(*h)
+	*h = append(*h, entry)
+}
+
+func (h *scheduleHeap) Pop() any {
+	old := *h
+	n := len(old)
+	entry := old[n-1]
+	old[n-1] = nil
+	entry.index = -1
+	*h = old[:n-1]
+	return entry
+}
+
+func newScheduler(nodeName string, obsChan chan<- *nwpd.Observation) *scheduler {
+	sc := &scheduler{
+		nodeName:      nodeName,
+		obsChan:       obsChan,
+		entriesByID:   map[string]*scheduleEntry{},
+		jitterPercent: defaultJitterPercent,
+		wakeup:        make(chan struct{}, 1),
+		random:        rand.New(rand.NewSource(time.Now().UnixNano())), // #nosec G404 -- no cryptographic use
+	}
+	sc.limiter = runners.NewLimiter(defaultMaxConcurrentRuns, sc.notify)
+	return sc
+}
+
+// configure applies the scheduler configuration. Runs in progress are not affected.
+func (sc *scheduler) configure(cfg *config.SchedulerConfig) {
+	maxConcurrentRuns := defaultMaxConcurrentRuns
+	jitterPercent := defaultJitterPercent
+	if cfg != nil {
+		if cfg.MaxConcurrentRuns > 0 {
+			maxConcurrentRuns = cfg.MaxConcurrentRuns
+		}
+		if cfg.JitterPercent != nil {
+			jitterPercent = min(max(*cfg.JitterPercent, 0), 50)
+		}
+	}
+
+	sc.limiter.SetMax(maxConcurrentRuns)
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+	sc.jitterPercent = jitterPercent
+	sc.notify()
+}
+
+// schedule adds or replaces a job. A replaced job keeps its run state and its schedule, adjusted to the new period.
+func (sc *scheduler) schedule(job *runners.InternalJob, now time.Time) {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	if entry := sc.entriesByID[job.JobID()]; entry != nil {
+		oldJob := entry.job
+		if oldJob != job {
+			job.KeepRunState(oldJob)
+		}
+		entry.job = job
+		if oldJob.Period() != job.Period() {
+			if lastRun := job.GetLastRun(); lastRun != nil {
+				entry.next = lastRun.Add(job.Period())
+			} else {
+				// not run yet: the first run is planned with the phase of the new period
+				entry.next = sc.firstRun(job, entry.added)
+			}
+			heap.Fix(&sc.entries, entry.index)
+		}
+	} else {
+		entry = &scheduleEntry{
+			job:   job,
+			added: now,
+			next:  sc.firstRun(job, now),
+		}
+		sc.entriesByID[job.JobID()] = entry
+		heap.Push(&sc.entries, entry)
+	}
+	sc.notify()
+}
+
+// unschedule removes a job. A run in progress is not affected.
+func (sc *scheduler) unschedule(jobID string) {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	if entry := sc.entriesByID[jobID]; entry != nil {
+		heap.Remove(&sc.entries, entry.index)
+		delete(sc.entriesByID, jobID)
+		sc.notify()
+	}
+}
+
+func (sc *scheduler) notify() {
+	select {
+	case sc.wakeup <- struct{}{}:
+	default:
+	}
+}
+
+// firstRun returns the time of the first run of a job added at the given time.
+func (sc *scheduler) firstRun(job *runners.InternalJob, added time.Time) time.Time {
+	return added.Add(time.Duration(sc.phase(job.JobID()) * float64(job.Period())))
+}
+
+// phase returns a fraction of the period in [0,1) for the first run of a job, which is stable for the node and the job.
+func (sc *scheduler) phase(jobID string) float64 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(sc.nodeName + "/" + jobID))
+	return float64(h.Sum32()) / (1 << 32)
+}
+
+// jitter returns a random deviation of the period.
+func (sc *scheduler) jitter(period time.Duration) time.Duration {
+	if sc.jitterPercent == 0 {
+		return 0
+	}
+	maxJitter := float64(period) * float64(sc.jitterPercent) / 100
+	return time.Duration((2*sc.random.Float64() - 1) * maxJitter)
+}
+
+// run dispatches the due jobs until the stop channel is closed.
+func (sc *scheduler) run(stop <-chan struct{}) {
+	timer := time.NewTimer(maxSchedulerIdle)
+	defer timer.Stop()
+	for {
+		timer.Reset(sc.dispatchDue(time.Now()))
+		select {
+		case <-stop:
+			return
+		case <-sc.wakeup:
+		case <-timer.C:
+		}
+	}
+}
+
+// dispatchDue starts the runs of all due jobs and returns the time until the next job is due.
+func (sc *scheduler) dispatchDue(now time.Time) time.Duration {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	for len(sc.entries) > 0 {
+		entry := sc.entries[0]
+		if entry.next.After(now) {
+			return entry.next.Sub(now)
+		}
+		if !sc.dispatch(entry.job, entry.next, now) {
+			// all slots are taken, the release of a slot wakes up the scheduler
+			return maxSchedulerIdle
+		}
+		period := max(entry.job.Period(), minSchedulerPeriod)
+		entry.next = entry.next.Add(period + sc.jitter(period))
+		if entry.next.Before(now) {
+			// far behind schedule, e.g. after a suspended process: restart from now instead of catching up
+			entry.next = now.Add(period + sc.jitter(period))
+		}
+		heap.Fix(&sc.entries, 0)
+	}
+	return maxSchedulerIdle
+}
+
+// dispatch starts a run of the job or skips it if the job is still active. It returns false if no slot is free.
+func (sc *scheduler) dispatch(job *runners.InternalJob, planned, now time.Time) bool {
+	release := sc.limiter.TryAcquire()
+	if release == nil {
+		return false
+	}
+	if !job.Start(now) {
+		release()
+		IncSkippedJobRuns(job.JobID())
+		return true
+	}
+	go func() {
+		defer release()
+		ReportSchedulingLag(time.Since(planned).Seconds())
+		job.Run(sc.nodeName, sc.obsChan)
+	}()
+	return true
+}
@@ -0,0 +1,225 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/gardener/network-problem-detector/pkg/agent/runners"
+	"github.com/gardener/network-problem-detector/pkg/common/config"
+	"github.com/gardener/network-problem-detector/pkg/common/nwpd"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type fakeRunner struct {
+	config  runners.RunnerConfig
+	release chan struct{}
+	running *atomic.Int32
+	runs    atomic.Int32
+}
+
+var _ runners.Runner = &fakeRunner{}
+
+func (r *fakeRunner) Run(_ string, _ chan<- *nwpd.Observation) {
+	r.runs.Add(1)
+	if r.running != nil {
+		r.running.Add(1)
+		defer r.running.Add(-1)
+	}
+	if r.release != nil {
+		<-r.release
+	}
+}
+
+func (r *fakeRunner) RunAll(_ string, _ func(destHost string) bool, _ chan<- *nwpd.Observation) {}
+
+func (r *fakeRunner) Config() runners.RunnerConfig { return r.config }
+
+func (r *fakeRunner) Description() string { return "" }
+
+func (r *fakeRunner) TestData() any { return nil }
+
+func (r *fakeRunner) DestHosts() []string { return nil }
+
+var _ = Describe("scheduler", func() {
+	var (
+		sc  *scheduler
+		now time.Time
+	)
+
+	newJob := func(jobID string, period time.Duration, release chan struct{}, running *atomic.Int32) (*runners.InternalJob, *fakeRunner) {
+		r := &fakeRunner{
+			config:  runners.RunnerConfig{Job: config.Job{JobID: jobID}, Period: period},
+			release: release,
+			running: running,
+		}
+		return runners.NewInternalJob(r, 0), r
+	}
+
+	BeforeEach(func() {
+		sc = newScheduler("node-1", nil)
+		now = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	})
+
+	It("should spread the first runs over the period by a stable phase", func() {
+		for _, id := range []string{"tcp-n2n", "ping-n2n", "https-n2api"} {
+			job, _ := newJob(id, 10*time.Second, nil, nil)
+			sc.schedule(job, now)
+		}
+		other := newScheduler("node-1", nil)
+		job, _ := newJob("tcp-n2n", 10*time.Second, nil, nil)
+		other.schedule(job, now)
+
+		nexts := map[time.Time]struct{}{}
+		for _, entry := range sc.entries {
+			Expect(entry.next).To(BeTemporally(">=", now))
+			Expect(entry.next).To(BeTemporally("<", now.Add(10*time.Second)))
+			nexts[entry.next] = struct{}{}
+		}
+		Expect(nexts).To(HaveLen(3))
+		Expect(other.entriesByID["tcp-n2n"].next).To(Equal(sc.entriesByID["tcp-n2n"].next))
+	})
+
+	It("should run due jobs and plan the next run relative to the planned time with jitter", func() {
+		jitter := 10
+		sc.configure(&config.SchedulerConfig{JitterPercent: &jitter})
+		job, r := newJob("tcp-n2n", 10*time.Second, nil, nil)
+		sc.schedule(job, now)
+		planned := sc.entriesByID["tcp-n2n"].next
+
+		Expect(sc.dispatchDue(planned.Add(-time.Second))).To(Equal(time.Second))
+		late := planned.Add(500 * time.Millisecond)
+		wait := sc.dispatchDue(late)
+		Eventually(r.runs.Load).Should(Equal(int32(1)))
+		Expect(*job.GetLastRun()).To(Equal(late))
+		next := sc.entriesByID["tcp-n2n"].next
+		Expect(next.Sub(planned)).To(BeNumerically("~", 10*time.Second, time.Second))
+		Expect(wait).To(Equal(next.Sub(late)))
+	})
+
+	It("should restart from now if far behind schedule", func() {
+		job, _ := newJob("tcp-n2n", 10*time.Second, nil, nil)
+		sc.schedule(job, now)
+		later := now.Add(time.Hour)
+		sc.dispatchDue(later)
+		Expect(sc.entriesByID["tcp-n2n"].next).To(BeTemporally("~", later.Add(10*time.Second), time.Second))
+	})
+
+	It("should skip runs of active jobs", func() {
+		release := make(chan struct{})
+		defer close(release)
+		job, r := newJob("skip-test", time.Second, release, nil)
+		sc.schedule(job, now)
+		sc.dispatchDue(now.Add(time.Second))
+		Eventually(r.runs.Load).Should(Equal(int32(1)))
+		sc.dispatchDue(now.Add(2 * time.Second))
+		Expect(testutil.ToFloat64(SkippedJobRuns.WithLabelValues("skip-test"))).To(Equal(float64(1)))
+		Expect(r.runs.Load()).To(Equal(int32(1)))
+		Expect(job.IsActive()).To(BeTrue())
+	})
+
+	It("should limit the number of concurrent runs", func() {
+		sc.configure(&config.SchedulerConfig{MaxConcurrentRuns: 2})
+		release := make(chan struct{})
+		var running atomic.Int32
+		var fakeRunners []*fakeRunner
+		for _, id := range []string{"a", "b", "c", "d"} {
+			job, r := newJob(id, time.Second, release, &running)
+			sc.schedule(job, now)
+			fakeRunners = append(fakeRunners, r)
+		}
+		runs := func() int32 {
+			var runs int32
+			for _, r := range fakeRunners {
+				runs += r.runs.Load()
+			}
+			return runs
+		}
+		// the due runs wait for a free slot without starting a goroutine
+		Expect(sc.dispatchDue(now.Add(time.Second))).To(Equal(maxSchedulerIdle))
+		Eventually(running.Load).Should(Equal(int32(2)))
+		Consistently(running.Load, 100*time.Millisecond).Should(Equal(int32(2)))
+		Expect(runs()).To(Equal(int32(2)))
+		select {
+		case <-sc.wakeup:
+		default:
+		}
+		close(release)
+		Eventually(sc.wakeup).Should(Receive())
+		Eventually(func() int32 {
+			sc.dispatchDue(now.Add(time.Second))
+			return runs()
+		}).Should(Equal(int32(4)))
+	})
+
+	It("should not spin on invalid periods", func() {
+		for _, period := range []time.Duration{0, -time.Second} {
+			job, r := newJob("invalid-period", period, nil, nil)
+			sc.schedule(job, now)
+			Expect(sc.dispatchDue(now)).To(BeNumerically("~", minSchedulerPeriod, minSchedulerPeriod/10))
+			Eventually(r.runs.Load).Should(Equal(int32(1)))
+			sc.unschedule("invalid-period")
+		}
+	})
+
+	It("should keep the schedule of replaced jobs and remove unscheduled jobs", func() {
+		job, _ := newJob("tcp-n2n", 10*time.Second, nil, nil)
+		sc.schedule(job, now)
+		planned := sc.entriesByID["tcp-n2n"].next
+		replaced, _ := newJob("tcp-n2n", 10*time.Second, nil, nil)
+		sc.schedule(replaced, now.Add(time.Second))
+		Expect(sc.entries).To(HaveLen(1))
+		Expect(sc.entries[0].job).To(BeIdenticalTo(replaced))
+		Expect(sc.entries[0].next).To(Equal(planned))
+
+		lastRun := now.Add(2 * time.Second)
+		replaced.SetLastRun(&lastRun)
+		slower, _ := newJob("tcp-n2n", 20*time.Second, nil, nil)
+		slower.SetLastRun(&lastRun)
+		sc.schedule(slower, now.Add(3*time.Second))
+		Expect(sc.entries[0].next).To(Equal(lastRun.Add(20 * time.Second)))
+
+		sc.unschedule("tcp-n2n")
+		Expect(sc.entries).To(BeEmpty())
+		Expect(sc.entriesByID).To(BeEmpty())
+		Expect(sc.dispatchDue(now)).To(Equal(maxSchedulerIdle))
+	})
+
+	It("should plan the first run of replaced jobs which never ran with the new period", func() {
+		job, _ := newJob("tcp-n2n", 10*time.Second, nil, nil)
+		sc.schedule(job, now)
+		phase := sc.phase("tcp-n2n")
+		slower, _ := newJob("tcp-n2n", 20*time.Second, nil, nil)
+		sc.schedule(slower, now.Add(time.Second))
+		Expect(sc.entries[0].next).To(Equal(now.Add(time.Duration(phase * float64(20*time.Second)))))
+	})
+
+	It("should not start a replaced job while the run of the old job is active", func() {
+		jitter := 0
+		sc.configure(&config.SchedulerConfig{JitterPercent: &jitter})
+		release := make(chan struct{})
+		job, r := newJob("replace-test", time.Second, release, nil)
+		sc.schedule(job, now)
+		sc.dispatchDue(now.Add(time.Second))
+		Eventually(r.runs.Load).Should(Equal(int32(1)))
+
+		replaced, replacedRunner := newJob("replace-test", time.Second, release, nil)
+		sc.schedule(replaced, now.Add(1500*time.Millisecond))
+		Expect(replaced.IsActive()).To(BeTrue())
+		sc.dispatchDue(now.Add(3 * time.Second))
+		Expect(testutil.ToFloat64(SkippedJobRuns.WithLabelValues("replace-test"))).To(Equal(float64(1)))
+		Expect(replacedRunner.runs.Load()).To(Equal(int32(0)))
+
+		close(release)
+		Eventually(replaced.IsActive).Should(BeFalse())
+		sc.dispatchDue(now.Add(5 * time.Second))
+		Eventually(replacedRunner.runs.Load).Should(Equal(int32(1)))
+		Expect(r.runs.Load()).To(Equal(int32(1)))
+	})
+})
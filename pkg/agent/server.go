@@ -8,7 +8,6 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"math/rand"
 	"net/http"
 	"os"
 	"os/signal"
@@ -49,16 +48,20 @@ type server struct {
 	currentAgentConfig   *config.AgentConfig
 	currentClusterConfig *config.ClusterConfig
 	obsChan              chan *nwpd.Observation
+	stream               *observationStream
 	writer               nwpd.ObservationWriter
 	aggregator           aggregation.ObservationListenerExtended
-	tickPeriod           time.Duration
+	scheduler            *scheduler
 	done                 chan struct{}
+	configGeneration     int64
+	configApplied        time.Time
 }
 
 var _ nwpd.AgentService = &server{}
 
 func newServer(log logrus.FieldLogger, agentConfigFile, clusterConfigFile string, hostNetwork bool) (*server, error) {
 	nodeName := getNodeName()
+	obsChan := make(chan *nwpd.Observation, 100)
 	return &server{
 		log:               log,
 		agentConfigFile:   agentConfigFile,
@@ -67,8 +70,9 @@ func newServer(log logrus.FieldLogger, agentConfigFile, clusterConfigFile string
 		hostNetwork:       hostNetwork,
 		nodeSampleStore:   config.NewNodeSampleStore(nodeName),
 		jobs:              map[jobid]*runners.InternalJob{},
-		obsChan:           make(chan *nwpd.Observation, 100),
-		tickPeriod:        200 * time.Millisecond,
+		obsChan:           obsChan,
+		stream:            newObservationStream(),
+		scheduler:         newScheduler(nodeName, obsChan),
 		done:              make(chan struct{}),
 	}, nil
 }
@@ -82,17 +86,29 @@ func getNodeName() string {
 }
 
 func (s *server) getNetworkCfg() *config.NetworkConfig {
+	return networkCfgOf(s.currentAgentConfig)
+}
+
+func networkCfgOf(agentCfg *config.AgentConfig) *config.NetworkConfig {
 	networkCfg := &config.NetworkConfig{}
-	if s.currentAgentConfig != nil {
-		if hostNetwork && s.currentAgentConfig.HostNetwork != nil {
-			networkCfg = s.currentAgentConfig.HostNetwork
-		} else if !hostNetwork && s.currentAgentConfig.PodNetwork != nil {
-			networkCfg = s.currentAgentConfig.PodNetwork
+	if agentCfg != nil {
+		if hostNetwork && agentCfg.HostNetwork != nil {
+			networkCfg = agentCfg.HostNetwork
+		} else if !hostNetwork && agentCfg.PodNetwork != nil {
+			networkCfg = agentCfg.PodNetwork
 		}
 	}
 	return networkCfg
 }
 
+// currentConfigs returns the current agent and cluster configuration for goroutines other than the reload loop, e.g.
+// HTTP handlers. The configurations are replaced on reload, but never modified.
+func (s *server) currentConfigs() (*config.AgentConfig, *config.ClusterConfig) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.currentAgentConfig, s.currentClusterConfig
+}
+
 func (s *server) setup() error {
 	cfg, err := config.LoadAgentConfig(s.agentConfigFile)
 	if err != nil {
@@ -130,6 +146,9 @@ func (s *server) setup() error {
 			return fmt.Errorf("invalid AggregationTimeWindow, must be >= 5m")
 		}
 	}
+	if cfg.MaxClockOffset != nil {
+		options.MaxClockOffset = cfg.MaxClockOffset.Duration
+	}
 	s.aggregator, err = aggregation.NewObsAggregator(options)
 	if err != nil {
 		return err
@@ -145,7 +164,11 @@ func (s *server) applyAgentConfig(cfg *config.AgentConfig) error {
 	if err != nil {
 		return err
 	}
+	s.lock.Lock()
 	s.currentAgentConfig = clone
+	s.lock.Unlock()
+
+	s.scheduler.configure(cfg.Scheduler)
 
 	networkCfg := s.getNetworkCfg()
 	if cfg.OutputDir != "" && s.writer == nil {
@@ -154,7 +177,8 @@ func (s *server) applyAgentConfig(cfg *config.AgentConfig) error {
 			prefix = networkCfg.DataFilePrefix
 		}
 		var err error
-		s.writer, err = db.NewObsWriter(s.log.WithField("sub", "writer"), cfg.OutputDir, prefix, cfg.RetentionHours)
+		budget := db.DiskBudget{MaxBytes: cfg.MaxDiskBytes, MinFreePercent: cfg.MinFreeDiskPercent}
+		s.writer, err = db.NewObsWriter(s.log.WithField("sub", "writer"), cfg.OutputDir, prefix, cfg.RetentionHours, budget, writerMetrics{})
 		if err != nil {
 			return err
 		}
@@ -201,6 +225,10 @@ func (s *server) applyAgentConfig(cfg *config.AgentConfig) error {
 			PeerNodeCount: peerNodeCount,
 		})
 	}
+	s.lock.Lock()
+	s.configGeneration++
+	s.configApplied = time.Now()
+	s.lock.Unlock()
 	go func() {
 		// second cleanup later to deal with potential blocked requests
 		// wait for request timeout
@@ -213,19 +241,6 @@ func (s *server) applyAgentConfig(cfg *config.AgentConfig) error {
 }
 
 func (s *server) parseJob(job *config.Job) (*runners.InternalJob, error) {
-	n := len(job.Args)
-	if n == 0 {
-		return nil, fmt.Errorf("no job args")
-	}
-
-	defaultPeriod := 1 * time.Second
-	if s.getNetworkCfg().DefaultPeriod.Duration != 0 {
-		defaultPeriod = s.getNetworkCfg().DefaultPeriod.Duration
-	}
-	rconfig := runners.RunnerConfig{
-		Job:    *job,
-		Period: defaultPeriod,
-	}
 	clusterCfg := config.ClusterConfig{}
 	if s.currentClusterConfig != nil {
 		clusterCfg = *s.currentClusterConfig
@@ -234,7 +249,43 @@ func (s *server) parseJob(job *config.Job) (*runners.InternalJob, error) {
 		MaxNodes:        s.maxPeerNodes,
 		NodeSampleStore: s.nodeSampleStore,
 	}
-	internalJob, err := runners.Parse(clusterCfg, rconfig, job.Args, &shuffleCfg)
+	return parseJob(job, s.getNetworkCfg(), clusterCfg, &shuffleCfg, s.checkLimiter())
+}
+
+// checkLimiter returns the limiter of the concurrent checks shared by the scheduled runs and the probes.
+func (s *server) checkLimiter() *runners.Limiter {
+	if s.scheduler == nil {
+		return nil
+	}
+	return s.scheduler.limiter
+}
+
+// defaultJobPeriod returns the period of jobs which don't specify it.
+func defaultJobPeriod(networkCfg *config.NetworkConfig) time.Duration {
+	if networkCfg.DefaultPeriod.Duration != 0 {
+		return networkCfg.DefaultPeriod.Duration
+	}
+	return 1 * time.Second
+}
+
+// parseJob parses a job of the network configuration for the given cluster configuration.
+func parseJob(job *config.Job, networkCfg *config.NetworkConfig, clusterCfg config.ClusterConfig, sampleCfg *config.SampleConfig,
+	limiter *runners.Limiter,
+) (*runners.InternalJob, error) {
+	if networkCfg.DefaultPeriod.Duration < 0 {
+		return nil, fmt.Errorf("invalid job %s: invalid default period %s", job.JobID, networkCfg.DefaultPeriod.Duration)
+	}
+	args, err := runners.JobArgs(*job)
+	if err != nil {
+		return nil, fmt.Errorf("invalid job %s: %s", job.JobID, err)
+	}
+
+	rconfig := runners.RunnerConfig{
+		Job:     *job,
+		Period:  defaultJobPeriod(networkCfg),
+		Limiter: limiter,
+	}
+	internalJob, err := runners.Parse(clusterCfg, rconfig, args, sampleCfg)
 	if err != nil {
 		return nil, fmt.Errorf("invalid job %s: %s", job.JobID, err)
 	}
@@ -248,12 +299,10 @@ func (s *server) addOrReplaceJob(job *runners.InternalJob) {
 	prefix := "starting"
 	if oldJob := s.jobs[job.JobID()]; oldJob != nil {
 		prefix = "restarting"
-		job.SetLastRun(oldJob.GetLastRun())
-	} else {
-		virtualLastRun := time.Now().Add(-time.Duration(float64(job.Period()) * rand.Float64())) // #nosec G404 -- no cryptographic use
-		job.SetLastRun(&virtualLastRun)
+		job.KeepLastResults(oldJob)
 	}
 	s.jobs[job.JobID()] = job
+	s.scheduler.schedule(job, time.Now())
 	s.logStart(job, prefix)
 }
 
@@ -262,7 +311,7 @@ func (s *server) logStart(job *runners.InternalJob, prefix string) {
 	if desc != "" {
 		desc += ", "
 	}
-	s.log.Infof("%s job %s: %s [%speriod=%.1fs]", prefix, job.Config().JobID, strings.Join(job.Config().Args, " "),
+	s.log.Infof("%s job %s: %s [%speriod=%.1fs]", prefix, job.Config().JobID, strings.Join(job.Args(), " "),
 		desc, job.Period().Seconds())
 }
 
@@ -272,6 +321,7 @@ func (s *server) deleteJob(jobID string) error {
 
 	if oldJob := s.jobs[jobID]; oldJob != nil {
 		delete(s.jobs, jobID)
+		s.scheduler.unschedule(jobID)
 		s.log.Infof("deleted job %s", jobID)
 	}
 	return nil
@@ -402,7 +452,9 @@ func (s *server) reloadConfig() {
 	changed := !reflect.DeepEqual(clusterConfig, s.currentClusterConfig) || !reflect.DeepEqual(agentConfig, s.currentAgentConfig)
 	if changed {
 		s.log.Infof("reloaded configuration from %s and %s", s.agentConfigFile, s.clusterConfigFile)
+		s.lock.Lock()
 		s.currentClusterConfig = clusterConfig
+		s.lock.Unlock()
 		err = s.applyAgentConfig(agentConfig)
 		if err != nil {
 			s.log.Warnf("cannot apply new agent configuration from %s", s.agentConfigFile)
@@ -418,15 +470,22 @@ func (s *server) run() {
 	interrupt := make(chan os.Signal, 1)
 	signal.Notify(interrupt, syscall.SIGINT, syscall.SIGTERM)
 
-	ticker := time.NewTicker(s.tickPeriod)
-
 	if port := s.getNetworkCfg().HTTPPort; port != 0 {
 		s.log.Infof("provide metrics at ':%d/metrics'", port)
 		http.Handle("/metrics", promhttp.Handler())
 
 		twirpServer := nwpd.NewAgentServiceServer(s)
 		s.log.Infof("provide agent service at ':%d%s'", port, twirpServer.PathPrefix())
-		http.Handle(twirpServer.PathPrefix(), twirpServer)
+		http.Handle(twirpServer.PathPrefix(), withProbeWriteDeadline(twirpServer))
+
+		s.log.Infof("provide observation stream at ':%d%s'", port, common.PathStreamObservations)
+		http.Handle(common.PathStreamObservations, s.stream)
+
+		s.log.Infof("provide echo endpoint at ':%d%s'", port, runners.EchoPath)
+		http.Handle(runners.EchoPath, newHTTPEchoHandler(s.nodeName, os.Getenv(common.EnvPodName)))
+
+		s.log.Infof("provide throughput endpoint at ':%d%s'", port, runners.ThroughputPath)
+		http.Handle(runners.ThroughputPath, newHTTPThroughputHandler())
 
 		go func() {
 			server := &http.Server{
@@ -441,6 +500,13 @@ func (s *server) run() {
 			s.log.Warnf(err.Error())
 		}()
 	}
+	if port := s.getNetworkCfg().UDPEchoPort; port != 0 {
+		s.log.Infof("provide UDP echo responder at ':%d'", port)
+		go func() {
+			err := runUDPEchoResponder(s.log.WithField("sub", "udpecho"), port)
+			s.log.Warnf("UDP echo responder stopped: %s", err)
+		}()
+	}
 	if s.writer != nil {
 		go s.writer.Run()
 	}
@@ -458,38 +524,47 @@ func (s *server) run() {
 	}
 	defer watcher.Close()
 
+	stopScheduler := make(chan struct{})
+	defer close(stopScheduler)
+	go s.scheduler.run(stopScheduler)
+
 	for {
 		select {
 		case <-s.done:
-			ticker.Stop()
 			s.stop()
 			return
 		case <-interrupt:
-			ticker.Stop()
 			s.stop()
 			return
 		case obs := <-s.obsChan:
-			logObservation := s.currentAgentConfig.LogObservations
-			if logObservation {
-				fields := logrus.Fields{
-					"src":   obs.SrcHost,
-					"dest":  obs.DestHost,
-					"ok":    obs.Ok,
-					"jobid": obs.JobID,
-					"time":  obs.Timestamp.AsTime(),
-				}
-				s.log.WithFields(fields).Info(obs.Result)
-			}
-			IncAggregatedObservation(obs.SrcHost, obs.DestHost, obs.JobID, obs.Ok)
+			s.logObservation(obs)
+			s.recordLastResult(obs)
+			IncAggregatedObservation(obs.SrcHost, obs.DestHost, obs.JobID, obs.Ok, obs.FailureClass)
 			if obs.Ok && obs.Duration != nil {
 				ReportAggregatedObservationLatency(obs.SrcHost, obs.DestHost, obs.JobID, obs.Duration.AsDuration().Seconds())
 			}
+			if obs.PathMTU > 0 {
+				ReportPathMTU(obs.SrcHost, obs.DestHost, obs.JobID, obs.PathMTU)
+			}
+			if obs.PhaseTimings != nil {
+				ReportHTTPPhaseDurations(obs.JobID, obs.PhaseTimings)
+			}
+			if obs.ClockOffset != nil {
+				ReportPeerClockOffset(obs.SrcHost, obs.DestHost, obs.JobID, obs.ClockOffset.AsDuration().Seconds())
+			}
+			if obs.Throughput > 0 {
+				ReportThroughput(obs.SrcHost, obs.DestHost, obs.JobID, obs.Throughput)
+			}
+			if obs.CertRemainingLifetime != nil {
+				ReportTLSCertRemainingLifetime(obs.SrcHost, obs.DestHost, obs.JobID, obs.CertRemainingLifetime.AsDuration().Seconds())
+			}
 			if s.writer != nil {
 				s.writer.Add(obs)
 			}
 			if s.aggregator != nil {
 				s.aggregator.Add(obs)
 			}
+			s.stream.publish(obs)
 		case err := <-watcher.Errors:
 			s.log.Warning("watcher failed: %s", err)
 			s.stop()
@@ -497,19 +572,32 @@ func (s *server) run() {
 		case <-watcher.Events:
 			s.log.Debug("watch")
 			go s.reloadConfig()
-		case <-ticker.C:
-			s.triggerJobs()
 		}
 	}
 }
 
-func (s *server) triggerJobs() {
+func (s *server) recordLastResult(obs *nwpd.Observation) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
-	for _, job := range s.jobs {
-		if err := job.Tick(s.nodeName, s.obsChan); err != nil {
-			s.log.Debug(err)
-		}
+	if job := s.jobs[obs.JobID]; job != nil {
+		job.SetLastResult(obs)
+	}
+}
+
+func (s *server) logObservation(obs *nwpd.Observation) {
+	if agentCfg, _ := s.currentConfigs(); !agentCfg.LogObservations {
+		return
+	}
+	fields := logrus.Fields{
+		"src":   obs.SrcHost,
+		"dest":  obs.DestHost,
+		"ok":    obs.Ok,
+		"jobid": obs.JobID,
+		"time":  obs.Timestamp.AsTime(),
+	}
+	if obs.AdHoc {
+		fields["adhoc"] = true
 	}
+	s.log.WithFields(fields).Info(obs.Result)
 }
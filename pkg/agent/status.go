@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+	"sort"
+
+	"github.com/gardener/network-problem-detector/pkg/agent/runners"
+	"github.com/gardener/network-problem-detector/pkg/agent/version"
+	"github.com/gardener/network-problem-detector/pkg/common/nwpd"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GetStatus returns the version, configuration generation, jobs with their runner state and the valid edges of the aggregator.
+func (s *server) GetStatus(_ context.Context, _ *nwpd.GetStatusRequest) (*nwpd.GetStatusResponse, error) {
+	s.lock.Lock()
+	response := &nwpd.GetStatusResponse{
+		Version:          version.Version,
+		NodeName:         s.nodeName,
+		HostNetwork:      s.hostNetwork,
+		ConfigGeneration: s.configGeneration,
+	}
+	if !s.configApplied.IsZero() {
+		response.ConfigApplied = timestamppb.New(s.configApplied)
+	}
+	jobs := make([]*runners.InternalJob, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		jobs = append(jobs, job)
+	}
+	s.lock.Unlock()
+
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].JobID() < jobs[j].JobID() })
+	for _, job := range jobs {
+		response.Jobs = append(response.Jobs, jobStatus(job))
+	}
+	if s.aggregator != nil {
+		edges := s.aggregator.GetValidEdges()
+		response.ValidEdges = &nwpd.ValidEdges{
+			JobIDs:        edges.JobIDs.ToSortedArray(),
+			SrcHosts:      edges.SrcHosts.ToSortedArray(),
+			DestHosts:     edges.DestHosts.ToSortedArray(),
+			PeerNodeCount: int32(edges.PeerNodeCount), // #nosec G115 -- number of nodes fits in int32
+		}
+	}
+	return response, nil
+}
+
+func jobStatus(job *runners.InternalJob) *nwpd.JobStatus {
+	destHosts := job.DestHosts()
+	sort.Strings(destHosts)
+	status := &nwpd.JobStatus{
+		JobID:         job.JobID(),
+		Args:          job.Args(),
+		Description:   job.Description(),
+		DestHosts:     destHosts,
+		Period:        durationpb.New(job.Period()),
+		Active:        job.IsActive(),
+		PeerNodeCount: int32(job.PeerNodeCount()), // #nosec G115 -- number of nodes fits in int32
+		Labels:        job.Labels(),
+	}
+	if lastRun := job.GetLastRun(); lastRun != nil {
+		status.LastRun = timestamppb.New(*lastRun)
+	}
+	for _, obs := range job.LastResults() {
+		status.LastResults = append(status.LastResults, obs)
+	}
+	sort.Slice(status.LastResults, func(i, j int) bool { return status.LastResults[i].DestHost < status.LastResults[j].DestHost })
+	return status
+}
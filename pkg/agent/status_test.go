@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+	"time"
+
+	"github.com/gardener/network-problem-detector/pkg/agent/aggregation"
+	"github.com/gardener/network-problem-detector/pkg/agent/runners"
+	"github.com/gardener/network-problem-detector/pkg/common"
+	"github.com/gardener/network-problem-detector/pkg/common/config"
+	"github.com/gardener/network-problem-detector/pkg/common/nwpd"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type fakeAggregator struct {
+	edges aggregation.ValidEdges
+}
+
+var _ aggregation.ObservationListenerExtended = &fakeAggregator{}
+
+func (a *fakeAggregator) Add(_ *nwpd.Observation) {}
+
+func (a *fakeAggregator) UpdateValidEdges(edges aggregation.ValidEdges) {
+	a.edges = edges
+}
+
+func (a *fakeAggregator) GetValidEdges() aggregation.ValidEdges {
+	return a.edges
+}
+
+var _ = Describe("GetStatus", func() {
+	It("should return jobs with runner state and valid edges", func() {
+		s := &server{
+			log:                  logrus.New(),
+			nodeName:             "node-1",
+			hostNetwork:          true,
+			jobs:                 map[jobid]*runners.InternalJob{},
+			currentAgentConfig:   &config.AgentConfig{HostNetwork: &config.NetworkConfig{}},
+			currentClusterConfig: syntheticClusterConfig(3),
+			aggregator:           &fakeAggregator{},
+			scheduler:            newScheduler("node-1", nil),
+		}
+		for _, job := range []config.Job{
+			{JobID: "tcp-n2n", Args: []string{"checkTCPPort", "--node-port", "10250"}},
+			{JobID: "https-n2api", Spec: &config.JobSpec{
+				Type:    "checkHTTPSGet",
+				Targets: &config.JobTargets{InternalKubeAPIServer: true},
+				Labels:  map[string]string{"team": "network"},
+			}},
+		} {
+			internalJob, err := s.parseJob(&job)
+			Expect(err).To(BeNil())
+			s.addOrReplaceJob(internalJob)
+		}
+		s.aggregator.UpdateValidEdges(aggregation.ValidEdges{
+			JobIDs:        common.StringSet{"tcp-n2n": {}, "https-n2api": {}},
+			SrcHosts:      common.StringSet{"node-1": {}},
+			DestHosts:     common.StringSet{"node-2": {}, "node-3": {}},
+			PeerNodeCount: 3,
+		})
+		s.configGeneration = 2
+		s.recordLastResult(&nwpd.Observation{JobID: "tcp-n2n", DestHost: "node-2", Ok: true, Timestamp: timestamppb.Now()})
+		s.recordLastResult(&nwpd.Observation{JobID: "tcp-n2n", DestHost: "node-3", Ok: false, Timestamp: timestamppb.Now()})
+		s.recordLastResult(&nwpd.Observation{JobID: "unknown", DestHost: "node-3", Ok: false, Timestamp: timestamppb.Now()})
+
+		// a restarted job keeps its last results
+		job, err := s.parseJob(&config.Job{JobID: "tcp-n2n", Args: []string{"checkTCPPort", "--node-port", "10250", "--period", "10s"}})
+		Expect(err).To(BeNil())
+		s.addOrReplaceJob(job)
+
+		status, err := s.GetStatus(context.Background(), &nwpd.GetStatusRequest{})
+		Expect(err).To(BeNil())
+		Expect(status.NodeName).To(Equal("node-1"))
+		Expect(status.HostNetwork).To(BeTrue())
+		Expect(status.ConfigGeneration).To(Equal(int64(2)))
+		Expect(status.ValidEdges.JobIDs).To(Equal([]string{"https-n2api", "tcp-n2n"}))
+		Expect(status.ValidEdges.DestHosts).To(Equal([]string{"node-2", "node-3"}))
+		Expect(status.ValidEdges.PeerNodeCount).To(Equal(int32(3)))
+
+		Expect(status.Jobs).To(HaveLen(2))
+		Expect(status.Jobs[0].JobID).To(Equal("https-n2api"))
+		Expect(status.Jobs[0].LastResults).To(BeEmpty())
+		Expect(status.Jobs[0].Labels).To(Equal(map[string]string{"team": "network"}))
+		tcp := status.Jobs[1]
+		Expect(tcp.JobID).To(Equal("tcp-n2n"))
+		Expect(tcp.Args).To(Equal([]string{"checkTCPPort", "--node-port", "10250", "--period", "10s"}))
+		Expect(tcp.Description).To(Equal("3 endpoints"))
+		Expect(tcp.DestHosts).To(Equal([]string{"node-1", "node-2", "node-3"}))
+		Expect(tcp.Period.AsDuration()).To(Equal(10 * time.Second))
+		Expect(tcp.LastRun).To(BeNil())
+		Expect(tcp.Active).To(BeFalse())
+		Expect(tcp.PeerNodeCount).To(Equal(int32(3)))
+		Expect(tcp.Labels).To(BeEmpty())
+		Expect(tcp.LastResults).To(HaveLen(2))
+		Expect(tcp.LastResults[0].DestHost).To(Equal("node-2"))
+		Expect(tcp.LastResults[0].Ok).To(BeTrue())
+		Expect(tcp.LastResults[1].DestHost).To(Equal("node-3"))
+		Expect(tcp.LastResults[1].Ok).To(BeFalse())
+	})
+})
@@ -0,0 +1,177 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gardener/network-problem-detector/pkg/common"
+	"github.com/gardener/network-problem-detector/pkg/common/nwpd"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	// streamBufferSize is the number of observations buffered per client before observations are dropped.
+	streamBufferSize = 100
+	// streamWriteTimeout is the timeout for writing a single event to a client.
+	streamWriteTimeout = 10 * time.Second
+	// streamKeepAlivePeriod is the period of keep-alive comments sent to idle clients.
+	streamKeepAlivePeriod = 15 * time.Second
+)
+
+// observationStream broadcasts new observations to the subscribed clients as server-sent events.
+// Publishing never blocks: if the buffer of a slow client is full, the observation is dropped and counted.
+type observationStream struct {
+	lock        sync.Mutex
+	subscribers map[*streamSubscriber]struct{}
+}
+
+type streamSubscriber struct {
+	filter  observationFilter
+	ch      chan *nwpd.Observation
+	dropped atomic.Int64
+}
+
+// observationFilter filters observations like the restrictions of a GetObservationsRequest. Empty sets match all values.
+type observationFilter struct {
+	jobIDs       common.StringSet
+	srcHosts     common.StringSet
+	destHosts    common.StringSet
+	failuresOnly bool
+}
+
+func (f *observationFilter) matches(obs *nwpd.Observation) bool {
+	if f.failuresOnly && obs.Ok {
+		return false
+	}
+	return matchesSet(f.jobIDs, obs.JobID) && matchesSet(f.srcHosts, obs.SrcHost) && matchesSet(f.destHosts, obs.DestHost)
+}
+
+func matchesSet(set common.StringSet, value string) bool {
+	return len(set) == 0 || set.Contains(value)
+}
+
+// parseObservationFilter parses the filter from the query parameters, which are named like the fields of a GetObservationsRequest.
+func parseObservationFilter(r *http.Request) (observationFilter, error) {
+	query := r.URL.Query()
+	filter := observationFilter{
+		jobIDs:    common.StringSet{},
+		srcHosts:  common.StringSet{},
+		destHosts: common.StringSet{},
+	}
+	filter.jobIDs.AddAll(query["restrictToJobIDs"]...)
+	filter.srcHosts.AddAll(query["restrictToSrcHosts"]...)
+	filter.destHosts.AddAll(query["restrictToDestHosts"]...)
+	if value := query.Get("failuresOnly"); value != "" {
+		failuresOnly, err := strconv.ParseBool(value)
+		if err != nil {
+			return filter, fmt.Errorf("invalid failuresOnly %q", value)
+		}
+		filter.failuresOnly = failuresOnly
+	}
+	return filter, nil
+}
+
+func newObservationStream() *observationStream {
+	return &observationStream{
+		subscribers: map[*streamSubscriber]struct{}{},
+	}
+}
+
+func (o *observationStream) subscribe(filter observationFilter) *streamSubscriber {
+	sub := &streamSubscriber{
+		filter: filter,
+		ch:     make(chan *nwpd.Observation, streamBufferSize),
+	}
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	o.subscribers[sub] = struct{}{}
+	return sub
+}
+
+func (o *observationStream) unsubscribe(sub *streamSubscriber) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	delete(o.subscribers, sub)
+}
+
+// publish passes the observation to all matching subscribers without blocking.
+func (o *observationStream) publish(obs *nwpd.Observation) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	for sub := range o.subscribers {
+		if !sub.filter.matches(obs) {
+			continue
+		}
+		select {
+		case sub.ch <- obs:
+		default:
+			sub.dropped.Add(1)
+			IncStreamDroppedObservations()
+		}
+	}
+}
+
+// ServeHTTP streams the observations as server-sent events with the observation in JSON format as data.
+// If observations have been dropped, an event `dropped` with the total number of dropped observations is sent.
+func (o *observationStream) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseObservationFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	sub := o.subscribe(filter)
+	defer o.unsubscribe(sub)
+
+	rc := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	write := func(format string, args ...any) error {
+		if err := rc.SetWriteDeadline(time.Now().Add(streamWriteTimeout)); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, format, args...); err != nil {
+			return err
+		}
+		return rc.Flush()
+	}
+	if err := write(": connected\n\n"); err != nil {
+		return
+	}
+
+	keepAlive := time.NewTicker(streamKeepAlivePeriod)
+	defer keepAlive.Stop()
+	var reportedDropped int64
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			err = write(": keep-alive\n\n")
+		case obs := <-sub.ch:
+			var data []byte
+			if data, err = protojson.Marshal(obs); err == nil {
+				err = write("data: %s\n\n", data)
+			}
+		}
+		if err != nil {
+			return
+		}
+		if dropped := sub.dropped.Load(); dropped != reportedDropped {
+			if err := write("event: dropped\ndata: %d\n\n", dropped); err != nil {
+				return
+			}
+			reportedDropped = dropped
+		}
+	}
+}
@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/gardener/network-problem-detector/pkg/common/nwpd"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var _ = Describe("observationStream", func() {
+	newObs := func(jobID, destHost string, ok bool) *nwpd.Observation {
+		return &nwpd.Observation{JobID: jobID, SrcHost: "node1", DestHost: destHost, Ok: ok, Timestamp: timestamppb.Now()}
+	}
+
+	It("should stream matching observations as server-sent events", func() {
+		stream := newObservationStream()
+		httpServer := httptest.NewServer(stream)
+		defer httpServer.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+			httpServer.URL+"?restrictToJobIDs=tcp-n2n&restrictToJobIDs=ping-n2n&failuresOnly=true", nil)
+		Expect(err).To(BeNil())
+		resp, err := http.DefaultClient.Do(req)
+		Expect(err).To(BeNil())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		Expect(resp.Header.Get("Content-Type")).To(Equal("text/event-stream"))
+
+		reader := bufio.NewReader(resp.Body)
+		line, err := reader.ReadString('\n')
+		Expect(err).To(BeNil())
+		Expect(line).To(Equal(": connected\n"))
+
+		stream.publish(newObs("tcp-n2n", "node2", true))
+		stream.publish(newObs("https-n2api", "api", false))
+		stream.publish(newObs("ping-n2n", "node3", false))
+
+		for {
+			line, err = reader.ReadString('\n')
+			Expect(err).To(BeNil())
+			if data, ok := strings.CutPrefix(line, "data: "); ok {
+				obs := &nwpd.Observation{}
+				Expect(protojson.Unmarshal([]byte(data), obs)).To(Succeed())
+				Expect(obs.JobID).To(Equal("ping-n2n"))
+				Expect(obs.DestHost).To(Equal("node3"))
+				break
+			}
+		}
+	})
+
+	It("should drop and count observations for slow clients", func() {
+		stream := newObservationStream()
+		sub := stream.subscribe(observationFilter{})
+		for i := 0; i < streamBufferSize+5; i++ {
+			stream.publish(newObs("tcp-n2n", "node2", true))
+		}
+		Expect(sub.ch).To(HaveLen(streamBufferSize))
+		Expect(sub.dropped.Load()).To(Equal(int64(5)))
+
+		stream.unsubscribe(sub)
+		stream.publish(newObs("tcp-n2n", "node2", true))
+		Expect(sub.dropped.Load()).To(Equal(int64(5)))
+	})
+
+	It("should reject invalid filters", func() {
+		rec := httptest.NewRecorder()
+		newObservationStream().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?failuresOnly=maybe", nil))
+		Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		Expect(rec.Body.String()).To(Equal("invalid failuresOnly \"maybe\"\n"))
+	})
+})
@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+
+	"github.com/gardener/network-problem-detector/pkg/agent/runners"
+
+	"github.com/sirupsen/logrus"
+)
+
+// maxUDPEchoPacketSize is the maximum size of an UDP echo request (large enough for path MTU probes with jumbo frames).
+const maxUDPEchoPacketSize = 9216
+
+// runUDPEchoResponder answers UDP echo requests sent by `checkUDPPort` jobs of other agents.
+// Only packets starting with the UDP echo prefix are sent back to avoid acting as a general reflector.
+func runUDPEchoResponder(log logrus.FieldLogger, port int) error {
+	conn, err := net.ListenPacket("udp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	return serveUDPEcho(log, conn)
+}
+
+// serveUDPEcho answers the UDP echo requests received on the connection until reading fails.
+func serveUDPEcho(log logrus.FieldLogger, conn net.PacketConn) error {
+	prefix := []byte(runners.UDPEchoPrefix)
+	buf := make([]byte, maxUDPEchoPacketSize)
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			return err
+		}
+		if !bytes.HasPrefix(buf[:n], prefix) {
+			continue
+		}
+		if _, err := conn.WriteTo(buf[:n], addr); err != nil {
+			log.Debugf("udp echo to %s failed: %s", addr, err)
+		}
+	}
+}
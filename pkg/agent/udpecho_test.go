@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"errors"
+	"net"
+	"time"
+
+	"github.com/gardener/network-problem-detector/pkg/agent/runners"
+	"github.com/gardener/network-problem-detector/pkg/common/config"
+	"github.com/gardener/network-problem-detector/pkg/common/nwpd"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+)
+
+var _ = Describe("UDP echo responder", func() {
+	var conn net.PacketConn
+
+	BeforeEach(func() {
+		var err error
+		conn, err = net.ListenPacket("udp", "127.0.0.1:0")
+		Expect(err).To(BeNil())
+		go func() {
+			_ = serveUDPEcho(logrus.New(), conn)
+		}()
+	})
+
+	AfterEach(func() {
+		_ = conn.Close()
+	})
+
+	send := func(payload string) (string, error) {
+		client, err := net.Dial("udp", conn.LocalAddr().String())
+		Expect(err).To(BeNil())
+		defer client.Close()
+		Expect(client.SetDeadline(time.Now().Add(500 * time.Millisecond))).To(Succeed())
+		_, err = client.Write([]byte(payload))
+		Expect(err).To(BeNil())
+		buf := make([]byte, 1024)
+		n, err := client.Read(buf)
+		return string(buf[:n]), err
+	}
+
+	It("should echo prefixed datagrams", func() {
+		response, err := send(runners.UDPEchoPrefix + "1234")
+		Expect(err).To(BeNil())
+		Expect(response).To(Equal(runners.UDPEchoPrefix + "1234"))
+	})
+
+	It("should ignore datagrams without prefix", func() {
+		_, err := send("hello")
+		Expect(err).NotTo(BeNil())
+		var netErr net.Error
+		Expect(errors.As(err, &netErr)).To(BeTrue())
+		Expect(netErr.Timeout()).To(BeTrue())
+	})
+
+	It("should be checked by checkUDPPort", func() {
+		port := conn.LocalAddr().(*net.UDPAddr).Port
+		r := runners.NewCheckUDPPort([]config.Endpoint{{Hostname: "local", IP: "127.0.0.1", Port: port}},
+			runners.RunnerConfig{Job: config.Job{JobID: "udp"}, Timeout: time.Second})
+		ch := make(chan *nwpd.Observation, 1)
+		r.Run("node1", ch)
+		var obs *nwpd.Observation
+		Eventually(ch).Should(Receive(&obs))
+		Expect(obs.Ok).To(BeTrue())
+		Expect(obs.DestHost).To(Equal("local"))
+	})
+})
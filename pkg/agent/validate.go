@@ -0,0 +1,185 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gardener/network-problem-detector/pkg/common"
+	"github.com/gardener/network-problem-detector/pkg/common/config"
+
+	"github.com/spf13/cobra"
+)
+
+// maxPrintedTargets is the maximum number of targets printed per job.
+const maxPrintedTargets = 10
+
+type validateConfigCommand struct {
+	agentConfigFile   string
+	clusterConfigFile string
+	nodes             int
+}
+
+// CreateValidateConfigCmd creates the command validating an agent configuration offline.
+func CreateValidateConfigCmd() *cobra.Command {
+	vc := &validateConfigCommand{}
+	cmd := &cobra.Command{
+		Use:   "validate-config",
+		Short: "validates the jobs of an agent configuration for a cluster configuration or a synthetic cluster",
+		Long: `Parses all jobs of the host and pod network of the agent configuration and prints the resolved runners,
+their targets, effective periods and the estimated number of checks per second.`,
+		RunE: vc.validate,
+	}
+	cmd.Flags().StringVar(&vc.agentConfigFile, "config", "", "file configuration of agent server.")
+	cmd.Flags().StringVar(&vc.clusterConfigFile, "cluster-config", "", "file configuration of cluster nodes and agent pods.")
+	cmd.Flags().IntVar(&vc.nodes, "nodes", 0, "number of nodes of a synthetic cluster as alternative to a cluster configuration.")
+	return cmd
+}
+
+func (vc *validateConfigCommand) validate(cmd *cobra.Command, _ []string) error {
+	if vc.agentConfigFile == "" {
+		return fmt.Errorf("missing --config option")
+	}
+	agentCfg, err := config.LoadAgentConfig(vc.agentConfigFile)
+	if err != nil {
+		return err
+	}
+
+	var clusterCfg *config.ClusterConfig
+	switch {
+	case vc.clusterConfigFile != "" && vc.nodes != 0:
+		return fmt.Errorf("options --cluster-config and --nodes are mutually exclusive")
+	case vc.clusterConfigFile != "":
+		if clusterCfg, err = config.LoadClusterConfig(vc.clusterConfigFile); err != nil {
+			return err
+		}
+		if clusterCfg.NodeCount == 0 {
+			clusterCfg.NodeCount = len(clusterCfg.Nodes)
+		}
+	case vc.nodes > 0:
+		clusterCfg = syntheticClusterConfig(vc.nodes)
+	default:
+		return fmt.Errorf("missing --cluster-config or --nodes option")
+	}
+
+	out := cmd.OutOrStdout()
+	invalid := validateNetworkConfig(out, "host", agentCfg.HostNetwork, clusterCfg, agentCfg.MaxPeerNodes)
+	invalid += validateNetworkConfig(out, "pod", agentCfg.PodNetwork, clusterCfg, agentCfg.MaxPeerNodes)
+	if invalid > 0 {
+		return fmt.Errorf("%d invalid jobs", invalid)
+	}
+	return nil
+}
+
+// validateNetworkConfig parses the jobs of a network configuration, prints the results and returns the number of invalid jobs.
+func validateNetworkConfig(out io.Writer, network string, networkCfg *config.NetworkConfig, clusterCfg *config.ClusterConfig, maxPeerNodes int) int {
+	if networkCfg == nil {
+		fmt.Fprintf(out, "%s network: not configured\n\n", network)
+		return 0
+	}
+
+	fmt.Fprintf(out, "%s network (%d jobs, default period %s):\n", network, len(networkCfg.Jobs), defaultJobPeriod(networkCfg))
+	var sampleNodeName string
+	if len(clusterCfg.Nodes) > 0 {
+		sampleNodeName = clusterCfg.Nodes[0].Hostname
+	}
+	sampleCfg := &config.SampleConfig{
+		MaxNodes:        maxPeerNodes,
+		NodeSampleStore: config.NewNodeSampleStore(sampleNodeName),
+	}
+	invalid := 0
+	checksPerSecond := 0.0
+	for i := range networkCfg.Jobs {
+		job := &networkCfg.Jobs[i]
+		internalJob, err := parseJob(job, networkCfg, *clusterCfg, sampleCfg, nil)
+		if err != nil {
+			invalid++
+			fmt.Fprintf(out, "  ERROR %s\n", err)
+			continue
+		}
+		if internalJob == nil {
+			fmt.Fprintf(out, "  %s: no targets, job is skipped\n", job.JobID)
+			continue
+		}
+		rate := float64(internalJob.ChecksPerRun()) / internalJob.Period().Seconds()
+		checksPerSecond += rate
+		fmt.Fprintf(out, "  %s: %s\n", job.JobID, strings.Join(internalJob.Args(), " "))
+		fmt.Fprintf(out, "    runner: %s, period %s, %.3g checks/s\n", internalJob.Description(), internalJob.Period().Round(time.Millisecond), rate)
+		if labels := internalJob.Labels(); len(labels) > 0 {
+			fmt.Fprintf(out, "    labels: %s\n", common.FormatLabels(labels))
+		}
+		fmt.Fprintf(out, "    targets: %s\n", formatTargets(internalJob.DestHosts()))
+	}
+	fmt.Fprintf(out, "  estimated checks: %.3g/s per node, %.3g/s cluster-wide (%d nodes)\n\n",
+		checksPerSecond, checksPerSecond*float64(clusterCfg.NodeCount), clusterCfg.NodeCount)
+	return invalid
+}
+
+func formatTargets(hosts []string) string {
+	hosts = slices.Clone(hosts)
+	slices.SortFunc(hosts, compareNatural)
+	if len(hosts) <= maxPrintedTargets {
+		return strings.Join(hosts, ", ")
+	}
+	return fmt.Sprintf("%s, ... (%d more)", strings.Join(hosts[:maxPrintedTargets], ", "), len(hosts)-maxPrintedTargets)
+}
+
+// compareNatural compares strings with numeric suffixes by their numbers, e.g. `node-9` before `node-10`.
+func compareNatural(a, b string) int {
+	ta, tb := strings.TrimRight(a, "0123456789"), strings.TrimRight(b, "0123456789")
+	if ta == tb && len(a) != len(b) {
+		return len(a) - len(b)
+	}
+	return strings.Compare(a, b)
+}
+
+// syntheticClusterConfig returns a cluster configuration with the given number of nodes and one agent pod per node.
+func syntheticClusterConfig(nodes int) *config.ClusterConfig {
+	clusterCfg := &config.ClusterConfig{
+		NodeCount: nodes,
+		InternalKubeAPIServer: &config.Endpoint{
+			Hostname: common.DomainNameKubernetesService,
+			IP:       "100.64.0.1",
+			Port:     443,
+		},
+		KubeAPIServer: &config.Endpoint{
+			Hostname: "api.example.com",
+			IP:       "192.0.2.1",
+			Port:     443,
+		},
+		KubeDNSService: &config.Endpoint{
+			Hostname: common.DomainNameKubeDNSService,
+			IP:       "100.64.0.10",
+			Port:     53,
+		},
+	}
+	for i := 1; i <= nodes; i++ {
+		hostname := fmt.Sprintf("node-%d", i)
+		clusterCfg.Nodes = append(clusterCfg.Nodes, config.Node{
+			Name:        hostname,
+			Hostname:    hostname,
+			InternalIPs: []string{fmt.Sprintf("10.250.%d.%d", i/256, i%256)},
+		})
+		clusterCfg.PodEndpoints = append(clusterCfg.PodEndpoints, config.PodEndpoint{
+			Nodename: hostname,
+			Podname:  fmt.Sprintf("%s-%d", common.NameDaemonSetAgentPodNet, i),
+			PodIP:    fmt.Sprintf("100.96.%d.%d", i/256, i%256),
+			Port:     common.PodNetPodHTTPPort,
+		})
+		if i <= 2 {
+			clusterCfg.KubeDNSPods = append(clusterCfg.KubeDNSPods, config.PodEndpoint{
+				Nodename: hostname,
+				Podname:  fmt.Sprintf("coredns-%d", i),
+				PodIP:    fmt.Sprintf("100.97.0.%d", i),
+				Port:     8053,
+			})
+		}
+	}
+	return clusterCfg
+}
@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("validate-config", func() {
+	run := func(agentConfig string, args ...string) (string, error) {
+		file := filepath.Join(GinkgoT().TempDir(), "agent.yaml")
+		Expect(os.WriteFile(file, []byte(agentConfig), 0o600)).To(Succeed())
+		cmd := CreateValidateConfigCmd()
+		out := &bytes.Buffer{}
+		cmd.SetOut(out)
+		cmd.SetErr(out)
+		cmd.SetArgs(append([]string{"--config", file}, args...))
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	It("should print jobs and estimated checks for a synthetic cluster", func() {
+		out, err := run(`
+hostNetwork:
+  defaultPeriod: 10s
+  jobs:
+  - jobID: tcp-n2n
+    args: ["checkTCPPort", "--node-port", "12996"]
+  - jobID: tcp-n2n-fan-out
+    spec:
+      type: checkTCPPort
+      targets:
+        nodePort: 12996
+      params:
+        fan-out: -1
+      period: 20s
+      labels:
+        team: network
+  - jobID: tcp-n2n-ipv6
+    args: ["checkTCPPort", "--node-port-ipv6", "12996"]
+`, "--nodes", "12")
+		Expect(err).To(BeNil())
+		Expect(out).To(ContainSubstring("host network (3 jobs, default period 10s):\n"))
+		Expect(out).To(ContainSubstring("  tcp-n2n: checkTCPPort --node-port 12996\n    runner: 12 endpoints, period 10s, 0.1 checks/s\n"))
+		Expect(out).To(ContainSubstring("    targets: node-1, node-2, node-3, node-4, node-5, node-6, node-7, node-8, node-9, node-10, ... (2 more)\n"))
+		Expect(out).To(ContainSubstring("  tcp-n2n-fan-out: checkTCPPort --node-port=12996 --fan-out=-1 --period=20s\n" +
+			"    runner: 12 endpoints (fan-out 12, max parallel 8), period 20s, 0.6 checks/s\n    labels: team=network\n"))
+		Expect(out).To(ContainSubstring("  tcp-n2n-ipv6: no targets, job is skipped\n"))
+		Expect(out).To(ContainSubstring("  estimated checks: 0.7/s per node, 8.4/s cluster-wide (12 nodes)\n"))
+		Expect(out).To(ContainSubstring("pod network: not configured\n"))
+	})
+
+	It("should report invalid jobs", func() {
+		out, err := run(`
+podNetwork:
+  jobs:
+  - jobID: tcp-p2p
+    args: ["checkTCPPort", "--endpoints-of-pod"]
+  - jobID: http-p2api
+    spec:
+      type: checkHTTP
+      targets:
+        nodePort: 443
+      params:
+        methd: GET
+`, "--nodes", "3")
+		Expect(err).NotTo(BeNil())
+		Expect(err.Error()).To(Equal("2 invalid jobs"))
+		Expect(out).To(ContainSubstring("  ERROR invalid job tcp-p2p: unknown flag: --endpoints-of-pod\n"))
+		Expect(out).To(ContainSubstring("  ERROR invalid job http-p2api: unknown parameter methd for job type checkHTTP\n"))
+	})
+	It("should report an invalid default period", func() {
+		out, err := run(`
+podNetwork:
+  defaultPeriod: -1s
+  jobs:
+  - jobID: tcp-p2p
+    args: ["checkTCPPort", "--endpoints-of-pod-ds"]
+`, "--nodes", "3")
+		Expect(err).NotTo(BeNil())
+		Expect(err.Error()).To(Equal("1 invalid jobs"))
+		Expect(out).To(ContainSubstring("  ERROR invalid job tcp-p2p: invalid default period -1s\n"))
+	})
+})
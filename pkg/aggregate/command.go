@@ -10,6 +10,7 @@ import (
 	"io"
 	"os"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -33,10 +34,13 @@ type aggrCommand struct {
 	jobFilter         string
 	srcFilter         string
 	destFilter        string
+	classes           []string
+	groupByClass      bool
 
 	jobFilterPattern  *regexp.Regexp
 	srcFilterPattern  *regexp.Regexp
 	destFilterPattern *regexp.Regexp
+	classFilter       map[nwpd.FailureClass]bool
 }
 
 type edge struct {
@@ -55,6 +59,13 @@ type results struct {
 	count            int
 	cumulativeDelta  int64
 	tachy            *tachymeter.Tachymeter
+	phases           []phaseData
+	failureClasses   map[string]int
+}
+
+type phaseData struct {
+	count              int
+	durationCumulative time.Duration
 }
 
 type bucketData struct {
@@ -85,6 +96,25 @@ func (r *results) incr(bucket int, ok bool, duration time.Duration) {
 	}
 }
 
+func (r *results) addPhaseTimings(timings *nwpd.PhaseTimings) {
+	if r.phases == nil {
+		r.phases = make([]phaseData, len(nwpd.PhaseNames))
+	}
+	for i, d := range timings.Durations() {
+		if d != nil {
+			r.phases[i].count++
+			r.phases[i].durationCumulative += d.AsDuration()
+		}
+	}
+}
+
+func (r *results) addFailureClass(class nwpd.FailureClass) {
+	if r.failureClasses == nil {
+		r.failureClasses = map[string]int{}
+	}
+	r.failureClasses[class.FailureLabel()]++
+}
+
 func CreateAggregateCmd() *cobra.Command {
 	ac := &aggrCommand{}
 	cmd := &cobra.Command{
@@ -103,6 +133,9 @@ func CreateAggregateCmd() *cobra.Command {
 	cmd.Flags().StringVar(&ac.jobFilter, "job", "", "filter observations by job id (use '*' for globbing)")
 	cmd.Flags().StringVar(&ac.srcFilter, "src", "", "filter observations by source (use '*' for globbing)")
 	cmd.Flags().StringVar(&ac.destFilter, "dest", "", "filter observations by destination (use '*' for globbing)")
+	cmd.Flags().StringSliceVar(&ac.classes, "class", nil,
+		fmt.Sprintf("filter failed observations by failure class (one of %s), successful observations are not filtered", strings.Join(nwpd.FailureClassLabels(), ", ")))
+	cmd.Flags().BoolVar(&ac.groupByClass, "group-by-class", false, "show the number of failed observations per failure class")
 	return cmd
 }
 
@@ -148,8 +181,15 @@ func (ac *aggrCommand) aggr(_ *cobra.Command, _ []string) error {
 	count := 0
 	var dataStartMillis, dataEndMillis int64
 
+	filter := db.RecordFilter{
+		Start: time.UnixMilli(startMillis),
+		End:   time.UnixMilli(endMillis),
+		Edge: func(jobID, srcHost, destHost string) bool {
+			return !filtered(srcHost, ac.srcFilterPattern) && !filtered(destHost, ac.destFilterPattern) && !filtered(jobID, ac.jobFilterPattern)
+		},
+	}
 	for _, filename := range filenames {
-		err := db.IterateRecordFile(filename, func(obs *nwpd.Observation) error {
+		err := db.IterateRecordFileFiltered(filename, filter, func(obs *nwpd.Observation) error {
 			timeMillis := obs.Timestamp.AsTime().UnixMilli()
 
 			if dataStartMillis == 0 || dataStartMillis > timeMillis {
@@ -172,6 +212,9 @@ func (ac *aggrCommand) aggr(_ *cobra.Command, _ []string) error {
 			if filtered(obs.JobID, ac.jobFilterPattern) {
 				return nil
 			}
+			if ac.classFilter != nil && !obs.Ok && !ac.classFilter[obs.FailureClass] {
+				return nil
+			}
 
 			edge := edge{
 				src:  obs.SrcHost,
@@ -207,8 +250,14 @@ func (ac *aggrCommand) aggr(_ *cobra.Command, _ []string) error {
 				if obs.Duration != nil {
 					jr.tachy.AddTime(obs.Duration.AsDuration())
 				}
+				if obs.PhaseTimings != nil {
+					jr.addPhaseTimings(obs.PhaseTimings)
+				}
 			} else {
 				jr.lastFailedMillis = timeMillis
+				if ac.groupByClass {
+					jr.addFailureClass(obs.FailureClass)
+				}
 			}
 			return nil
 		})
@@ -273,6 +322,9 @@ func (ac *aggrCommand) prepareFilterExpressions() error {
 	if ac.destFilterPattern, err = buildFilter(ac.destFilter); err != nil {
 		return err
 	}
+	if ac.classFilter, err = nwpd.ParseFailureClasses(ac.classes); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -301,6 +353,26 @@ func (ac *aggrCommand) printJobResultLine(src, dest string, jr *results) {
 			latence = fmt.Sprintf(" (period=%.1f s)", float64(jr.cumulativeDelta)/float64(jr.count)/1000)
 		}
 	}
+	if jr.phases != nil {
+		var names, means []string
+		for i, pd := range jr.phases {
+			if pd.count > 0 {
+				names = append(names, nwpd.PhaseNames[i])
+				means = append(means, fmt.Sprintf("%.1f", float64((pd.durationCumulative/time.Duration(pd.count)).Microseconds())/1000))
+			}
+		}
+		if len(names) > 0 {
+			latence += fmt.Sprintf(" (%s mean=%s ms)", strings.Join(names, "/"), strings.Join(means, "/"))
+		}
+	}
+	if len(jr.failureClasses) > 0 {
+		var classes []string
+		for class, count := range jr.failureClasses {
+			classes = append(classes, fmt.Sprintf("%s=%d", class, count))
+		}
+		sort.Strings(classes)
+		latence += fmt.Sprintf(" (failures: %s)", strings.Join(classes, ", "))
+	}
 	fmt.Printf("%s -> %s: %s%s\n", src, dest, sb.String(), latence)
 }
 
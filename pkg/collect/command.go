@@ -147,6 +147,14 @@ func (cc *collectCommand) loadFrom(log logrus.FieldLogger, dir string, pod *core
 		cc.failedNodes.Inc()
 		return
 	}
+	var indexes []string
+	for _, filename := range filenames {
+		// copy index files too, so that queries on the collected files are fast
+		if index := db.RecordIndexFilename(filename); fileExists(index) {
+			indexes = append(indexes, index)
+		}
+	}
+	filenames = append(filenames, indexes...)
 	countBytes := 0
 	countFiles := 0
 	for _, filename := range filenames {
@@ -189,6 +197,11 @@ func copyFile(srcFilename, destFilename string) (int64, error) {
 	return io.Copy(output, input)
 }
 
+func fileExists(filename string) bool {
+	_, err := os.Stat(filename)
+	return err == nil
+}
+
 func copyFileDates(srcFilename, destFilename string) error {
 	stat, err := os.Stat(srcFilename)
 	if err != nil {
@@ -11,8 +11,8 @@ import (
 	"os"
 	"path"
 	"path/filepath"
-	"strings"
 
+	"github.com/gardener/network-problem-detector/pkg/agent/db"
 	"github.com/gardener/network-problem-detector/pkg/common"
 
 	"github.com/spf13/cobra"
@@ -48,7 +48,7 @@ func (cc *runCollectCommand) listFiles(dir string) ([]string, error) {
 	}
 	var filenames []string
 	for _, file := range files {
-		if !file.IsDir() && strings.HasSuffix(file.Name(), ".records") {
+		if !file.IsDir() && (db.IsRecordFile(file.Name()) || db.IsRecordIndexFile(file.Name())) {
 			filenames = append(filenames, file.Name())
 		}
 	}
@@ -15,6 +15,11 @@ type AgentConfig struct {
 	OutputDir string `json:"outputDir,omitempty"`
 	// RetentionHours defines how many hours to keep old observations.
 	RetentionHours int `json:"retentionHours,omitempty"`
+	// MaxDiskBytes defines the maximum total size of the observation files. The oldest files are deleted if exceeded (0 means unlimited).
+	MaxDiskBytes int64 `json:"maxDiskBytes,omitempty"`
+	// MinFreeDiskPercent defines the minimum free space of the file system of the output directory in percent.
+	// The oldest observation files are deleted if the free space falls below (0 means unlimited).
+	MinFreeDiskPercent int `json:"minFreeDiskPercent,omitempty"`
 	// LogObservations defines if observations should be logged additionally (for debug purposes)
 	LogObservations bool `json:"logObservations"`
 	// K8sExporter defines configuration of the K8s exporter for writing node conditions and events
@@ -23,8 +28,13 @@ type AgentConfig struct {
 	AggregationReportPeriod *metav1.Duration `json:"aggregationReportPeriod,omitempty"`
 	// AggregationTimeWindow defines when an aggregation edge outdates if no new observations arrive
 	AggregationTimeWindow *metav1.Duration `json:"aggregationTimeWindow,omitempty"`
+	// MaxClockOffset defines the limit of the median clock offset of a node to its peers measured by `checkClockSkew` jobs.
+	// If exceeded, a clock skew is reported in the node condition (disabled if not set)
+	MaxClockOffset *metav1.Duration `json:"maxClockOffset,omitempty"`
 	// MaxPeerNodes defines the maximum number of nodes to check (0 means check all nodes)
 	MaxPeerNodes int `json:"maxPeerNodes,omitempty"`
+	// Scheduler defines the scheduling of the job runs.
+	Scheduler *SchedulerConfig `json:"scheduler,omitempty"`
 	// HostNetwork is the configuration specific for daemon set in node network
 	HostNetwork *NetworkConfig `json:"hostNetwork,omitempty"`
 	// PodNetwork is the configuration specific for daemon set in node network
@@ -43,20 +53,46 @@ func (c *AgentConfig) Clone() (*AgentConfig, error) {
 	return clone, nil
 }
 
+// SchedulerConfig defines the scheduling of the job runs.
+type SchedulerConfig struct {
+	// MaxConcurrentRuns is the maximum number of concurrent checks of all job runs and probes (default 16).
+	MaxConcurrentRuns int `json:"maxConcurrentRuns,omitempty"`
+	// JitterPercent is the maximum random deviation of the time between two runs of a job in percent of its period (default 5).
+	JitterPercent *int `json:"jitterPercent,omitempty"`
+}
+
 type NetworkConfig struct {
 	// DataFilePrefix is the prefix for observation data files.
 	DataFilePrefix string `json:"dataFilePrefix,omitempty"`
 	// HTTPPort is the port of the http server.
 	HTTPPort int `json:"httpPort,omitempty"`
+	// UDPEchoPort is the port of the UDP echo responder used by `checkUDPPort` jobs (disabled if 0).
+	UDPEchoPort int `json:"udpEchoPort,omitempty"`
 	// Jobs are the jobs to execute.
 	Jobs []Job `json:"jobs,omitempty"`
 	// DefaultPeriod is the period used for a new job if it doesn't specify the period.
 	DefaultPeriod metav1.Duration `json:"defaultPeriod,omitempty"`
 }
 
+const (
+	// ExpectReachable is the default expectation of a job: a check succeeds if the destination is reachable.
+	ExpectReachable = "reachable"
+	// ExpectBlocked inverts the success semantics of a job: a check succeeds if the destination is blocked, e.g. by a network policy.
+	ExpectBlocked = "blocked"
+)
+
 type Job struct {
 	JobID string   `json:"jobID"`
 	Args  []string `json:"args,omitempty"`
+	// Spec is the typed definition of the job as alternative to Args.
+	Spec *JobSpec `json:"spec,omitempty"`
+	// Expect is the expected outcome of the checks, either `reachable` (default) or `blocked`.
+	Expect string `json:"expect,omitempty"`
+}
+
+// ExpectsBlocked returns true if the checks of the job are negative checks.
+func (j Job) ExpectsBlocked() bool {
+	return j.Expect == ExpectBlocked
 }
 
 type K8sExporterConfig struct {
@@ -4,11 +4,15 @@
 
 package config
 
+import "strings"
+
 type WithDestHost interface {
 	DestHost() string
 }
 
 type Node struct {
+	// Name is the name of the node object, which may differ from the hostname.
+	Name          string   `json:"name,omitempty"`
 	Hostname      string   `json:"hostname"`
 	InternalIPs   []string `json:"internalIPs"`
 	InternalIPsV6 []string `json:"internalIPsV6"`
@@ -39,6 +43,14 @@ func (e Endpoint) DestHost() string {
 	return e.Hostname
 }
 
+// DialHost returns the IP address or the hostname if the IP address is not specified.
+func (e Endpoint) DialHost() string {
+	if e.IP == "" {
+		return strings.TrimSuffix(e.Hostname, ".")
+	}
+	return e.IP
+}
+
 type ClusterConfig struct {
 	// NodeCount is the number known nodes (not anly the subset used as destinations)
 	NodeCount int
@@ -52,4 +64,8 @@ type ClusterConfig struct {
 	InternalKubeAPIServer *Endpoint `json:"internalKubeAPIServer,omitempty"`
 	// KubeAPIServer is the discovered external address of the kube-apiserver (relies on Gardener shoot-info)
 	KubeAPIServer *Endpoint `json:"kubeAPIServer,omitempty"`
+	// KubeDNSService is the discovered cluster IP address of the kube-dns service
+	KubeDNSService *Endpoint `json:"kubeDNSService,omitempty"`
+	// KubeDNSPods is the subset of the known pods backing the kube-dns service.
+	KubeDNSPods []PodEndpoint `json:"kubeDNSPods,omitempty"`
 }
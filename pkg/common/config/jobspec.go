@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// JobSpec is the typed definition of a job as alternative to the command line style `Args` of a job.
+// It is decoded strictly, i.e. unknown fields are rejected.
+type JobSpec struct {
+	// Type is the job type, e.g. `checkTCPPort`.
+	Type string `json:"type"`
+	// Targets selects the destinations of the checks.
+	Targets *JobTargets `json:"targets,omitempty"`
+	// Params are the parameters specific for the job type. The names are the flag names of the job type without the leading dashes.
+	Params map[string]any `json:"params,omitempty"`
+	// Period overwrites the default execution period.
+	Period *metav1.Duration `json:"period,omitempty"`
+	// ScalePeriod if true, the period is scaled by the number of nodes.
+	ScalePeriod bool `json:"scalePeriod,omitempty"`
+	// Timeout is the timeout of a single check attempt (default depends on job type).
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// Labels are additional labels describing the job.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// JobTargets selects the destinations of the checks. Each job type supports only a subset of the selectors.
+type JobTargets struct {
+	// Endpoints are explicit endpoints, typically in format `<hostname>:<ip>:<port>`.
+	Endpoints []string `json:"endpoints,omitempty"`
+	// Hosts are explicit hosts in format `<hostname>:<ip>`.
+	Hosts []string `json:"hosts,omitempty"`
+	// Names are DNS names to look up.
+	Names []string `json:"names,omitempty"`
+	// Servers are explicit DNS servers in format `<hostname>:<ip>[:<port>]`.
+	Servers []string `json:"servers,omitempty"`
+	// NodePort is a port on the internal IP addresses of the nodes.
+	NodePort int `json:"nodePort,omitempty"`
+	// NodePortIPv6 is a port on the internal IPv6 addresses of the nodes.
+	NodePortIPv6 int `json:"nodePortIPv6,omitempty"`
+	// PodDaemonSet selects the known pod endpoints of the agent daemon set on the pod network.
+	PodDaemonSet bool `json:"podDaemonSet,omitempty"`
+	// PodDaemonSetIPv6 selects the known pod IPv6 endpoints of the agent daemon set on the pod network.
+	PodDaemonSetIPv6 bool `json:"podDaemonSetIPv6,omitempty"`
+	// InternalKubeAPIServer selects the internal endpoint or DNS name of the kube-apiserver.
+	InternalKubeAPIServer bool `json:"internalKubeAPIServer,omitempty"`
+	// ExternalKubeAPIServer selects the external endpoint or DNS name of the kube-apiserver.
+	ExternalKubeAPIServer bool `json:"externalKubeAPIServer,omitempty"`
+	// KubeDNSService selects the cluster IP of the kube-dns service.
+	KubeDNSService bool `json:"kubeDNSService,omitempty"`
+	// KubeDNSPods selects the pod endpoints of the kube-dns service.
+	KubeDNSPods bool `json:"kubeDNSPods,omitempty"`
+}
+
+// UnmarshalJSON decodes the job spec strictly.
+func (s *JobSpec) UnmarshalJSON(data []byte) error {
+	type plain JobSpec
+	var p plain
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&p); err != nil {
+		return fmt.Errorf("invalid job spec: %w", err)
+	}
+	*s = JobSpec(p)
+	return nil
+}
@@ -84,6 +84,8 @@ func (sc *SampleConfig) ShuffledSample(cc ClusterConfig) ClusterConfig {
 		PodEndpointsV6:        CloneAndShuffle(selectSample(sc, cc.PodEndpointsV6)),
 		InternalKubeAPIServer: cc.InternalKubeAPIServer,
 		KubeAPIServer:         cc.KubeAPIServer,
+		KubeDNSService:        cc.KubeDNSService,
+		KubeDNSPods:           CloneAndShuffle(cc.KubeDNSPods),
 	}
 }
 
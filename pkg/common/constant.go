@@ -15,6 +15,8 @@ const (
 	DomainNameKubernetesService = "kubernetes.default.svc.cluster.local."
 	// NameKubeDNSService is the name of the kube-dns service.
 	NameKubeDNSService = "kube-dns"
+	// DomainNameKubeDNSService is the kube-dns service domain name.
+	DomainNameKubeDNSService = "kube-dns.kube-system.svc.cluster.local."
 	// NameGardenerShootInfo is the name of the shoot info config map from Gardener.
 	NameGardenerShootInfo = "shoot-info"
 	// AgentConfigFilename is the name of the config file.
@@ -27,6 +29,10 @@ const (
 	EnvNodeIP = "NODE_IP"
 	// EnvPodIP is the env variable to get the pod ip in an agent pod.
 	EnvPodIP = "POD_IP"
+	// EnvPodIPs is the env variable to get the comma separated pod ips (IPv4 and IPv6) in an agent pod.
+	EnvPodIPs = "POD_IPS"
+	// EnvPodName is the env variable to get the pod name in an agent pod.
+	EnvPodName = "POD_NAME"
 	// LabelKeyK8sApp is the label key used to mark the pods.
 	LabelKeyK8sApp = "k8s-app"
 	// ApplicationName is the application name.
@@ -51,4 +57,12 @@ const (
 	PodNetPodHTTPPort = 8881
 	// HostNetPodHTTPPort is the port used for the metrics http server of the pods running in the host network.
 	HostNetPodHTTPPort = 12996
+	// PathStreamObservations is the path of the http server of the agents streaming new observations as server-sent events.
+	PathStreamObservations = "/stream/observations"
+	// NameNetworkPolicyCanary name of the deployment, service and network policy of the canary pod for negative checks.
+	NameNetworkPolicyCanary = ApplicationName + "-policy-canary"
+	// DomainNameNetworkPolicyCanaryService is the domain name of the service of the canary pod.
+	DomainNameNetworkPolicyCanaryService = NameNetworkPolicyCanary + "." + NamespaceKubeSystem + ".svc.cluster.local."
+	// NetworkPolicyCanaryPort is the TCP and UDP port of the canary pod.
+	NetworkPolicyCanaryPort = 8882
 )
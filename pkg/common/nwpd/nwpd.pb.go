@@ -28,6 +28,81 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// FailureClass classifies the cause of a failed check.
+// Observations persisted by older versions have no failure class.
+type FailureClass int32
+
+const (
+	FailureClass_FAILURE_CLASS_UNSPECIFIED        FailureClass = 0 // successful check or failure not classified
+	FailureClass_FAILURE_CLASS_OTHER              FailureClass = 1 // failure without a more specific class
+	FailureClass_FAILURE_CLASS_TIMEOUT            FailureClass = 2 // timeout of the check, e.g. connect timeout or lost ping packets
+	FailureClass_FAILURE_CLASS_CONNECTION_REFUSED FailureClass = 3
+	FailureClass_FAILURE_CLASS_CONNECTION_RESET   FailureClass = 4
+	FailureClass_FAILURE_CLASS_UNREACHABLE        FailureClass = 5  // no route to the host or network
+	FailureClass_FAILURE_CLASS_DNS_NOT_FOUND      FailureClass = 6  // name does not exist (NXDOMAIN)
+	FailureClass_FAILURE_CLASS_DNS                FailureClass = 7  // name resolution failed for other reasons
+	FailureClass_FAILURE_CLASS_TLS                FailureClass = 8  // TLS handshake or certificate validation failed
+	FailureClass_FAILURE_CLASS_HTTP_STATUS        FailureClass = 9  // unexpected HTTP status or response body
+	FailureClass_FAILURE_CLASS_NOT_BLOCKED        FailureClass = 10 // destination of a negative check is not blocked
+)
+
+// Enum value maps for FailureClass.
+var (
+	FailureClass_name = map[int32]string{
+		0:  "FAILURE_CLASS_UNSPECIFIED",
+		1:  "FAILURE_CLASS_OTHER",
+		2:  "FAILURE_CLASS_TIMEOUT",
+		3:  "FAILURE_CLASS_CONNECTION_REFUSED",
+		4:  "FAILURE_CLASS_CONNECTION_RESET",
+		5:  "FAILURE_CLASS_UNREACHABLE",
+		6:  "FAILURE_CLASS_DNS_NOT_FOUND",
+		7:  "FAILURE_CLASS_DNS",
+		8:  "FAILURE_CLASS_TLS",
+		9:  "FAILURE_CLASS_HTTP_STATUS",
+		10: "FAILURE_CLASS_NOT_BLOCKED",
+	}
+	FailureClass_value = map[string]int32{
+		"FAILURE_CLASS_UNSPECIFIED":        0,
+		"FAILURE_CLASS_OTHER":              1,
+		"FAILURE_CLASS_TIMEOUT":            2,
+		"FAILURE_CLASS_CONNECTION_REFUSED": 3,
+		"FAILURE_CLASS_CONNECTION_RESET":   4,
+		"FAILURE_CLASS_UNREACHABLE":        5,
+		"FAILURE_CLASS_DNS_NOT_FOUND":      6,
+		"FAILURE_CLASS_DNS":                7,
+		"FAILURE_CLASS_TLS":                8,
+		"FAILURE_CLASS_HTTP_STATUS":        9,
+		"FAILURE_CLASS_NOT_BLOCKED":        10,
+	}
+)
+
+func (x FailureClass) Enum() *FailureClass {
+	p := new(FailureClass)
+	*p = x
+	return p
+}
+
+func (x FailureClass) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FailureClass) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_common_nwpd_nwpd_proto_enumTypes[0].Descriptor()
+}
+
+func (FailureClass) Type() protoreflect.EnumType {
+	return &file_pkg_common_nwpd_nwpd_proto_enumTypes[0]
+}
+
+func (x FailureClass) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FailureClass.Descriptor instead.
+func (FailureClass) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_common_nwpd_nwpd_proto_rawDescGZIP(), []int{0}
+}
+
 type GetObservationsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -178,16 +253,19 @@ func (x *GetObservationsResponse) GetObservations() []*Observation {
 	return nil
 }
 
-type GetAggregatedObservationsResponse struct {
+// RunProbeRequest triggers an on-demand check.
+type RunProbeRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	AggregatedObservations []*AggregatedObservation `protobuf:"bytes,1,rep,name=aggregatedObservations,proto3" json:"aggregatedObservations,omitempty"`
+	Args                []string             `protobuf:"bytes,1,rep,name=args,proto3" json:"args,omitempty"` // runner args in the same syntax as the args of a job
+	RestrictToDestHosts []string             `protobuf:"bytes,2,rep,name=restrictToDestHosts,proto3" json:"restrictToDestHosts,omitempty"`
+	Timeout             *durationpb.Duration `protobuf:"bytes,3,opt,name=timeout,proto3" json:"timeout,omitempty"` // overall timeout of the probe
 }
 
-func (x *GetAggregatedObservationsResponse) Reset() {
-	*x = GetAggregatedObservationsResponse{}
+func (x *RunProbeRequest) Reset() {
+	*x = RunProbeRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_common_nwpd_nwpd_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -195,13 +273,13 @@ func (x *GetAggregatedObservationsResponse) Reset() {
 	}
 }
 
-func (x *GetAggregatedObservationsResponse) String() string {
+func (x *RunProbeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAggregatedObservationsResponse) ProtoMessage() {}
+func (*RunProbeRequest) ProtoMessage() {}
 
-func (x *GetAggregatedObservationsResponse) ProtoReflect() protoreflect.Message {
+func (x *RunProbeRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_common_nwpd_nwpd_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -213,34 +291,43 @@ func (x *GetAggregatedObservationsResponse) ProtoReflect() protoreflect.Message
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAggregatedObservationsResponse.ProtoReflect.Descriptor instead.
-func (*GetAggregatedObservationsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use RunProbeRequest.ProtoReflect.Descriptor instead.
+func (*RunProbeRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_common_nwpd_nwpd_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *GetAggregatedObservationsResponse) GetAggregatedObservations() []*AggregatedObservation {
+func (x *RunProbeRequest) GetArgs() []string {
 	if x != nil {
-		return x.AggregatedObservations
+		return x.Args
 	}
 	return nil
 }
 
-type AggregatedObservation struct {
+func (x *RunProbeRequest) GetRestrictToDestHosts() []string {
+	if x != nil {
+		return x.RestrictToDestHosts
+	}
+	return nil
+}
+
+func (x *RunProbeRequest) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+type RunProbeResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SrcHost        string                          `protobuf:"bytes,1,opt,name=srcHost,proto3" json:"srcHost,omitempty"`
-	DestHost       string                          `protobuf:"bytes,2,opt,name=destHost,proto3" json:"destHost,omitempty"`
-	PeriodStart    *timestamppb.Timestamp          `protobuf:"bytes,3,opt,name=periodStart,proto3" json:"periodStart,omitempty"`
-	PeriodEnd      *timestamppb.Timestamp          `protobuf:"bytes,4,opt,name=periodEnd,proto3" json:"periodEnd,omitempty"`
-	JobsOkCount    map[string]int32                `protobuf:"bytes,5,rep,name=jobsOkCount,proto3" json:"jobsOkCount,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
-	JobsNotOkCount map[string]int32                `protobuf:"bytes,6,rep,name=jobsNotOkCount,proto3" json:"jobsNotOkCount,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
-	MeanOkDuration map[string]*durationpb.Duration `protobuf:"bytes,7,rep,name=meanOkDuration,proto3" json:"meanOkDuration,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Observations  []*Observation `protobuf:"bytes,1,rep,name=observations,proto3" json:"observations,omitempty"`
+	PendingChecks int32          `protobuf:"varint,2,opt,name=pendingChecks,proto3" json:"pendingChecks,omitempty"` // number of checks not finished before the timeout
 }
 
-func (x *AggregatedObservation) Reset() {
-	*x = AggregatedObservation{}
+func (x *RunProbeResponse) Reset() {
+	*x = RunProbeResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_common_nwpd_nwpd_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -248,13 +335,13 @@ func (x *AggregatedObservation) Reset() {
 	}
 }
 
-func (x *AggregatedObservation) String() string {
+func (x *RunProbeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AggregatedObservation) ProtoMessage() {}
+func (*RunProbeResponse) ProtoMessage() {}
 
-func (x *AggregatedObservation) ProtoReflect() protoreflect.Message {
+func (x *RunProbeResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_common_nwpd_nwpd_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -266,92 +353,95 @@ func (x *AggregatedObservation) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AggregatedObservation.ProtoReflect.Descriptor instead.
-func (*AggregatedObservation) Descriptor() ([]byte, []int) {
+// Deprecated: Use RunProbeResponse.ProtoReflect.Descriptor instead.
+func (*RunProbeResponse) Descriptor() ([]byte, []int) {
 	return file_pkg_common_nwpd_nwpd_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *AggregatedObservation) GetSrcHost() string {
+func (x *RunProbeResponse) GetObservations() []*Observation {
 	if x != nil {
-		return x.SrcHost
+		return x.Observations
 	}
-	return ""
+	return nil
 }
 
-func (x *AggregatedObservation) GetDestHost() string {
+func (x *RunProbeResponse) GetPendingChecks() int32 {
 	if x != nil {
-		return x.DestHost
+		return x.PendingChecks
 	}
-	return ""
+	return 0
 }
 
-func (x *AggregatedObservation) GetPeriodStart() *timestamppb.Timestamp {
-	if x != nil {
-		return x.PeriodStart
-	}
-	return nil
+type GetStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 }
 
-func (x *AggregatedObservation) GetPeriodEnd() *timestamppb.Timestamp {
-	if x != nil {
-		return x.PeriodEnd
+func (x *GetStatusRequest) Reset() {
+	*x = GetStatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_common_nwpd_nwpd_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return nil
 }
 
-func (x *AggregatedObservation) GetJobsOkCount() map[string]int32 {
-	if x != nil {
-		return x.JobsOkCount
-	}
-	return nil
+func (x *GetStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *AggregatedObservation) GetJobsNotOkCount() map[string]int32 {
-	if x != nil {
-		return x.JobsNotOkCount
+func (*GetStatusRequest) ProtoMessage() {}
+
+func (x *GetStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_common_nwpd_nwpd_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *AggregatedObservation) GetMeanOkDuration() map[string]*durationpb.Duration {
-	if x != nil {
-		return x.MeanOkDuration
-	}
-	return nil
+// Deprecated: Use GetStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetStatusRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_common_nwpd_nwpd_proto_rawDescGZIP(), []int{4}
 }
 
-type Observation struct {
+// GetStatusResponse describes the current state of the agent.
+type GetStatusResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	JobID     string                 `protobuf:"bytes,1,opt,name=jobID,proto3" json:"jobID,omitempty"`
-	SrcHost   string                 `protobuf:"bytes,2,opt,name=srcHost,proto3" json:"srcHost,omitempty"`
-	DestHost  string                 `protobuf:"bytes,3,opt,name=destHost,proto3" json:"destHost,omitempty"`
-	Timestamp *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	Duration  *durationpb.Duration   `protobuf:"bytes,5,opt,name=duration,proto3" json:"duration,omitempty"`
-	Result    string                 `protobuf:"bytes,6,opt,name=result,proto3" json:"result,omitempty"` // not persisted
-	Ok        bool                   `protobuf:"varint,7,opt,name=ok,proto3" json:"ok,omitempty"`
-	Period    *durationpb.Duration   `protobuf:"bytes,8,opt,name=period,proto3" json:"period,omitempty"`
+	Version          string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	NodeName         string                 `protobuf:"bytes,2,opt,name=nodeName,proto3" json:"nodeName,omitempty"`
+	HostNetwork      bool                   `protobuf:"varint,3,opt,name=hostNetwork,proto3" json:"hostNetwork,omitempty"`
+	ConfigGeneration int64                  `protobuf:"varint,4,opt,name=configGeneration,proto3" json:"configGeneration,omitempty"` // incremented on each applied configuration change
+	ConfigApplied    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=configApplied,proto3" json:"configApplied,omitempty"`
+	Jobs             []*JobStatus           `protobuf:"bytes,6,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	ValidEdges       *ValidEdges            `protobuf:"bytes,7,opt,name=validEdges,proto3" json:"validEdges,omitempty"`
 }
 
-func (x *Observation) Reset() {
-	*x = Observation{}
+func (x *GetStatusResponse) Reset() {
+	*x = GetStatusResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_common_nwpd_nwpd_proto_msgTypes[4]
+		mi := &file_pkg_common_nwpd_nwpd_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Observation) String() string {
+func (x *GetStatusResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Observation) ProtoMessage() {}
+func (*GetStatusResponse) ProtoMessage() {}
 
-func (x *Observation) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_common_nwpd_nwpd_proto_msgTypes[4]
+func (x *GetStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_common_nwpd_nwpd_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -362,98 +452,94 @@ func (x *Observation) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Observation.ProtoReflect.Descriptor instead.
-func (*Observation) Descriptor() ([]byte, []int) {
-	return file_pkg_common_nwpd_nwpd_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use GetStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetStatusResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_common_nwpd_nwpd_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *Observation) GetJobID() string {
+func (x *GetStatusResponse) GetVersion() string {
 	if x != nil {
-		return x.JobID
+		return x.Version
 	}
 	return ""
 }
 
-func (x *Observation) GetSrcHost() string {
+func (x *GetStatusResponse) GetNodeName() string {
 	if x != nil {
-		return x.SrcHost
+		return x.NodeName
 	}
 	return ""
 }
 
-func (x *Observation) GetDestHost() string {
+func (x *GetStatusResponse) GetHostNetwork() bool {
 	if x != nil {
-		return x.DestHost
+		return x.HostNetwork
 	}
-	return ""
+	return false
 }
 
-func (x *Observation) GetTimestamp() *timestamppb.Timestamp {
+func (x *GetStatusResponse) GetConfigGeneration() int64 {
 	if x != nil {
-		return x.Timestamp
+		return x.ConfigGeneration
 	}
-	return nil
+	return 0
 }
 
-func (x *Observation) GetDuration() *durationpb.Duration {
+func (x *GetStatusResponse) GetConfigApplied() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Duration
+		return x.ConfigApplied
 	}
 	return nil
 }
 
-func (x *Observation) GetResult() string {
-	if x != nil {
-		return x.Result
-	}
-	return ""
-}
-
-func (x *Observation) GetOk() bool {
+func (x *GetStatusResponse) GetJobs() []*JobStatus {
 	if x != nil {
-		return x.Ok
+		return x.Jobs
 	}
-	return false
+	return nil
 }
 
-func (x *Observation) GetPeriod() *durationpb.Duration {
+func (x *GetStatusResponse) GetValidEdges() *ValidEdges {
 	if x != nil {
-		return x.Period
+		return x.ValidEdges
 	}
 	return nil
 }
 
-type IntObservation struct {
+type JobStatus struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	JobID          int64 `protobuf:"varint,1,opt,name=JobID,proto3" json:"JobID,omitempty"`
-	SrcHost        int64 `protobuf:"varint,2,opt,name=srcHost,proto3" json:"srcHost,omitempty"`
-	DestHost       int64 `protobuf:"varint,3,opt,name=destHost,proto3" json:"destHost,omitempty"`
-	TimeMillis     int64 `protobuf:"varint,4,opt,name=timeMillis,proto3" json:"timeMillis,omitempty"`
-	DurationMillis int32 `protobuf:"varint,5,opt,name=durationMillis,proto3" json:"durationMillis,omitempty"`
-	Ok             bool  `protobuf:"varint,6,opt,name=ok,proto3" json:"ok,omitempty"`
-	PeriodMillis   int32 `protobuf:"varint,7,opt,name=periodMillis,proto3" json:"periodMillis,omitempty"`
+	JobID         string                 `protobuf:"bytes,1,opt,name=jobID,proto3" json:"jobID,omitempty"`
+	Args          []string               `protobuf:"bytes,2,rep,name=args,proto3" json:"args,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	DestHosts     []string               `protobuf:"bytes,4,rep,name=destHosts,proto3" json:"destHosts,omitempty"`
+	Period        *durationpb.Duration   `protobuf:"bytes,5,opt,name=period,proto3" json:"period,omitempty"`
+	LastRun       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=lastRun,proto3" json:"lastRun,omitempty"`
+	Active        bool                   `protobuf:"varint,7,opt,name=active,proto3" json:"active,omitempty"` // if a run is currently in progress
+	PeerNodeCount int32                  `protobuf:"varint,8,opt,name=peerNodeCount,proto3" json:"peerNodeCount,omitempty"`
+	LastResults   []*Observation         `protobuf:"bytes,9,rep,name=lastResults,proto3" json:"lastResults,omitempty"`                                                                                // last observation per destination host
+	Labels        map[string]string      `protobuf:"bytes,10,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // labels of the job spec
 }
 
-func (x *IntObservation) Reset() {
-	*x = IntObservation{}
+func (x *JobStatus) Reset() {
+	*x = JobStatus{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_common_nwpd_nwpd_proto_msgTypes[5]
+		mi := &file_pkg_common_nwpd_nwpd_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *IntObservation) String() string {
+func (x *JobStatus) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IntObservation) ProtoMessage() {}
+func (*JobStatus) ProtoMessage() {}
 
-func (x *IntObservation) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_common_nwpd_nwpd_proto_msgTypes[5]
+func (x *JobStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_common_nwpd_nwpd_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -464,85 +550,110 @@ func (x *IntObservation) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IntObservation.ProtoReflect.Descriptor instead.
-func (*IntObservation) Descriptor() ([]byte, []int) {
-	return file_pkg_common_nwpd_nwpd_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use JobStatus.ProtoReflect.Descriptor instead.
+func (*JobStatus) Descriptor() ([]byte, []int) {
+	return file_pkg_common_nwpd_nwpd_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *IntObservation) GetJobID() int64 {
+func (x *JobStatus) GetJobID() string {
 	if x != nil {
 		return x.JobID
 	}
-	return 0
+	return ""
 }
 
-func (x *IntObservation) GetSrcHost() int64 {
+func (x *JobStatus) GetArgs() []string {
 	if x != nil {
-		return x.SrcHost
+		return x.Args
 	}
-	return 0
+	return nil
 }
 
-func (x *IntObservation) GetDestHost() int64 {
+func (x *JobStatus) GetDescription() string {
 	if x != nil {
-		return x.DestHost
+		return x.Description
 	}
-	return 0
+	return ""
 }
 
-func (x *IntObservation) GetTimeMillis() int64 {
+func (x *JobStatus) GetDestHosts() []string {
 	if x != nil {
-		return x.TimeMillis
+		return x.DestHosts
 	}
-	return 0
+	return nil
 }
 
-func (x *IntObservation) GetDurationMillis() int32 {
+func (x *JobStatus) GetPeriod() *durationpb.Duration {
 	if x != nil {
-		return x.DurationMillis
+		return x.Period
 	}
-	return 0
+	return nil
 }
 
-func (x *IntObservation) GetOk() bool {
+func (x *JobStatus) GetLastRun() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Ok
+		return x.LastRun
+	}
+	return nil
+}
+
+func (x *JobStatus) GetActive() bool {
+	if x != nil {
+		return x.Active
 	}
 	return false
 }
 
-func (x *IntObservation) GetPeriodMillis() int32 {
+func (x *JobStatus) GetPeerNodeCount() int32 {
 	if x != nil {
-		return x.PeriodMillis
+		return x.PeerNodeCount
 	}
 	return 0
 }
 
-type Int64Arrays struct {
+func (x *JobStatus) GetLastResults() []*Observation {
+	if x != nil {
+		return x.LastResults
+	}
+	return nil
+}
+
+func (x *JobStatus) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+// ValidEdges are the job IDs, source and destination hosts considered by the aggregator for the node conditions.
+type ValidEdges struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Array []int64 `protobuf:"varint,1,rep,packed,name=array,proto3" json:"array,omitempty"`
+	JobIDs        []string `protobuf:"bytes,1,rep,name=jobIDs,proto3" json:"jobIDs,omitempty"`
+	SrcHosts      []string `protobuf:"bytes,2,rep,name=srcHosts,proto3" json:"srcHosts,omitempty"`
+	DestHosts     []string `protobuf:"bytes,3,rep,name=destHosts,proto3" json:"destHosts,omitempty"`
+	PeerNodeCount int32    `protobuf:"varint,4,opt,name=peerNodeCount,proto3" json:"peerNodeCount,omitempty"`
 }
 
-func (x *Int64Arrays) Reset() {
-	*x = Int64Arrays{}
+func (x *ValidEdges) Reset() {
+	*x = ValidEdges{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_common_nwpd_nwpd_proto_msgTypes[6]
+		mi := &file_pkg_common_nwpd_nwpd_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Int64Arrays) String() string {
+func (x *ValidEdges) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Int64Arrays) ProtoMessage() {}
+func (*ValidEdges) ProtoMessage() {}
 
-func (x *Int64Arrays) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_common_nwpd_nwpd_proto_msgTypes[6]
+func (x *ValidEdges) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_common_nwpd_nwpd_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -553,44 +664,64 @@ func (x *Int64Arrays) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Int64Arrays.ProtoReflect.Descriptor instead.
-func (*Int64Arrays) Descriptor() ([]byte, []int) {
-	return file_pkg_common_nwpd_nwpd_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use ValidEdges.ProtoReflect.Descriptor instead.
+func (*ValidEdges) Descriptor() ([]byte, []int) {
+	return file_pkg_common_nwpd_nwpd_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *Int64Arrays) GetArray() []int64 {
+func (x *ValidEdges) GetJobIDs() []string {
 	if x != nil {
-		return x.Array
+		return x.JobIDs
 	}
 	return nil
 }
 
-type IntString struct {
+func (x *ValidEdges) GetSrcHosts() []string {
+	if x != nil {
+		return x.SrcHosts
+	}
+	return nil
+}
+
+func (x *ValidEdges) GetDestHosts() []string {
+	if x != nil {
+		return x.DestHosts
+	}
+	return nil
+}
+
+func (x *ValidEdges) GetPeerNodeCount() int32 {
+	if x != nil {
+		return x.PeerNodeCount
+	}
+	return 0
+}
+
+type GetAggregatedObservationsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Key   int64  `protobuf:"varint,1,opt,name=key,proto3" json:"key,omitempty"`
-	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	AggregatedObservations []*AggregatedObservation `protobuf:"bytes,1,rep,name=aggregatedObservations,proto3" json:"aggregatedObservations,omitempty"`
 }
 
-func (x *IntString) Reset() {
-	*x = IntString{}
+func (x *GetAggregatedObservationsResponse) Reset() {
+	*x = GetAggregatedObservationsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_common_nwpd_nwpd_proto_msgTypes[7]
+		mi := &file_pkg_common_nwpd_nwpd_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *IntString) String() string {
+func (x *GetAggregatedObservationsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IntString) ProtoMessage() {}
+func (*GetAggregatedObservationsResponse) ProtoMessage() {}
 
-func (x *IntString) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_common_nwpd_nwpd_proto_msgTypes[7]
+func (x *GetAggregatedObservationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_common_nwpd_nwpd_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -601,23 +732,1109 @@ func (x *IntString) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IntString.ProtoReflect.Descriptor instead.
-func (*IntString) Descriptor() ([]byte, []int) {
-	return file_pkg_common_nwpd_nwpd_proto_rawDescGZIP(), []int{7}
-}
+// Deprecated: Use GetAggregatedObservationsResponse.ProtoReflect.Descriptor instead.
+func (*GetAggregatedObservationsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_common_nwpd_nwpd_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetAggregatedObservationsResponse) GetAggregatedObservations() []*AggregatedObservation {
+	if x != nil {
+		return x.AggregatedObservations
+	}
+	return nil
+}
+
+type AggregatedObservation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SrcHost        string                          `protobuf:"bytes,1,opt,name=srcHost,proto3" json:"srcHost,omitempty"`
+	DestHost       string                          `protobuf:"bytes,2,opt,name=destHost,proto3" json:"destHost,omitempty"`
+	PeriodStart    *timestamppb.Timestamp          `protobuf:"bytes,3,opt,name=periodStart,proto3" json:"periodStart,omitempty"`
+	PeriodEnd      *timestamppb.Timestamp          `protobuf:"bytes,4,opt,name=periodEnd,proto3" json:"periodEnd,omitempty"`
+	JobsOkCount    map[string]int32                `protobuf:"bytes,5,rep,name=jobsOkCount,proto3" json:"jobsOkCount,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	JobsNotOkCount map[string]int32                `protobuf:"bytes,6,rep,name=jobsNotOkCount,proto3" json:"jobsNotOkCount,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	MeanOkDuration map[string]*durationpb.Duration `protobuf:"bytes,7,rep,name=meanOkDuration,proto3" json:"meanOkDuration,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *AggregatedObservation) Reset() {
+	*x = AggregatedObservation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_common_nwpd_nwpd_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AggregatedObservation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AggregatedObservation) ProtoMessage() {}
+
+func (x *AggregatedObservation) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_common_nwpd_nwpd_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AggregatedObservation.ProtoReflect.Descriptor instead.
+func (*AggregatedObservation) Descriptor() ([]byte, []int) {
+	return file_pkg_common_nwpd_nwpd_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *AggregatedObservation) GetSrcHost() string {
+	if x != nil {
+		return x.SrcHost
+	}
+	return ""
+}
+
+func (x *AggregatedObservation) GetDestHost() string {
+	if x != nil {
+		return x.DestHost
+	}
+	return ""
+}
+
+func (x *AggregatedObservation) GetPeriodStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PeriodStart
+	}
+	return nil
+}
+
+func (x *AggregatedObservation) GetPeriodEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PeriodEnd
+	}
+	return nil
+}
+
+func (x *AggregatedObservation) GetJobsOkCount() map[string]int32 {
+	if x != nil {
+		return x.JobsOkCount
+	}
+	return nil
+}
+
+func (x *AggregatedObservation) GetJobsNotOkCount() map[string]int32 {
+	if x != nil {
+		return x.JobsNotOkCount
+	}
+	return nil
+}
+
+func (x *AggregatedObservation) GetMeanOkDuration() map[string]*durationpb.Duration {
+	if x != nil {
+		return x.MeanOkDuration
+	}
+	return nil
+}
+
+type Observation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	JobID                 string                 `protobuf:"bytes,1,opt,name=jobID,proto3" json:"jobID,omitempty"`
+	SrcHost               string                 `protobuf:"bytes,2,opt,name=srcHost,proto3" json:"srcHost,omitempty"`
+	DestHost              string                 `protobuf:"bytes,3,opt,name=destHost,proto3" json:"destHost,omitempty"`
+	Timestamp             *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Duration              *durationpb.Duration   `protobuf:"bytes,5,opt,name=duration,proto3" json:"duration,omitempty"`
+	Result                string                 `protobuf:"bytes,6,opt,name=result,proto3" json:"result,omitempty"` // not persisted
+	Ok                    bool                   `protobuf:"varint,7,opt,name=ok,proto3" json:"ok,omitempty"`
+	Period                *durationpb.Duration   `protobuf:"bytes,8,opt,name=period,proto3" json:"period,omitempty"`
+	PathMTU               int32                  `protobuf:"varint,9,opt,name=pathMTU,proto3" json:"pathMTU,omitempty"` // not persisted
+	PhaseTimings          *PhaseTimings          `protobuf:"bytes,10,opt,name=phaseTimings,proto3" json:"phaseTimings,omitempty"`
+	CertRemainingLifetime *durationpb.Duration   `protobuf:"bytes,11,opt,name=certRemainingLifetime,proto3" json:"certRemainingLifetime,omitempty"`       // not persisted
+	ClockOffset           *durationpb.Duration   `protobuf:"bytes,12,opt,name=clockOffset,proto3" json:"clockOffset,omitempty"`                           // not persisted
+	Throughput            float64                `protobuf:"fixed64,13,opt,name=throughput,proto3" json:"throughput,omitempty"`                           // bytes per second, not persisted
+	Attempts              int32                  `protobuf:"varint,14,opt,name=attempts,proto3" json:"attempts,omitempty"`                                // number of attempts if the check was retried, 0 or 1 otherwise
+	ExpectBlocked         bool                   `protobuf:"varint,15,opt,name=expectBlocked,proto3" json:"expectBlocked,omitempty"`                      // if the check is a negative check expecting the destination to be blocked
+	AdHoc                 bool                   `protobuf:"varint,16,opt,name=adHoc,proto3" json:"adHoc,omitempty"`                                      // if the observation is the result of an on-demand probe, not persisted
+	FailureClass          FailureClass           `protobuf:"varint,17,opt,name=failureClass,proto3,enum=nwpd.FailureClass" json:"failureClass,omitempty"` // class of the failure, unspecified for successful checks
+	PingStatistics        *PingStatistics        `protobuf:"bytes,18,opt,name=pingStatistics,proto3" json:"pingStatistics,omitempty"`                     // statistics of the packets sent by pingHost
+}
+
+func (x *Observation) Reset() {
+	*x = Observation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_common_nwpd_nwpd_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Observation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Observation) ProtoMessage() {}
+
+func (x *Observation) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_common_nwpd_nwpd_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Observation.ProtoReflect.Descriptor instead.
+func (*Observation) Descriptor() ([]byte, []int) {
+	return file_pkg_common_nwpd_nwpd_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Observation) GetJobID() string {
+	if x != nil {
+		return x.JobID
+	}
+	return ""
+}
+
+func (x *Observation) GetSrcHost() string {
+	if x != nil {
+		return x.SrcHost
+	}
+	return ""
+}
+
+func (x *Observation) GetDestHost() string {
+	if x != nil {
+		return x.DestHost
+	}
+	return ""
+}
+
+func (x *Observation) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *Observation) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *Observation) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+func (x *Observation) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *Observation) GetPeriod() *durationpb.Duration {
+	if x != nil {
+		return x.Period
+	}
+	return nil
+}
+
+func (x *Observation) GetPathMTU() int32 {
+	if x != nil {
+		return x.PathMTU
+	}
+	return 0
+}
+
+func (x *Observation) GetPhaseTimings() *PhaseTimings {
+	if x != nil {
+		return x.PhaseTimings
+	}
+	return nil
+}
+
+func (x *Observation) GetCertRemainingLifetime() *durationpb.Duration {
+	if x != nil {
+		return x.CertRemainingLifetime
+	}
+	return nil
+}
+
+func (x *Observation) GetClockOffset() *durationpb.Duration {
+	if x != nil {
+		return x.ClockOffset
+	}
+	return nil
+}
+
+func (x *Observation) GetThroughput() float64 {
+	if x != nil {
+		return x.Throughput
+	}
+	return 0
+}
+
+func (x *Observation) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *Observation) GetExpectBlocked() bool {
+	if x != nil {
+		return x.ExpectBlocked
+	}
+	return false
+}
+
+func (x *Observation) GetAdHoc() bool {
+	if x != nil {
+		return x.AdHoc
+	}
+	return false
+}
+
+func (x *Observation) GetFailureClass() FailureClass {
+	if x != nil {
+		return x.FailureClass
+	}
+	return FailureClass_FAILURE_CLASS_UNSPECIFIED
+}
+
+func (x *Observation) GetPingStatistics() *PingStatistics {
+	if x != nil {
+		return x.PingStatistics
+	}
+	return nil
+}
+
+// PingStatistics are the statistics of the packets sent by a ping check.
+type PingStatistics struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PacketsSent int32                `protobuf:"varint,1,opt,name=packetsSent,proto3" json:"packetsSent,omitempty"`
+	PacketsRecv int32                `protobuf:"varint,2,opt,name=packetsRecv,proto3" json:"packetsRecv,omitempty"`
+	LossPercent float64              `protobuf:"fixed64,3,opt,name=lossPercent,proto3" json:"lossPercent,omitempty"`
+	MinRtt      *durationpb.Duration `protobuf:"bytes,4,opt,name=minRtt,proto3" json:"minRtt,omitempty"`
+	AvgRtt      *durationpb.Duration `protobuf:"bytes,5,opt,name=avgRtt,proto3" json:"avgRtt,omitempty"`
+	MaxRtt      *durationpb.Duration `protobuf:"bytes,6,opt,name=maxRtt,proto3" json:"maxRtt,omitempty"`
+	MdevRtt     *durationpb.Duration `protobuf:"bytes,7,opt,name=mdevRtt,proto3" json:"mdevRtt,omitempty"`
+}
+
+func (x *PingStatistics) Reset() {
+	*x = PingStatistics{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_common_nwpd_nwpd_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PingStatistics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PingStatistics) ProtoMessage() {}
+
+func (x *PingStatistics) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_common_nwpd_nwpd_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PingStatistics.ProtoReflect.Descriptor instead.
+func (*PingStatistics) Descriptor() ([]byte, []int) {
+	return file_pkg_common_nwpd_nwpd_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *PingStatistics) GetPacketsSent() int32 {
+	if x != nil {
+		return x.PacketsSent
+	}
+	return 0
+}
+
+func (x *PingStatistics) GetPacketsRecv() int32 {
+	if x != nil {
+		return x.PacketsRecv
+	}
+	return 0
+}
+
+func (x *PingStatistics) GetLossPercent() float64 {
+	if x != nil {
+		return x.LossPercent
+	}
+	return 0
+}
+
+func (x *PingStatistics) GetMinRtt() *durationpb.Duration {
+	if x != nil {
+		return x.MinRtt
+	}
+	return nil
+}
+
+func (x *PingStatistics) GetAvgRtt() *durationpb.Duration {
+	if x != nil {
+		return x.AvgRtt
+	}
+	return nil
+}
+
+func (x *PingStatistics) GetMaxRtt() *durationpb.Duration {
+	if x != nil {
+		return x.MaxRtt
+	}
+	return nil
+}
+
+func (x *PingStatistics) GetMdevRtt() *durationpb.Duration {
+	if x != nil {
+		return x.MdevRtt
+	}
+	return nil
+}
+
+// PhaseTimings are the optional durations of the phases of an HTTP request.
+type PhaseTimings struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Dns     *durationpb.Duration `protobuf:"bytes,1,opt,name=dns,proto3" json:"dns,omitempty"`
+	Connect *durationpb.Duration `protobuf:"bytes,2,opt,name=connect,proto3" json:"connect,omitempty"`
+	Tls     *durationpb.Duration `protobuf:"bytes,3,opt,name=tls,proto3" json:"tls,omitempty"`
+	Ttfb    *durationpb.Duration `protobuf:"bytes,4,opt,name=ttfb,proto3" json:"ttfb,omitempty"`
+}
+
+func (x *PhaseTimings) Reset() {
+	*x = PhaseTimings{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_common_nwpd_nwpd_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PhaseTimings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PhaseTimings) ProtoMessage() {}
+
+func (x *PhaseTimings) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_common_nwpd_nwpd_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PhaseTimings.ProtoReflect.Descriptor instead.
+func (*PhaseTimings) Descriptor() ([]byte, []int) {
+	return file_pkg_common_nwpd_nwpd_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *PhaseTimings) GetDns() *durationpb.Duration {
+	if x != nil {
+		return x.Dns
+	}
+	return nil
+}
+
+func (x *PhaseTimings) GetConnect() *durationpb.Duration {
+	if x != nil {
+		return x.Connect
+	}
+	return nil
+}
+
+func (x *PhaseTimings) GetTls() *durationpb.Duration {
+	if x != nil {
+		return x.Tls
+	}
+	return nil
+}
+
+func (x *PhaseTimings) GetTtfb() *durationpb.Duration {
+	if x != nil {
+		return x.Ttfb
+	}
+	return nil
+}
+
+type IntObservation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	JobID          int64              `protobuf:"varint,1,opt,name=JobID,proto3" json:"JobID,omitempty"`
+	SrcHost        int64              `protobuf:"varint,2,opt,name=srcHost,proto3" json:"srcHost,omitempty"`
+	DestHost       int64              `protobuf:"varint,3,opt,name=destHost,proto3" json:"destHost,omitempty"`
+	TimeMillis     int64              `protobuf:"varint,4,opt,name=timeMillis,proto3" json:"timeMillis,omitempty"`
+	DurationMillis int32              `protobuf:"varint,5,opt,name=durationMillis,proto3" json:"durationMillis,omitempty"`
+	Ok             bool               `protobuf:"varint,6,opt,name=ok,proto3" json:"ok,omitempty"`
+	PeriodMillis   int32              `protobuf:"varint,7,opt,name=periodMillis,proto3" json:"periodMillis,omitempty"`
+	PhaseTimings   *IntPhaseTimings   `protobuf:"bytes,8,opt,name=phaseTimings,proto3" json:"phaseTimings,omitempty"`
+	Attempts       int32              `protobuf:"varint,9,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	ExpectBlocked  bool               `protobuf:"varint,10,opt,name=expectBlocked,proto3" json:"expectBlocked,omitempty"`
+	FailureClass   FailureClass       `protobuf:"varint,11,opt,name=failureClass,proto3,enum=nwpd.FailureClass" json:"failureClass,omitempty"`
+	PingStatistics *IntPingStatistics `protobuf:"bytes,12,opt,name=pingStatistics,proto3" json:"pingStatistics,omitempty"`
+}
+
+func (x *IntObservation) Reset() {
+	*x = IntObservation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_common_nwpd_nwpd_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IntObservation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IntObservation) ProtoMessage() {}
+
+func (x *IntObservation) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_common_nwpd_nwpd_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IntObservation.ProtoReflect.Descriptor instead.
+func (*IntObservation) Descriptor() ([]byte, []int) {
+	return file_pkg_common_nwpd_nwpd_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *IntObservation) GetJobID() int64 {
+	if x != nil {
+		return x.JobID
+	}
+	return 0
+}
+
+func (x *IntObservation) GetSrcHost() int64 {
+	if x != nil {
+		return x.SrcHost
+	}
+	return 0
+}
+
+func (x *IntObservation) GetDestHost() int64 {
+	if x != nil {
+		return x.DestHost
+	}
+	return 0
+}
+
+func (x *IntObservation) GetTimeMillis() int64 {
+	if x != nil {
+		return x.TimeMillis
+	}
+	return 0
+}
+
+func (x *IntObservation) GetDurationMillis() int32 {
+	if x != nil {
+		return x.DurationMillis
+	}
+	return 0
+}
+
+func (x *IntObservation) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *IntObservation) GetPeriodMillis() int32 {
+	if x != nil {
+		return x.PeriodMillis
+	}
+	return 0
+}
+
+func (x *IntObservation) GetPhaseTimings() *IntPhaseTimings {
+	if x != nil {
+		return x.PhaseTimings
+	}
+	return nil
+}
+
+func (x *IntObservation) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *IntObservation) GetExpectBlocked() bool {
+	if x != nil {
+		return x.ExpectBlocked
+	}
+	return false
+}
+
+func (x *IntObservation) GetFailureClass() FailureClass {
+	if x != nil {
+		return x.FailureClass
+	}
+	return FailureClass_FAILURE_CLASS_UNSPECIFIED
+}
+
+func (x *IntObservation) GetPingStatistics() *IntPingStatistics {
+	if x != nil {
+		return x.PingStatistics
+	}
+	return nil
+}
+
+// IntPhaseTimings are the persisted phase durations in microseconds (0 if the phase is missing).
+type IntPhaseTimings struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DnsMicros     int32 `protobuf:"varint,1,opt,name=dnsMicros,proto3" json:"dnsMicros,omitempty"`
+	ConnectMicros int32 `protobuf:"varint,2,opt,name=connectMicros,proto3" json:"connectMicros,omitempty"`
+	TlsMicros     int32 `protobuf:"varint,3,opt,name=tlsMicros,proto3" json:"tlsMicros,omitempty"`
+	TtfbMicros    int32 `protobuf:"varint,4,opt,name=ttfbMicros,proto3" json:"ttfbMicros,omitempty"`
+}
+
+func (x *IntPhaseTimings) Reset() {
+	*x = IntPhaseTimings{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_common_nwpd_nwpd_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IntPhaseTimings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IntPhaseTimings) ProtoMessage() {}
+
+func (x *IntPhaseTimings) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_common_nwpd_nwpd_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IntPhaseTimings.ProtoReflect.Descriptor instead.
+func (*IntPhaseTimings) Descriptor() ([]byte, []int) {
+	return file_pkg_common_nwpd_nwpd_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *IntPhaseTimings) GetDnsMicros() int32 {
+	if x != nil {
+		return x.DnsMicros
+	}
+	return 0
+}
+
+func (x *IntPhaseTimings) GetConnectMicros() int32 {
+	if x != nil {
+		return x.ConnectMicros
+	}
+	return 0
+}
+
+func (x *IntPhaseTimings) GetTlsMicros() int32 {
+	if x != nil {
+		return x.TlsMicros
+	}
+	return 0
+}
+
+func (x *IntPhaseTimings) GetTtfbMicros() int32 {
+	if x != nil {
+		return x.TtfbMicros
+	}
+	return 0
+}
+
+// IntPingStatistics are the persisted ping statistics with round trip times in microseconds.
+type IntPingStatistics struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PacketsSent   int32 `protobuf:"varint,1,opt,name=packetsSent,proto3" json:"packetsSent,omitempty"`
+	PacketsRecv   int32 `protobuf:"varint,2,opt,name=packetsRecv,proto3" json:"packetsRecv,omitempty"`
+	MinRttMicros  int32 `protobuf:"varint,3,opt,name=minRttMicros,proto3" json:"minRttMicros,omitempty"`
+	AvgRttMicros  int32 `protobuf:"varint,4,opt,name=avgRttMicros,proto3" json:"avgRttMicros,omitempty"`
+	MaxRttMicros  int32 `protobuf:"varint,5,opt,name=maxRttMicros,proto3" json:"maxRttMicros,omitempty"`
+	MdevRttMicros int32 `protobuf:"varint,6,opt,name=mdevRttMicros,proto3" json:"mdevRttMicros,omitempty"`
+}
+
+func (x *IntPingStatistics) Reset() {
+	*x = IntPingStatistics{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_common_nwpd_nwpd_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IntPingStatistics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IntPingStatistics) ProtoMessage() {}
+
+func (x *IntPingStatistics) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_common_nwpd_nwpd_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IntPingStatistics.ProtoReflect.Descriptor instead.
+func (*IntPingStatistics) Descriptor() ([]byte, []int) {
+	return file_pkg_common_nwpd_nwpd_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *IntPingStatistics) GetPacketsSent() int32 {
+	if x != nil {
+		return x.PacketsSent
+	}
+	return 0
+}
+
+func (x *IntPingStatistics) GetPacketsRecv() int32 {
+	if x != nil {
+		return x.PacketsRecv
+	}
+	return 0
+}
+
+func (x *IntPingStatistics) GetMinRttMicros() int32 {
+	if x != nil {
+		return x.MinRttMicros
+	}
+	return 0
+}
+
+func (x *IntPingStatistics) GetAvgRttMicros() int32 {
+	if x != nil {
+		return x.AvgRttMicros
+	}
+	return 0
+}
+
+func (x *IntPingStatistics) GetMaxRttMicros() int32 {
+	if x != nil {
+		return x.MaxRttMicros
+	}
+	return 0
+}
+
+func (x *IntPingStatistics) GetMdevRttMicros() int32 {
+	if x != nil {
+		return x.MdevRttMicros
+	}
+	return 0
+}
+
+type Int64Arrays struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Array []int64 `protobuf:"varint,1,rep,packed,name=array,proto3" json:"array,omitempty"`
+}
+
+func (x *Int64Arrays) Reset() {
+	*x = Int64Arrays{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_common_nwpd_nwpd_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Int64Arrays) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Int64Arrays) ProtoMessage() {}
+
+func (x *Int64Arrays) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_common_nwpd_nwpd_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Int64Arrays.ProtoReflect.Descriptor instead.
+func (*Int64Arrays) Descriptor() ([]byte, []int) {
+	return file_pkg_common_nwpd_nwpd_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Int64Arrays) GetArray() []int64 {
+	if x != nil {
+		return x.Array
+	}
+	return nil
+}
+
+type IntString struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key   int64  `protobuf:"varint,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *IntString) Reset() {
+	*x = IntString{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_common_nwpd_nwpd_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IntString) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IntString) ProtoMessage() {}
+
+func (x *IntString) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_common_nwpd_nwpd_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IntString.ProtoReflect.Descriptor instead.
+func (*IntString) Descriptor() ([]byte, []int) {
+	return file_pkg_common_nwpd_nwpd_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *IntString) GetKey() int64 {
+	if x != nil {
+		return x.Key
+	}
+	return 0
+}
+
+func (x *IntString) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// RecordFileIndex is the sidecar index of a rotated record file.
+type RecordFileIndex struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version int32 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	// fileSize is the size of the indexed record file. The index is outdated if the size differs.
+	FileSize     int64 `protobuf:"varint,2,opt,name=fileSize,proto3" json:"fileSize,omitempty"`
+	RecordFormat int32 `protobuf:"varint,3,opt,name=recordFormat,proto3" json:"recordFormat,omitempty"`
+	StartMillis  int64 `protobuf:"varint,4,opt,name=startMillis,proto3" json:"startMillis,omitempty"`
+	EndMillis    int64 `protobuf:"varint,5,opt,name=endMillis,proto3" json:"endMillis,omitempty"`
+	// strings is the StringIDMap of the complete record file.
+	Strings []*IntString            `protobuf:"bytes,6,rep,name=strings,proto3" json:"strings,omitempty"`
+	Edges   []*RecordFileIndexEdge  `protobuf:"bytes,7,rep,name=edges,proto3" json:"edges,omitempty"`
+	Slices  []*RecordFileIndexSlice `protobuf:"bytes,8,rep,name=slices,proto3" json:"slices,omitempty"`
+}
+
+func (x *RecordFileIndex) Reset() {
+	*x = RecordFileIndex{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_common_nwpd_nwpd_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecordFileIndex) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordFileIndex) ProtoMessage() {}
+
+func (x *RecordFileIndex) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_common_nwpd_nwpd_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordFileIndex.ProtoReflect.Descriptor instead.
+func (*RecordFileIndex) Descriptor() ([]byte, []int) {
+	return file_pkg_common_nwpd_nwpd_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RecordFileIndex) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *RecordFileIndex) GetFileSize() int64 {
+	if x != nil {
+		return x.FileSize
+	}
+	return 0
+}
+
+func (x *RecordFileIndex) GetRecordFormat() int32 {
+	if x != nil {
+		return x.RecordFormat
+	}
+	return 0
+}
+
+func (x *RecordFileIndex) GetStartMillis() int64 {
+	if x != nil {
+		return x.StartMillis
+	}
+	return 0
+}
+
+func (x *RecordFileIndex) GetEndMillis() int64 {
+	if x != nil {
+		return x.EndMillis
+	}
+	return 0
+}
+
+func (x *RecordFileIndex) GetStrings() []*IntString {
+	if x != nil {
+		return x.Strings
+	}
+	return nil
+}
 
-func (x *IntString) GetKey() int64 {
+func (x *RecordFileIndex) GetEdges() []*RecordFileIndexEdge {
 	if x != nil {
-		return x.Key
+		return x.Edges
+	}
+	return nil
+}
+
+func (x *RecordFileIndex) GetSlices() []*RecordFileIndexSlice {
+	if x != nil {
+		return x.Slices
+	}
+	return nil
+}
+
+// RecordFileIndexEdge counts the observations of a job between two hosts.
+type RecordFileIndexEdge struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	JobID       int64 `protobuf:"varint,1,opt,name=jobID,proto3" json:"jobID,omitempty"`
+	SrcHost     int64 `protobuf:"varint,2,opt,name=srcHost,proto3" json:"srcHost,omitempty"`
+	DestHost    int64 `protobuf:"varint,3,opt,name=destHost,proto3" json:"destHost,omitempty"`
+	OkCount     int32 `protobuf:"varint,4,opt,name=okCount,proto3" json:"okCount,omitempty"`
+	FailedCount int32 `protobuf:"varint,5,opt,name=failedCount,proto3" json:"failedCount,omitempty"`
+}
+
+func (x *RecordFileIndexEdge) Reset() {
+	*x = RecordFileIndexEdge{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_common_nwpd_nwpd_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecordFileIndexEdge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordFileIndexEdge) ProtoMessage() {}
+
+func (x *RecordFileIndexEdge) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_common_nwpd_nwpd_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordFileIndexEdge.ProtoReflect.Descriptor instead.
+func (*RecordFileIndexEdge) Descriptor() ([]byte, []int) {
+	return file_pkg_common_nwpd_nwpd_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RecordFileIndexEdge) GetJobID() int64 {
+	if x != nil {
+		return x.JobID
 	}
 	return 0
 }
 
-func (x *IntString) GetValue() string {
+func (x *RecordFileIndexEdge) GetSrcHost() int64 {
 	if x != nil {
-		return x.Value
+		return x.SrcHost
 	}
-	return ""
+	return 0
+}
+
+func (x *RecordFileIndexEdge) GetDestHost() int64 {
+	if x != nil {
+		return x.DestHost
+	}
+	return 0
+}
+
+func (x *RecordFileIndexEdge) GetOkCount() int32 {
+	if x != nil {
+		return x.OkCount
+	}
+	return 0
+}
+
+func (x *RecordFileIndexEdge) GetFailedCount() int32 {
+	if x != nil {
+		return x.FailedCount
+	}
+	return 0
+}
+
+// RecordFileIndexSlice is a time slice of the record file stored as separate gzip member starting at the offset.
+type RecordFileIndexSlice struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StartMillis int64 `protobuf:"varint,1,opt,name=startMillis,proto3" json:"startMillis,omitempty"`
+	EndMillis   int64 `protobuf:"varint,2,opt,name=endMillis,proto3" json:"endMillis,omitempty"`
+	Offset      int64 `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+}
+
+func (x *RecordFileIndexSlice) Reset() {
+	*x = RecordFileIndexSlice{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_common_nwpd_nwpd_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecordFileIndexSlice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordFileIndexSlice) ProtoMessage() {}
+
+func (x *RecordFileIndexSlice) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_common_nwpd_nwpd_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordFileIndexSlice.ProtoReflect.Descriptor instead.
+func (*RecordFileIndexSlice) Descriptor() ([]byte, []int) {
+	return file_pkg_common_nwpd_nwpd_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RecordFileIndexSlice) GetStartMillis() int64 {
+	if x != nil {
+		return x.StartMillis
+	}
+	return 0
+}
+
+func (x *RecordFileIndexSlice) GetEndMillis() int64 {
+	if x != nil {
+		return x.EndMillis
+	}
+	return 0
+}
+
+func (x *RecordFileIndexSlice) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
 }
 
 var File_pkg_common_nwpd_nwpd_proto protoreflect.FileDescriptor
@@ -659,112 +1876,353 @@ var file_pkg_common_nwpd_nwpd_proto_rawDesc = []byte{
 	0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
 	0x32, 0x11, 0x2e, 0x6e, 0x77, 0x70, 0x64, 0x2e, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74,
 	0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x22, 0x78, 0x0a, 0x21, 0x47, 0x65, 0x74, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74,
-	0x65, 0x64, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x53, 0x0a, 0x16, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67,
+	0x73, 0x22, 0x8c, 0x01, 0x0a, 0x0f, 0x52, 0x75, 0x6e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x67, 0x73, 0x12, 0x30, 0x0a, 0x13, 0x72, 0x65, 0x73,
+	0x74, 0x72, 0x69, 0x63, 0x74, 0x54, 0x6f, 0x44, 0x65, 0x73, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x13, 0x72, 0x65, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74,
+	0x54, 0x6f, 0x44, 0x65, 0x73, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x73, 0x12, 0x33, 0x0a, 0x07, 0x74,
+	0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74,
+	0x22, 0x6f, 0x0a, 0x10, 0x52, 0x75, 0x6e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x0c, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6e, 0x77, 0x70,
+	0x64, 0x2e, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x6f,
+	0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x70,
+	0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0d, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x43, 0x68, 0x65, 0x63, 0x6b,
+	0x73, 0x22, 0x12, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xb0, 0x02, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x6f, 0x64, 0x65, 0x4e, 0x61, 0x6d,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x6f, 0x64, 0x65, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x20, 0x0a, 0x0b, 0x68, 0x6f, 0x73, 0x74, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x68, 0x6f, 0x73, 0x74, 0x4e, 0x65, 0x74, 0x77,
+	0x6f, 0x72, 0x6b, 0x12, 0x2a, 0x0a, 0x10, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x47, 0x65, 0x6e,
+	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x40, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x65,
+	0x64, 0x12, 0x23, 0x0a, 0x04, 0x6a, 0x6f, 0x62, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0f, 0x2e, 0x6e, 0x77, 0x70, 0x64, 0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x04, 0x6a, 0x6f, 0x62, 0x73, 0x12, 0x30, 0x0a, 0x0a, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x45,
+	0x64, 0x67, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6e, 0x77, 0x70,
+	0x64, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x45, 0x64, 0x67, 0x65, 0x73, 0x52, 0x0a, 0x76, 0x61,
+	0x6c, 0x69, 0x64, 0x45, 0x64, 0x67, 0x65, 0x73, 0x22, 0xc1, 0x03, 0x0a, 0x09, 0x4a, 0x6f, 0x62,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6a, 0x6f, 0x62, 0x49, 0x44, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6a, 0x6f, 0x62, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04,
+	0x61, 0x72, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x67, 0x73,
+	0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x65, 0x73, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x64, 0x65, 0x73, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x73,
+	0x12, 0x31, 0x0a, 0x06, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x70, 0x65, 0x72,
+	0x69, 0x6f, 0x64, 0x12, 0x34, 0x0a, 0x07, 0x6c, 0x61, 0x73, 0x74, 0x52, 0x75, 0x6e, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x07, 0x6c, 0x61, 0x73, 0x74, 0x52, 0x75, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74,
+	0x69, 0x76, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x76,
+	0x65, 0x12, 0x24, 0x0a, 0x0d, 0x70, 0x65, 0x65, 0x72, 0x4e, 0x6f, 0x64, 0x65, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x70, 0x65, 0x65, 0x72, 0x4e, 0x6f,
+	0x64, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x33, 0x0a, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6e,
+	0x77, 0x70, 0x64, 0x2e, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x0b, 0x6c, 0x61, 0x73, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x33, 0x0a, 0x06,
+	0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6e,
+	0x77, 0x70, 0x64, 0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2e, 0x4c, 0x61,
+	0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c,
+	0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x84, 0x01, 0x0a,
+	0x0a, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x45, 0x64, 0x67, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6a,
+	0x6f, 0x62, 0x49, 0x44, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x6a, 0x6f, 0x62,
+	0x49, 0x44, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x72, 0x63, 0x48, 0x6f, 0x73, 0x74, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x73, 0x72, 0x63, 0x48, 0x6f, 0x73, 0x74, 0x73, 0x12,
+	0x1c, 0x0a, 0x09, 0x64, 0x65, 0x73, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x09, 0x64, 0x65, 0x73, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x73, 0x12, 0x24, 0x0a,
+	0x0d, 0x70, 0x65, 0x65, 0x72, 0x4e, 0x6f, 0x64, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x70, 0x65, 0x65, 0x72, 0x4e, 0x6f, 0x64, 0x65, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x22, 0x78, 0x0a, 0x21, 0x47, 0x65, 0x74, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67,
 	0x61, 0x74, 0x65, 0x64, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6e, 0x77, 0x70, 0x64, 0x2e, 0x41, 0x67,
-	0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x64, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x52, 0x16, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x64, 0x4f,
-	0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xa8, 0x05, 0x0a, 0x15,
-	0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x64, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x72, 0x63, 0x48, 0x6f, 0x73, 0x74,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x72, 0x63, 0x48, 0x6f, 0x73, 0x74, 0x12,
-	0x1a, 0x0a, 0x08, 0x64, 0x65, 0x73, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x08, 0x64, 0x65, 0x73, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x12, 0x3c, 0x0a, 0x0b, 0x70,
-	0x65, 0x72, 0x69, 0x6f, 0x64, 0x53, 0x74, 0x61, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x70, 0x65,
-	0x72, 0x69, 0x6f, 0x64, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x38, 0x0a, 0x09, 0x70, 0x65, 0x72,
-	0x69, 0x6f, 0x64, 0x45, 0x6e, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
-	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64,
-	0x45, 0x6e, 0x64, 0x12, 0x4e, 0x0a, 0x0b, 0x6a, 0x6f, 0x62, 0x73, 0x4f, 0x6b, 0x43, 0x6f, 0x75,
-	0x6e, 0x74, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x6e, 0x77, 0x70, 0x64, 0x2e,
-	0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x64, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4a, 0x6f, 0x62, 0x73, 0x4f, 0x6b, 0x43, 0x6f, 0x75, 0x6e,
-	0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x6a, 0x6f, 0x62, 0x73, 0x4f, 0x6b, 0x43, 0x6f,
-	0x75, 0x6e, 0x74, 0x12, 0x57, 0x0a, 0x0e, 0x6a, 0x6f, 0x62, 0x73, 0x4e, 0x6f, 0x74, 0x4f, 0x6b,
-	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x6e, 0x77,
-	0x70, 0x64, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x64, 0x4f, 0x62, 0x73,
-	0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4a, 0x6f, 0x62, 0x73, 0x4e, 0x6f, 0x74,
-	0x4f, 0x6b, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0e, 0x6a, 0x6f,
-	0x62, 0x73, 0x4e, 0x6f, 0x74, 0x4f, 0x6b, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x57, 0x0a, 0x0e,
-	0x6d, 0x65, 0x61, 0x6e, 0x4f, 0x6b, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x6e, 0x77, 0x70, 0x64, 0x2e, 0x41, 0x67, 0x67, 0x72,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x53, 0x0a, 0x16, 0x61, 0x67, 0x67, 0x72,
 	0x65, 0x67, 0x61, 0x74, 0x65, 0x64, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x2e, 0x4d, 0x65, 0x61, 0x6e, 0x4f, 0x6b, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0e, 0x6d, 0x65, 0x61, 0x6e, 0x4f, 0x6b, 0x44, 0x75, 0x72,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x3e, 0x0a, 0x10, 0x4a, 0x6f, 0x62, 0x73, 0x4f, 0x6b, 0x43,
-	0x6f, 0x75, 0x6e, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x41, 0x0a, 0x13, 0x4a, 0x6f, 0x62, 0x73, 0x4e, 0x6f, 0x74,
-	0x4f, 0x6b, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
-	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
-	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x5c, 0x0a, 0x13, 0x4d, 0x65, 0x61, 0x6e,
-	0x4f, 0x6b, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
-	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
-	0x79, 0x12, 0x2f, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xa5, 0x02, 0x0a, 0x0b, 0x4f, 0x62, 0x73, 0x65, 0x72,
-	0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x6a, 0x6f, 0x62, 0x49, 0x44, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6a, 0x6f, 0x62, 0x49, 0x44, 0x12, 0x18, 0x0a, 0x07,
-	0x73, 0x72, 0x63, 0x48, 0x6f, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73,
-	0x72, 0x63, 0x48, 0x6f, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x73, 0x74, 0x48, 0x6f,
-	0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x65, 0x73, 0x74, 0x48, 0x6f,
-	0x73, 0x74, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
-	0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x35, 0x0a, 0x08,
-	0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
+	0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6e, 0x77, 0x70, 0x64, 0x2e,
+	0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x64, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x16, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65,
+	0x64, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xa8, 0x05,
+	0x0a, 0x15, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x64, 0x4f, 0x62, 0x73, 0x65,
+	0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x72, 0x63, 0x48, 0x6f,
+	0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x72, 0x63, 0x48, 0x6f, 0x73,
+	0x74, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x73, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x65, 0x73, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x12, 0x3c, 0x0a,
+	0x0b, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x53, 0x74, 0x61, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b,
+	0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x38, 0x0a, 0x09, 0x70,
+	0x65, 0x72, 0x69, 0x6f, 0x64, 0x45, 0x6e, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
 	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
-	0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x06, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x6f,
-	0x6b, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x31, 0x0a, 0x06, 0x70,
-	0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75,
-	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x22, 0xd8,
-	0x01, 0x0a, 0x0e, 0x49, 0x6e, 0x74, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x12, 0x14, 0x0a, 0x05, 0x4a, 0x6f, 0x62, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x05, 0x4a, 0x6f, 0x62, 0x49, 0x44, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x72, 0x63, 0x48, 0x6f,
-	0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x73, 0x72, 0x63, 0x48, 0x6f, 0x73,
-	0x74, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x73, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x08, 0x64, 0x65, 0x73, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x12, 0x1e, 0x0a,
-	0x0a, 0x74, 0x69, 0x6d, 0x65, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x12, 0x26, 0x0a,
-	0x0e, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d,
-	0x69, 0x6c, 0x6c, 0x69, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x06, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x22, 0x0a, 0x0c, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x4d,
-	0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x70, 0x65, 0x72,
-	0x69, 0x6f, 0x64, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x22, 0x23, 0x0a, 0x0b, 0x49, 0x6e, 0x74,
-	0x36, 0x34, 0x41, 0x72, 0x72, 0x61, 0x79, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x72, 0x72, 0x61,
-	0x79, 0x18, 0x01, 0x20, 0x03, 0x28, 0x03, 0x52, 0x05, 0x61, 0x72, 0x72, 0x61, 0x79, 0x22, 0x33,
-	0x0a, 0x09, 0x49, 0x6e, 0x74, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x10, 0x0a, 0x03, 0x6b,
-	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x32, 0xc6, 0x01, 0x0a, 0x0c, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x53, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x12, 0x50, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x4f, 0x62, 0x73, 0x65, 0x72,
-	0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1c, 0x2e, 0x6e, 0x77, 0x70, 0x64, 0x2e, 0x47,
-	0x65, 0x74, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x6e, 0x77, 0x70, 0x64, 0x2e, 0x47, 0x65, 0x74,
-	0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x64, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x41, 0x67, 0x67,
-	0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x64, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x12, 0x1c, 0x2e, 0x6e, 0x77, 0x70, 0x64, 0x2e, 0x47, 0x65, 0x74, 0x4f, 0x62,
-	0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x27, 0x2e, 0x6e, 0x77, 0x70, 0x64, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x67, 0x67, 0x72,
-	0x65, 0x67, 0x61, 0x74, 0x65, 0x64, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x3e, 0x5a, 0x3c,
-	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x61, 0x72, 0x64, 0x65,
-	0x6e, 0x65, 0x72, 0x2f, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2d, 0x70, 0x72, 0x6f, 0x62,
-	0x6c, 0x65, 0x6d, 0x2d, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2f, 0x70, 0x6b, 0x67,
-	0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x6e, 0x77, 0x70, 0x64, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x70, 0x65, 0x72, 0x69,
+	0x6f, 0x64, 0x45, 0x6e, 0x64, 0x12, 0x4e, 0x0a, 0x0b, 0x6a, 0x6f, 0x62, 0x73, 0x4f, 0x6b, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x6e, 0x77, 0x70,
+	0x64, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x64, 0x4f, 0x62, 0x73, 0x65,
+	0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4a, 0x6f, 0x62, 0x73, 0x4f, 0x6b, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x6a, 0x6f, 0x62, 0x73, 0x4f, 0x6b,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x57, 0x0a, 0x0e, 0x6a, 0x6f, 0x62, 0x73, 0x4e, 0x6f, 0x74,
+	0x4f, 0x6b, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e,
+	0x6e, 0x77, 0x70, 0x64, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x64, 0x4f,
+	0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4a, 0x6f, 0x62, 0x73, 0x4e,
+	0x6f, 0x74, 0x4f, 0x6b, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0e,
+	0x6a, 0x6f, 0x62, 0x73, 0x4e, 0x6f, 0x74, 0x4f, 0x6b, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x57,
+	0x0a, 0x0e, 0x6d, 0x65, 0x61, 0x6e, 0x4f, 0x6b, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x6e, 0x77, 0x70, 0x64, 0x2e, 0x41, 0x67,
+	0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x64, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x2e, 0x4d, 0x65, 0x61, 0x6e, 0x4f, 0x6b, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0e, 0x6d, 0x65, 0x61, 0x6e, 0x4f, 0x6b, 0x44,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x3e, 0x0a, 0x10, 0x4a, 0x6f, 0x62, 0x73, 0x4f,
+	0x6b, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x41, 0x0a, 0x13, 0x4a, 0x6f, 0x62, 0x73, 0x4e,
+	0x6f, 0x74, 0x4f, 0x6b, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x5c, 0x0a, 0x13, 0x4d, 0x65,
+	0x61, 0x6e, 0x4f, 0x6b, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x2f, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xf3, 0x05, 0x0a, 0x0b, 0x4f, 0x62, 0x73,
+	0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x6a, 0x6f, 0x62, 0x49,
+	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6a, 0x6f, 0x62, 0x49, 0x44, 0x12, 0x18,
+	0x0a, 0x07, 0x73, 0x72, 0x63, 0x48, 0x6f, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x73, 0x72, 0x63, 0x48, 0x6f, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x73, 0x74,
+	0x48, 0x6f, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x65, 0x73, 0x74,
+	0x48, 0x6f, 0x73, 0x74, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x35,
+	0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x64, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x0e, 0x0a,
+	0x02, 0x6f, 0x6b, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x31, 0x0a,
+	0x06, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64,
+	0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x74, 0x68, 0x4d, 0x54, 0x55, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x07, 0x70, 0x61, 0x74, 0x68, 0x4d, 0x54, 0x55, 0x12, 0x36, 0x0a, 0x0c, 0x70, 0x68,
+	0x61, 0x73, 0x65, 0x54, 0x69, 0x6d, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x12, 0x2e, 0x6e, 0x77, 0x70, 0x64, 0x2e, 0x50, 0x68, 0x61, 0x73, 0x65, 0x54, 0x69, 0x6d,
+	0x69, 0x6e, 0x67, 0x73, 0x52, 0x0c, 0x70, 0x68, 0x61, 0x73, 0x65, 0x54, 0x69, 0x6d, 0x69, 0x6e,
+	0x67, 0x73, 0x12, 0x4f, 0x0a, 0x15, 0x63, 0x65, 0x72, 0x74, 0x52, 0x65, 0x6d, 0x61, 0x69, 0x6e,
+	0x69, 0x6e, 0x67, 0x4c, 0x69, 0x66, 0x65, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x15, 0x63, 0x65,
+	0x72, 0x74, 0x52, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x4c, 0x69, 0x66, 0x65, 0x74,
+	0x69, 0x6d, 0x65, 0x12, 0x3b, 0x0a, 0x0b, 0x63, 0x6c, 0x6f, 0x63, 0x6b, 0x4f, 0x66, 0x66, 0x73,
+	0x65, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x63, 0x6c, 0x6f, 0x63, 0x6b, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74,
+	0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x70, 0x75, 0x74, 0x18, 0x0d,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x74, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x70, 0x75, 0x74,
+	0x12, 0x1a, 0x0a, 0x08, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x18, 0x0e, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x08, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x12, 0x24, 0x0a, 0x0d,
+	0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x18, 0x0f, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0d, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x64, 0x48, 0x6f, 0x63, 0x18, 0x10, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x05, 0x61, 0x64, 0x48, 0x6f, 0x63, 0x12, 0x36, 0x0a, 0x0c, 0x66, 0x61, 0x69, 0x6c,
+	0x75, 0x72, 0x65, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12,
+	0x2e, 0x6e, 0x77, 0x70, 0x64, 0x2e, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x43, 0x6c, 0x61,
+	0x73, 0x73, 0x52, 0x0c, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x43, 0x6c, 0x61, 0x73, 0x73,
+	0x12, 0x3c, 0x0a, 0x0e, 0x70, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69,
+	0x63, 0x73, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6e, 0x77, 0x70, 0x64, 0x2e,
+	0x50, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x52, 0x0e,
+	0x70, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x22, 0xc4,
+	0x02, 0x0a, 0x0e, 0x50, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63,
+	0x73, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x53, 0x65, 0x6e, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x53,
+	0x65, 0x6e, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x52, 0x65,
+	0x63, 0x76, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74,
+	0x73, 0x52, 0x65, 0x63, 0x76, 0x12, 0x20, 0x0a, 0x0b, 0x6c, 0x6f, 0x73, 0x73, 0x50, 0x65, 0x72,
+	0x63, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x6c, 0x6f, 0x73, 0x73,
+	0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x31, 0x0a, 0x06, 0x6d, 0x69, 0x6e, 0x52, 0x74,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x06, 0x6d, 0x69, 0x6e, 0x52, 0x74, 0x74, 0x12, 0x31, 0x0a, 0x06, 0x61, 0x76,
+	0x67, 0x52, 0x74, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x61, 0x76, 0x67, 0x52, 0x74, 0x74, 0x12, 0x31, 0x0a,
+	0x06, 0x6d, 0x61, 0x78, 0x52, 0x74, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x6d, 0x61, 0x78, 0x52, 0x74, 0x74,
+	0x12, 0x33, 0x0a, 0x07, 0x6d, 0x64, 0x65, 0x76, 0x52, 0x74, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x6d, 0x64,
+	0x65, 0x76, 0x52, 0x74, 0x74, 0x22, 0xcc, 0x01, 0x0a, 0x0c, 0x50, 0x68, 0x61, 0x73, 0x65, 0x54,
+	0x69, 0x6d, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x2b, 0x0a, 0x03, 0x64, 0x6e, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x03,
+	0x64, 0x6e, 0x73, 0x12, 0x33, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x07, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x12, 0x2b, 0x0a, 0x03, 0x74, 0x6c, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x03, 0x74, 0x6c, 0x73, 0x12, 0x2d, 0x0a, 0x04, 0x74, 0x74, 0x66, 0x62, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x04,
+	0x74, 0x74, 0x66, 0x62, 0x22, 0xce, 0x03, 0x0a, 0x0e, 0x49, 0x6e, 0x74, 0x4f, 0x62, 0x73, 0x65,
+	0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x4a, 0x6f, 0x62, 0x49, 0x44,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x4a, 0x6f, 0x62, 0x49, 0x44, 0x12, 0x18, 0x0a,
+	0x07, 0x73, 0x72, 0x63, 0x48, 0x6f, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07,
+	0x73, 0x72, 0x63, 0x48, 0x6f, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x73, 0x74, 0x48,
+	0x6f, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x64, 0x65, 0x73, 0x74, 0x48,
+	0x6f, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x4d, 0x69, 0x6c, 0x6c, 0x69,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x4d, 0x69, 0x6c,
+	0x6c, 0x69, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d,
+	0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x64, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x6f,
+	0x6b, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x22, 0x0a, 0x0c, 0x70,
+	0x65, 0x72, 0x69, 0x6f, 0x64, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0c, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x12,
+	0x39, 0x0a, 0x0c, 0x70, 0x68, 0x61, 0x73, 0x65, 0x54, 0x69, 0x6d, 0x69, 0x6e, 0x67, 0x73, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6e, 0x77, 0x70, 0x64, 0x2e, 0x49, 0x6e, 0x74,
+	0x50, 0x68, 0x61, 0x73, 0x65, 0x54, 0x69, 0x6d, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x0c, 0x70, 0x68,
+	0x61, 0x73, 0x65, 0x54, 0x69, 0x6d, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x74,
+	0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x61, 0x74,
+	0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x65,
+	0x78, 0x70, 0x65, 0x63, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x12, 0x36, 0x0a, 0x0c,
+	0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x12, 0x2e, 0x6e, 0x77, 0x70, 0x64, 0x2e, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72,
+	0x65, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x52, 0x0c, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x43,
+	0x6c, 0x61, 0x73, 0x73, 0x12, 0x3f, 0x0a, 0x0e, 0x70, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74,
+	0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6e,
+	0x77, 0x70, 0x64, 0x2e, 0x49, 0x6e, 0x74, 0x50, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x69,
+	0x73, 0x74, 0x69, 0x63, 0x73, 0x52, 0x0e, 0x70, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x69,
+	0x73, 0x74, 0x69, 0x63, 0x73, 0x22, 0x93, 0x01, 0x0a, 0x0f, 0x49, 0x6e, 0x74, 0x50, 0x68, 0x61,
+	0x73, 0x65, 0x54, 0x69, 0x6d, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x6e, 0x73,
+	0x4d, 0x69, 0x63, 0x72, 0x6f, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x64, 0x6e,
+	0x73, 0x4d, 0x69, 0x63, 0x72, 0x6f, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x4d, 0x69, 0x63, 0x72, 0x6f, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d,
+	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x4d, 0x69, 0x63, 0x72, 0x6f, 0x73, 0x12, 0x1c, 0x0a,
+	0x09, 0x74, 0x6c, 0x73, 0x4d, 0x69, 0x63, 0x72, 0x6f, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x09, 0x74, 0x6c, 0x73, 0x4d, 0x69, 0x63, 0x72, 0x6f, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x74,
+	0x74, 0x66, 0x62, 0x4d, 0x69, 0x63, 0x72, 0x6f, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0a, 0x74, 0x74, 0x66, 0x62, 0x4d, 0x69, 0x63, 0x72, 0x6f, 0x73, 0x22, 0xe9, 0x01, 0x0a, 0x11,
+	0x49, 0x6e, 0x74, 0x50, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63,
+	0x73, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x53, 0x65, 0x6e, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x53,
+	0x65, 0x6e, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x52, 0x65,
+	0x63, 0x76, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74,
+	0x73, 0x52, 0x65, 0x63, 0x76, 0x12, 0x22, 0x0a, 0x0c, 0x6d, 0x69, 0x6e, 0x52, 0x74, 0x74, 0x4d,
+	0x69, 0x63, 0x72, 0x6f, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x6d, 0x69, 0x6e,
+	0x52, 0x74, 0x74, 0x4d, 0x69, 0x63, 0x72, 0x6f, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x61, 0x76, 0x67,
+	0x52, 0x74, 0x74, 0x4d, 0x69, 0x63, 0x72, 0x6f, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0c, 0x61, 0x76, 0x67, 0x52, 0x74, 0x74, 0x4d, 0x69, 0x63, 0x72, 0x6f, 0x73, 0x12, 0x22, 0x0a,
+	0x0c, 0x6d, 0x61, 0x78, 0x52, 0x74, 0x74, 0x4d, 0x69, 0x63, 0x72, 0x6f, 0x73, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0c, 0x6d, 0x61, 0x78, 0x52, 0x74, 0x74, 0x4d, 0x69, 0x63, 0x72, 0x6f,
+	0x73, 0x12, 0x24, 0x0a, 0x0d, 0x6d, 0x64, 0x65, 0x76, 0x52, 0x74, 0x74, 0x4d, 0x69, 0x63, 0x72,
+	0x6f, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x6d, 0x64, 0x65, 0x76, 0x52, 0x74,
+	0x74, 0x4d, 0x69, 0x63, 0x72, 0x6f, 0x73, 0x22, 0x23, 0x0a, 0x0b, 0x49, 0x6e, 0x74, 0x36, 0x34,
+	0x41, 0x72, 0x72, 0x61, 0x79, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x72, 0x72, 0x61, 0x79, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x03, 0x52, 0x05, 0x61, 0x72, 0x72, 0x61, 0x79, 0x22, 0x33, 0x0a, 0x09,
+	0x49, 0x6e, 0x74, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x22, 0xbb, 0x02, 0x0a, 0x0f, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x46, 0x69, 0x6c, 0x65,
+	0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x72,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0c, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12,
+	0x20, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x72, 0x74, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x73, 0x74, 0x61, 0x72, 0x74, 0x4d, 0x69, 0x6c, 0x6c, 0x69,
+	0x73, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x6e, 0x64, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x65, 0x6e, 0x64, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x12,
+	0x29, 0x0a, 0x07, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0f, 0x2e, 0x6e, 0x77, 0x70, 0x64, 0x2e, 0x49, 0x6e, 0x74, 0x53, 0x74, 0x72, 0x69, 0x6e,
+	0x67, 0x52, 0x07, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x2f, 0x0a, 0x05, 0x65, 0x64,
+	0x67, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6e, 0x77, 0x70, 0x64,
+	0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78,
+	0x45, 0x64, 0x67, 0x65, 0x52, 0x05, 0x65, 0x64, 0x67, 0x65, 0x73, 0x12, 0x32, 0x0a, 0x06, 0x73,
+	0x6c, 0x69, 0x63, 0x65, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6e, 0x77,
+	0x70, 0x64, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x64,
+	0x65, 0x78, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x52, 0x06, 0x73, 0x6c, 0x69, 0x63, 0x65, 0x73, 0x22,
+	0x9d, 0x01, 0x0a, 0x13, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e,
+	0x64, 0x65, 0x78, 0x45, 0x64, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6a, 0x6f, 0x62, 0x49, 0x44,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6a, 0x6f, 0x62, 0x49, 0x44, 0x12, 0x18, 0x0a,
+	0x07, 0x73, 0x72, 0x63, 0x48, 0x6f, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07,
+	0x73, 0x72, 0x63, 0x48, 0x6f, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x73, 0x74, 0x48,
+	0x6f, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x64, 0x65, 0x73, 0x74, 0x48,
+	0x6f, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x6b, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6f, 0x6b, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x20, 0x0a,
+	0x0b, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0b, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22,
+	0x6e, 0x0a, 0x14, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x64,
+	0x65, 0x78, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x6e, 0x64,
+	0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x65, 0x6e,
+	0x64, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x2a,
+	0xd7, 0x02, 0x0a, 0x0c, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x43, 0x6c, 0x61, 0x73, 0x73,
+	0x12, 0x1d, 0x0a, 0x19, 0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45, 0x5f, 0x43, 0x4c, 0x41, 0x53,
+	0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12,
+	0x17, 0x0a, 0x13, 0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45, 0x5f, 0x43, 0x4c, 0x41, 0x53, 0x53,
+	0x5f, 0x4f, 0x54, 0x48, 0x45, 0x52, 0x10, 0x01, 0x12, 0x19, 0x0a, 0x15, 0x46, 0x41, 0x49, 0x4c,
+	0x55, 0x52, 0x45, 0x5f, 0x43, 0x4c, 0x41, 0x53, 0x53, 0x5f, 0x54, 0x49, 0x4d, 0x45, 0x4f, 0x55,
+	0x54, 0x10, 0x02, 0x12, 0x24, 0x0a, 0x20, 0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45, 0x5f, 0x43,
+	0x4c, 0x41, 0x53, 0x53, 0x5f, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f,
+	0x52, 0x45, 0x46, 0x55, 0x53, 0x45, 0x44, 0x10, 0x03, 0x12, 0x22, 0x0a, 0x1e, 0x46, 0x41, 0x49,
+	0x4c, 0x55, 0x52, 0x45, 0x5f, 0x43, 0x4c, 0x41, 0x53, 0x53, 0x5f, 0x43, 0x4f, 0x4e, 0x4e, 0x45,
+	0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x52, 0x45, 0x53, 0x45, 0x54, 0x10, 0x04, 0x12, 0x1d, 0x0a,
+	0x19, 0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45, 0x5f, 0x43, 0x4c, 0x41, 0x53, 0x53, 0x5f, 0x55,
+	0x4e, 0x52, 0x45, 0x41, 0x43, 0x48, 0x41, 0x42, 0x4c, 0x45, 0x10, 0x05, 0x12, 0x1f, 0x0a, 0x1b,
+	0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45, 0x5f, 0x43, 0x4c, 0x41, 0x53, 0x53, 0x5f, 0x44, 0x4e,
+	0x53, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x46, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0x06, 0x12, 0x15, 0x0a,
+	0x11, 0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45, 0x5f, 0x43, 0x4c, 0x41, 0x53, 0x53, 0x5f, 0x44,
+	0x4e, 0x53, 0x10, 0x07, 0x12, 0x15, 0x0a, 0x11, 0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45, 0x5f,
+	0x43, 0x4c, 0x41, 0x53, 0x53, 0x5f, 0x54, 0x4c, 0x53, 0x10, 0x08, 0x12, 0x1d, 0x0a, 0x19, 0x46,
+	0x41, 0x49, 0x4c, 0x55, 0x52, 0x45, 0x5f, 0x43, 0x4c, 0x41, 0x53, 0x53, 0x5f, 0x48, 0x54, 0x54,
+	0x50, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x10, 0x09, 0x12, 0x1d, 0x0a, 0x19, 0x46, 0x41,
+	0x49, 0x4c, 0x55, 0x52, 0x45, 0x5f, 0x43, 0x4c, 0x41, 0x53, 0x53, 0x5f, 0x4e, 0x4f, 0x54, 0x5f,
+	0x42, 0x4c, 0x4f, 0x43, 0x4b, 0x45, 0x44, 0x10, 0x0a, 0x32, 0xc3, 0x02, 0x0a, 0x0c, 0x41, 0x67,
+	0x65, 0x6e, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x50, 0x0a, 0x0f, 0x47, 0x65,
+	0x74, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1c, 0x2e,
+	0x6e, 0x77, 0x70, 0x64, 0x2e, 0x47, 0x65, 0x74, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x6e, 0x77,
+	0x70, 0x64, 0x2e, 0x47, 0x65, 0x74, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x64, 0x0a, 0x19,
+	0x47, 0x65, 0x74, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x64, 0x4f, 0x62, 0x73,
+	0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1c, 0x2e, 0x6e, 0x77, 0x70, 0x64,
+	0x2e, 0x47, 0x65, 0x74, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x6e, 0x77, 0x70, 0x64, 0x2e, 0x47,
+	0x65, 0x74, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x64, 0x4f, 0x62, 0x73, 0x65,
+	0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x3b, 0x0a, 0x08, 0x52, 0x75, 0x6e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x12, 0x15,
+	0x2e, 0x6e, 0x77, 0x70, 0x64, 0x2e, 0x52, 0x75, 0x6e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x6e, 0x77, 0x70, 0x64, 0x2e, 0x52, 0x75, 0x6e,
+	0x50, 0x72, 0x6f, 0x62, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x3e, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x2e, 0x6e,
+	0x77, 0x70, 0x64, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x6e, 0x77, 0x70, 0x64, 0x2e, 0x47, 0x65, 0x74, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42,
+	0x3e, 0x5a, 0x3c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x61,
+	0x72, 0x64, 0x65, 0x6e, 0x65, 0x72, 0x2f, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2d, 0x70,
+	0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x2d, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2f,
+	0x70, 0x6b, 0x67, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x6e, 0x77, 0x70, 0x64, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -779,46 +2237,94 @@ func file_pkg_common_nwpd_nwpd_proto_rawDescGZIP() []byte {
 	return file_pkg_common_nwpd_nwpd_proto_rawDescData
 }
 
-var file_pkg_common_nwpd_nwpd_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_pkg_common_nwpd_nwpd_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_pkg_common_nwpd_nwpd_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
 var file_pkg_common_nwpd_nwpd_proto_goTypes = []interface{}{
-	(*GetObservationsRequest)(nil),            // 0: nwpd.GetObservationsRequest
-	(*GetObservationsResponse)(nil),           // 1: nwpd.GetObservationsResponse
-	(*GetAggregatedObservationsResponse)(nil), // 2: nwpd.GetAggregatedObservationsResponse
-	(*AggregatedObservation)(nil),             // 3: nwpd.AggregatedObservation
-	(*Observation)(nil),                       // 4: nwpd.Observation
-	(*IntObservation)(nil),                    // 5: nwpd.IntObservation
-	(*Int64Arrays)(nil),                       // 6: nwpd.Int64Arrays
-	(*IntString)(nil),                         // 7: nwpd.IntString
-	nil,                                       // 8: nwpd.AggregatedObservation.JobsOkCountEntry
-	nil,                                       // 9: nwpd.AggregatedObservation.JobsNotOkCountEntry
-	nil,                                       // 10: nwpd.AggregatedObservation.MeanOkDurationEntry
-	(*timestamppb.Timestamp)(nil),             // 11: google.protobuf.Timestamp
-	(*durationpb.Duration)(nil),               // 12: google.protobuf.Duration
+	(FailureClass)(0),                         // 0: nwpd.FailureClass
+	(*GetObservationsRequest)(nil),            // 1: nwpd.GetObservationsRequest
+	(*GetObservationsResponse)(nil),           // 2: nwpd.GetObservationsResponse
+	(*RunProbeRequest)(nil),                   // 3: nwpd.RunProbeRequest
+	(*RunProbeResponse)(nil),                  // 4: nwpd.RunProbeResponse
+	(*GetStatusRequest)(nil),                  // 5: nwpd.GetStatusRequest
+	(*GetStatusResponse)(nil),                 // 6: nwpd.GetStatusResponse
+	(*JobStatus)(nil),                         // 7: nwpd.JobStatus
+	(*ValidEdges)(nil),                        // 8: nwpd.ValidEdges
+	(*GetAggregatedObservationsResponse)(nil), // 9: nwpd.GetAggregatedObservationsResponse
+	(*AggregatedObservation)(nil),             // 10: nwpd.AggregatedObservation
+	(*Observation)(nil),                       // 11: nwpd.Observation
+	(*PingStatistics)(nil),                    // 12: nwpd.PingStatistics
+	(*PhaseTimings)(nil),                      // 13: nwpd.PhaseTimings
+	(*IntObservation)(nil),                    // 14: nwpd.IntObservation
+	(*IntPhaseTimings)(nil),                   // 15: nwpd.IntPhaseTimings
+	(*IntPingStatistics)(nil),                 // 16: nwpd.IntPingStatistics
+	(*Int64Arrays)(nil),                       // 17: nwpd.Int64Arrays
+	(*IntString)(nil),                         // 18: nwpd.IntString
+	(*RecordFileIndex)(nil),                   // 19: nwpd.RecordFileIndex
+	(*RecordFileIndexEdge)(nil),               // 20: nwpd.RecordFileIndexEdge
+	(*RecordFileIndexSlice)(nil),              // 21: nwpd.RecordFileIndexSlice
+	nil,                                       // 22: nwpd.JobStatus.LabelsEntry
+	nil,                                       // 23: nwpd.AggregatedObservation.JobsOkCountEntry
+	nil,                                       // 24: nwpd.AggregatedObservation.JobsNotOkCountEntry
+	nil,                                       // 25: nwpd.AggregatedObservation.MeanOkDurationEntry
+	(*timestamppb.Timestamp)(nil),             // 26: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),               // 27: google.protobuf.Duration
 }
 var file_pkg_common_nwpd_nwpd_proto_depIdxs = []int32{
-	11, // 0: nwpd.GetObservationsRequest.start:type_name -> google.protobuf.Timestamp
-	11, // 1: nwpd.GetObservationsRequest.end:type_name -> google.protobuf.Timestamp
-	12, // 2: nwpd.GetObservationsRequest.aggregationWindow:type_name -> google.protobuf.Duration
-	4,  // 3: nwpd.GetObservationsResponse.observations:type_name -> nwpd.Observation
-	3,  // 4: nwpd.GetAggregatedObservationsResponse.aggregatedObservations:type_name -> nwpd.AggregatedObservation
-	11, // 5: nwpd.AggregatedObservation.periodStart:type_name -> google.protobuf.Timestamp
-	11, // 6: nwpd.AggregatedObservation.periodEnd:type_name -> google.protobuf.Timestamp
-	8,  // 7: nwpd.AggregatedObservation.jobsOkCount:type_name -> nwpd.AggregatedObservation.JobsOkCountEntry
-	9,  // 8: nwpd.AggregatedObservation.jobsNotOkCount:type_name -> nwpd.AggregatedObservation.JobsNotOkCountEntry
-	10, // 9: nwpd.AggregatedObservation.meanOkDuration:type_name -> nwpd.AggregatedObservation.MeanOkDurationEntry
-	11, // 10: nwpd.Observation.timestamp:type_name -> google.protobuf.Timestamp
-	12, // 11: nwpd.Observation.duration:type_name -> google.protobuf.Duration
-	12, // 12: nwpd.Observation.period:type_name -> google.protobuf.Duration
-	12, // 13: nwpd.AggregatedObservation.MeanOkDurationEntry.value:type_name -> google.protobuf.Duration
-	0,  // 14: nwpd.AgentService.GetObservations:input_type -> nwpd.GetObservationsRequest
-	0,  // 15: nwpd.AgentService.GetAggregatedObservations:input_type -> nwpd.GetObservationsRequest
-	1,  // 16: nwpd.AgentService.GetObservations:output_type -> nwpd.GetObservationsResponse
-	2,  // 17: nwpd.AgentService.GetAggregatedObservations:output_type -> nwpd.GetAggregatedObservationsResponse
-	16, // [16:18] is the sub-list for method output_type
-	14, // [14:16] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
+	26, // 0: nwpd.GetObservationsRequest.start:type_name -> google.protobuf.Timestamp
+	26, // 1: nwpd.GetObservationsRequest.end:type_name -> google.protobuf.Timestamp
+	27, // 2: nwpd.GetObservationsRequest.aggregationWindow:type_name -> google.protobuf.Duration
+	11, // 3: nwpd.GetObservationsResponse.observations:type_name -> nwpd.Observation
+	27, // 4: nwpd.RunProbeRequest.timeout:type_name -> google.protobuf.Duration
+	11, // 5: nwpd.RunProbeResponse.observations:type_name -> nwpd.Observation
+	26, // 6: nwpd.GetStatusResponse.configApplied:type_name -> google.protobuf.Timestamp
+	7,  // 7: nwpd.GetStatusResponse.jobs:type_name -> nwpd.JobStatus
+	8,  // 8: nwpd.GetStatusResponse.validEdges:type_name -> nwpd.ValidEdges
+	27, // 9: nwpd.JobStatus.period:type_name -> google.protobuf.Duration
+	26, // 10: nwpd.JobStatus.lastRun:type_name -> google.protobuf.Timestamp
+	11, // 11: nwpd.JobStatus.lastResults:type_name -> nwpd.Observation
+	22, // 12: nwpd.JobStatus.labels:type_name -> nwpd.JobStatus.LabelsEntry
+	10, // 13: nwpd.GetAggregatedObservationsResponse.aggregatedObservations:type_name -> nwpd.AggregatedObservation
+	26, // 14: nwpd.AggregatedObservation.periodStart:type_name -> google.protobuf.Timestamp
+	26, // 15: nwpd.AggregatedObservation.periodEnd:type_name -> google.protobuf.Timestamp
+	23, // 16: nwpd.AggregatedObservation.jobsOkCount:type_name -> nwpd.AggregatedObservation.JobsOkCountEntry
+	24, // 17: nwpd.AggregatedObservation.jobsNotOkCount:type_name -> nwpd.AggregatedObservation.JobsNotOkCountEntry
+	25, // 18: nwpd.AggregatedObservation.meanOkDuration:type_name -> nwpd.AggregatedObservation.MeanOkDurationEntry
+	26, // 19: nwpd.Observation.timestamp:type_name -> google.protobuf.Timestamp
+	27, // 20: nwpd.Observation.duration:type_name -> google.protobuf.Duration
+	27, // 21: nwpd.Observation.period:type_name -> google.protobuf.Duration
+	13, // 22: nwpd.Observation.phaseTimings:type_name -> nwpd.PhaseTimings
+	27, // 23: nwpd.Observation.certRemainingLifetime:type_name -> google.protobuf.Duration
+	27, // 24: nwpd.Observation.clockOffset:type_name -> google.protobuf.Duration
+	0,  // 25: nwpd.Observation.failureClass:type_name -> nwpd.FailureClass
+	12, // 26: nwpd.Observation.pingStatistics:type_name -> nwpd.PingStatistics
+	27, // 27: nwpd.PingStatistics.minRtt:type_name -> google.protobuf.Duration
+	27, // 28: nwpd.PingStatistics.avgRtt:type_name -> google.protobuf.Duration
+	27, // 29: nwpd.PingStatistics.maxRtt:type_name -> google.protobuf.Duration
+	27, // 30: nwpd.PingStatistics.mdevRtt:type_name -> google.protobuf.Duration
+	27, // 31: nwpd.PhaseTimings.dns:type_name -> google.protobuf.Duration
+	27, // 32: nwpd.PhaseTimings.connect:type_name -> google.protobuf.Duration
+	27, // 33: nwpd.PhaseTimings.tls:type_name -> google.protobuf.Duration
+	27, // 34: nwpd.PhaseTimings.ttfb:type_name -> google.protobuf.Duration
+	15, // 35: nwpd.IntObservation.phaseTimings:type_name -> nwpd.IntPhaseTimings
+	0,  // 36: nwpd.IntObservation.failureClass:type_name -> nwpd.FailureClass
+	16, // 37: nwpd.IntObservation.pingStatistics:type_name -> nwpd.IntPingStatistics
+	18, // 38: nwpd.RecordFileIndex.strings:type_name -> nwpd.IntString
+	20, // 39: nwpd.RecordFileIndex.edges:type_name -> nwpd.RecordFileIndexEdge
+	21, // 40: nwpd.RecordFileIndex.slices:type_name -> nwpd.RecordFileIndexSlice
+	27, // 41: nwpd.AggregatedObservation.MeanOkDurationEntry.value:type_name -> google.protobuf.Duration
+	1,  // 42: nwpd.AgentService.GetObservations:input_type -> nwpd.GetObservationsRequest
+	1,  // 43: nwpd.AgentService.GetAggregatedObservations:input_type -> nwpd.GetObservationsRequest
+	3,  // 44: nwpd.AgentService.RunProbe:input_type -> nwpd.RunProbeRequest
+	5,  // 45: nwpd.AgentService.GetStatus:input_type -> nwpd.GetStatusRequest
+	2,  // 46: nwpd.AgentService.GetObservations:output_type -> nwpd.GetObservationsResponse
+	9,  // 47: nwpd.AgentService.GetAggregatedObservations:output_type -> nwpd.GetAggregatedObservationsResponse
+	4,  // 48: nwpd.AgentService.RunProbe:output_type -> nwpd.RunProbeResponse
+	6,  // 49: nwpd.AgentService.GetStatus:output_type -> nwpd.GetStatusResponse
+	46, // [46:50] is the sub-list for method output_type
+	42, // [42:46] is the sub-list for method input_type
+	42, // [42:42] is the sub-list for extension type_name
+	42, // [42:42] is the sub-list for extension extendee
+	0,  // [0:42] is the sub-list for field type_name
 }
 
 func init() { file_pkg_common_nwpd_nwpd_proto_init() }
@@ -852,7 +2358,7 @@ func file_pkg_common_nwpd_nwpd_proto_init() {
 			}
 		}
 		file_pkg_common_nwpd_nwpd_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetAggregatedObservationsResponse); i {
+			switch v := v.(*RunProbeRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -864,7 +2370,7 @@ func file_pkg_common_nwpd_nwpd_proto_init() {
 			}
 		}
 		file_pkg_common_nwpd_nwpd_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AggregatedObservation); i {
+			switch v := v.(*RunProbeResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -876,7 +2382,7 @@ func file_pkg_common_nwpd_nwpd_proto_init() {
 			}
 		}
 		file_pkg_common_nwpd_nwpd_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Observation); i {
+			switch v := v.(*GetStatusRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -888,7 +2394,7 @@ func file_pkg_common_nwpd_nwpd_proto_init() {
 			}
 		}
 		file_pkg_common_nwpd_nwpd_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IntObservation); i {
+			switch v := v.(*GetStatusResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -900,7 +2406,7 @@ func file_pkg_common_nwpd_nwpd_proto_init() {
 			}
 		}
 		file_pkg_common_nwpd_nwpd_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Int64Arrays); i {
+			switch v := v.(*JobStatus); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -912,6 +2418,126 @@ func file_pkg_common_nwpd_nwpd_proto_init() {
 			}
 		}
 		file_pkg_common_nwpd_nwpd_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ValidEdges); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_common_nwpd_nwpd_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetAggregatedObservationsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_common_nwpd_nwpd_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AggregatedObservation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_common_nwpd_nwpd_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Observation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_common_nwpd_nwpd_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PingStatistics); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_common_nwpd_nwpd_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PhaseTimings); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_common_nwpd_nwpd_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IntObservation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_common_nwpd_nwpd_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IntPhaseTimings); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_common_nwpd_nwpd_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IntPingStatistics); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_common_nwpd_nwpd_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Int64Arrays); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_common_nwpd_nwpd_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*IntString); i {
 			case 0:
 				return &v.state
@@ -923,19 +2549,56 @@ func file_pkg_common_nwpd_nwpd_proto_init() {
 				return nil
 			}
 		}
+		file_pkg_common_nwpd_nwpd_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RecordFileIndex); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_common_nwpd_nwpd_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RecordFileIndexEdge); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_common_nwpd_nwpd_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RecordFileIndexSlice); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_pkg_common_nwpd_nwpd_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   11,
+			NumEnums:      1,
+			NumMessages:   25,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_pkg_common_nwpd_nwpd_proto_goTypes,
 		DependencyIndexes: file_pkg_common_nwpd_nwpd_proto_depIdxs,
+		EnumInfos:         file_pkg_common_nwpd_nwpd_proto_enumTypes,
 		MessageInfos:      file_pkg_common_nwpd_nwpd_proto_msgTypes,
 	}.Build()
 	File_pkg_common_nwpd_nwpd_proto = out.File
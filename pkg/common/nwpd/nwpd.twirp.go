@@ -49,6 +49,10 @@ type AgentService interface {
 	GetObservations(context.Context, *GetObservationsRequest) (*GetObservationsResponse, error)
 
 	GetAggregatedObservations(context.Context, *GetObservationsRequest) (*GetAggregatedObservationsResponse, error)
+
+	RunProbe(context.Context, *RunProbeRequest) (*RunProbeResponse, error)
+
+	GetStatus(context.Context, *GetStatusRequest) (*GetStatusResponse, error)
 }
 
 // ============================
@@ -57,7 +61,7 @@ type AgentService interface {
 
 type agentServiceProtobufClient struct {
 	client      HTTPClient
-	urls        [2]string
+	urls        [4]string
 	interceptor twirp.Interceptor
 	opts        twirp.ClientOptions
 }
@@ -85,9 +89,11 @@ func NewAgentServiceProtobufClient(baseURL string, client HTTPClient, opts ...tw
 	// Build method URLs: <baseURL>[<prefix>]/<package>.<Service>/<Method>
 	serviceURL := sanitizeBaseURL(baseURL)
 	serviceURL += baseServicePath(pathPrefix, "nwpd", "AgentService")
-	urls := [2]string{
+	urls := [4]string{
 		serviceURL + "GetObservations",
 		serviceURL + "GetAggregatedObservations",
+		serviceURL + "RunProbe",
+		serviceURL + "GetStatus",
 	}
 
 	return &agentServiceProtobufClient{
@@ -190,13 +196,105 @@ func (c *agentServiceProtobufClient) callGetAggregatedObservations(ctx context.C
 	return out, nil
 }
 
+func (c *agentServiceProtobufClient) RunProbe(ctx context.Context, in *RunProbeRequest) (*RunProbeResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "nwpd")
+	ctx = ctxsetters.WithServiceName(ctx, "AgentService")
+	ctx = ctxsetters.WithMethodName(ctx, "RunProbe")
+	caller := c.callRunProbe
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *RunProbeRequest) (*RunProbeResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*RunProbeRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*RunProbeRequest) when calling interceptor")
+					}
+					return c.callRunProbe(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*RunProbeResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*RunProbeResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *agentServiceProtobufClient) callRunProbe(ctx context.Context, in *RunProbeRequest) (*RunProbeResponse, error) {
+	out := new(RunProbeResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[2], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *agentServiceProtobufClient) GetStatus(ctx context.Context, in *GetStatusRequest) (*GetStatusResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "nwpd")
+	ctx = ctxsetters.WithServiceName(ctx, "AgentService")
+	ctx = ctxsetters.WithMethodName(ctx, "GetStatus")
+	caller := c.callGetStatus
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetStatusRequest) (*GetStatusResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetStatusRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetStatusRequest) when calling interceptor")
+					}
+					return c.callGetStatus(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetStatusResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetStatusResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *agentServiceProtobufClient) callGetStatus(ctx context.Context, in *GetStatusRequest) (*GetStatusResponse, error) {
+	out := new(GetStatusResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[3], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
 // ========================
 // AgentService JSON Client
 // ========================
 
 type agentServiceJSONClient struct {
 	client      HTTPClient
-	urls        [2]string
+	urls        [4]string
 	interceptor twirp.Interceptor
 	opts        twirp.ClientOptions
 }
@@ -224,9 +322,11 @@ func NewAgentServiceJSONClient(baseURL string, client HTTPClient, opts ...twirp.
 	// Build method URLs: <baseURL>[<prefix>]/<package>.<Service>/<Method>
 	serviceURL := sanitizeBaseURL(baseURL)
 	serviceURL += baseServicePath(pathPrefix, "nwpd", "AgentService")
-	urls := [2]string{
+	urls := [4]string{
 		serviceURL + "GetObservations",
 		serviceURL + "GetAggregatedObservations",
+		serviceURL + "RunProbe",
+		serviceURL + "GetStatus",
 	}
 
 	return &agentServiceJSONClient{
@@ -329,6 +429,98 @@ func (c *agentServiceJSONClient) callGetAggregatedObservations(ctx context.Conte
 	return out, nil
 }
 
+func (c *agentServiceJSONClient) RunProbe(ctx context.Context, in *RunProbeRequest) (*RunProbeResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "nwpd")
+	ctx = ctxsetters.WithServiceName(ctx, "AgentService")
+	ctx = ctxsetters.WithMethodName(ctx, "RunProbe")
+	caller := c.callRunProbe
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *RunProbeRequest) (*RunProbeResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*RunProbeRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*RunProbeRequest) when calling interceptor")
+					}
+					return c.callRunProbe(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*RunProbeResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*RunProbeResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *agentServiceJSONClient) callRunProbe(ctx context.Context, in *RunProbeRequest) (*RunProbeResponse, error) {
+	out := new(RunProbeResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[2], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *agentServiceJSONClient) GetStatus(ctx context.Context, in *GetStatusRequest) (*GetStatusResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "nwpd")
+	ctx = ctxsetters.WithServiceName(ctx, "AgentService")
+	ctx = ctxsetters.WithMethodName(ctx, "GetStatus")
+	caller := c.callGetStatus
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetStatusRequest) (*GetStatusResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetStatusRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetStatusRequest) when calling interceptor")
+					}
+					return c.callGetStatus(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetStatusResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetStatusResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *agentServiceJSONClient) callGetStatus(ctx context.Context, in *GetStatusRequest) (*GetStatusResponse, error) {
+	out := new(GetStatusResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[3], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
 // ===========================
 // AgentService Server Handler
 // ===========================
@@ -432,6 +624,12 @@ func (s *agentServiceServer) ServeHTTP(resp http.ResponseWriter, req *http.Reque
 	case "GetAggregatedObservations":
 		s.serveGetAggregatedObservations(ctx, resp, req)
 		return
+	case "RunProbe":
+		s.serveRunProbe(ctx, resp, req)
+		return
+	case "GetStatus":
+		s.serveGetStatus(ctx, resp, req)
+		return
 	default:
 		msg := fmt.Sprintf("no handler for path %q", req.URL.Path)
 		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
@@ -799,6 +997,366 @@ func (s *agentServiceServer) serveGetAggregatedObservationsProtobuf(ctx context.
 	callResponseSent(ctx, s.hooks)
 }
 
+func (s *agentServiceServer) serveRunProbe(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveRunProbeJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveRunProbeProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *agentServiceServer) serveRunProbeJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "RunProbe")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(RunProbeRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.AgentService.RunProbe
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *RunProbeRequest) (*RunProbeResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*RunProbeRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*RunProbeRequest) when calling interceptor")
+					}
+					return s.AgentService.RunProbe(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*RunProbeResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*RunProbeResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *RunProbeResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *RunProbeResponse and nil error while calling RunProbe. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *agentServiceServer) serveRunProbeProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "RunProbe")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(RunProbeRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.AgentService.RunProbe
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *RunProbeRequest) (*RunProbeResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*RunProbeRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*RunProbeRequest) when calling interceptor")
+					}
+					return s.AgentService.RunProbe(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*RunProbeResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*RunProbeResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *RunProbeResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *RunProbeResponse and nil error while calling RunProbe. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *agentServiceServer) serveGetStatus(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveGetStatusJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveGetStatusProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *agentServiceServer) serveGetStatusJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetStatus")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(GetStatusRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.AgentService.GetStatus
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetStatusRequest) (*GetStatusResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetStatusRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetStatusRequest) when calling interceptor")
+					}
+					return s.AgentService.GetStatus(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetStatusResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetStatusResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *GetStatusResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GetStatusResponse and nil error while calling GetStatus. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *agentServiceServer) serveGetStatusProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetStatus")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(GetStatusRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.AgentService.GetStatus
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetStatusRequest) (*GetStatusResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetStatusRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetStatusRequest) when calling interceptor")
+					}
+					return s.AgentService.GetStatus(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetStatusResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetStatusResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *GetStatusResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GetStatusResponse and nil error while calling GetStatus. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
 func (s *agentServiceServer) ServiceDescriptor() ([]byte, int) {
 	return twirpFileDescriptor0, 0
 }
@@ -1380,58 +1938,133 @@ func callClientError(ctx context.Context, h *twirp.ClientHooks, err twirp.Error)
 }
 
 var twirpFileDescriptor0 = []byte{
-	// 834 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0xe1, 0x8e, 0xdb, 0x44,
-	0x10, 0xae, 0xe3, 0x73, 0x2e, 0x99, 0x9c, 0x8e, 0xeb, 0x5e, 0x39, 0x5c, 0x03, 0x25, 0x18, 0x09,
-	0x22, 0xd4, 0xda, 0xe5, 0x4a, 0xd1, 0x09, 0x55, 0x95, 0x02, 0x57, 0x1d, 0x39, 0xa9, 0x4d, 0xb5,
-	0xa9, 0x54, 0x09, 0xf1, 0xc7, 0x89, 0xb7, 0xc6, 0x8d, 0xbd, 0x1b, 0x76, 0xd7, 0x77, 0xe4, 0x11,
-	0x78, 0x10, 0x24, 0x9e, 0x84, 0x67, 0xe0, 0x71, 0x90, 0x77, 0x9d, 0xc4, 0x71, 0x9c, 0x04, 0xfe,
-	0x44, 0x3b, 0x33, 0xdf, 0x7c, 0xbb, 0x33, 0xfb, 0xed, 0x38, 0xe0, 0xcc, 0xa6, 0x91, 0x3f, 0x61,
-	0x69, 0xca, 0xa8, 0x4f, 0x6f, 0x67, 0xa1, 0xfa, 0xf1, 0x66, 0x9c, 0x49, 0x86, 0x0e, 0xf2, 0xb5,
-	0xf3, 0x59, 0xc4, 0x58, 0x94, 0x10, 0x5f, 0xf9, 0xc6, 0xd9, 0x3b, 0x5f, 0xc6, 0x29, 0x11, 0x32,
-	0x48, 0x67, 0x1a, 0xe6, 0x3c, 0xa8, 0x02, 0xc2, 0x8c, 0x07, 0x32, 0x66, 0x54, 0xc7, 0xdd, 0x3f,
-	0x4c, 0x38, 0xbb, 0x22, 0x72, 0x38, 0x16, 0x84, 0xdf, 0xa8, 0x80, 0xc0, 0xe4, 0xb7, 0x8c, 0x08,
-	0x89, 0x1e, 0x83, 0x25, 0x64, 0xc0, 0xa5, 0x6d, 0x74, 0x8d, 0x5e, 0xe7, 0xdc, 0xf1, 0x34, 0x95,
-	0xb7, 0xa0, 0xf2, 0xde, 0x2c, 0xf6, 0xc2, 0x1a, 0x88, 0x1e, 0x82, 0x49, 0x68, 0x68, 0x37, 0xf6,
-	0xe2, 0x73, 0x18, 0xba, 0x07, 0x56, 0x12, 0xa7, 0xb1, 0xb4, 0xcd, 0xae, 0xd1, 0xb3, 0xb0, 0x36,
-	0xd0, 0xd7, 0x70, 0xc2, 0x89, 0x90, 0x3c, 0x9e, 0xc8, 0x37, 0xec, 0x9a, 0x8d, 0x07, 0x97, 0xc2,
-	0x3e, 0xe8, 0x9a, 0xbd, 0x36, 0xde, 0xf0, 0x23, 0x0f, 0xd0, 0xca, 0x37, 0xe2, 0x93, 0x9f, 0x98,
-	0x90, 0xc2, 0xb6, 0x14, 0xba, 0x26, 0x82, 0x1e, 0xc3, 0xe9, 0xca, 0x7b, 0x49, 0x84, 0xd4, 0x09,
-	0x4d, 0x95, 0x50, 0x17, 0x42, 0x57, 0x70, 0x37, 0x88, 0x22, 0x4e, 0x22, 0xd5, 0x9a, 0xb7, 0x31,
-	0x0d, 0xd9, 0xad, 0x7d, 0xa8, 0xea, 0xbb, 0xbf, 0x51, 0xdf, 0x65, 0xd1, 0x5a, 0xbc, 0x99, 0x83,
-	0x5c, 0x38, 0x7a, 0x17, 0xc4, 0x49, 0xc6, 0x89, 0x18, 0xd2, 0x64, 0x6e, 0xb7, 0xba, 0x46, 0xaf,
-	0x85, 0xd7, 0x7c, 0xee, 0x6b, 0xf8, 0x68, 0xe3, 0x2a, 0xc4, 0x8c, 0x51, 0x41, 0xd0, 0x53, 0x38,
-	0x62, 0x25, 0xbf, 0x6d, 0x74, 0xcd, 0x5e, 0xe7, 0xfc, 0xae, 0xa7, 0x04, 0x51, 0xca, 0xc0, 0x6b,
-	0x30, 0xf7, 0x77, 0xf8, 0xfc, 0x8a, 0xc8, 0x7e, 0x71, 0x1a, 0x12, 0xd6, 0x72, 0x8f, 0xe0, 0x2c,
-	0xa8, 0x45, 0x14, 0xbb, 0x7c, 0xac, 0x77, 0xa9, 0x65, 0xc1, 0x5b, 0x52, 0xdd, 0xbf, 0x2c, 0xf8,
-	0xb0, 0x36, 0x03, 0xd9, 0x70, 0x28, 0xf4, 0x85, 0x28, 0x61, 0xb5, 0xf1, 0xc2, 0x44, 0x0e, 0xb4,
-	0xc2, 0xa2, 0xf3, 0x4a, 0x43, 0x6d, 0xbc, 0xb4, 0xd1, 0x33, 0xe8, 0xcc, 0x08, 0x8f, 0x59, 0x38,
-	0x52, 0x92, 0x34, 0xf7, 0x4a, 0xac, 0x0c, 0x47, 0x17, 0xd0, 0xd6, 0xe6, 0x0b, 0x1a, 0xda, 0x07,
-	0x7b, 0x73, 0x57, 0x60, 0xf4, 0x0a, 0x3a, 0xef, 0xd9, 0x58, 0x0c, 0xa7, 0x3f, 0xb2, 0x8c, 0x4a,
-	0xa5, 0xad, 0xce, 0xf9, 0xc3, 0x1d, 0x1d, 0xf1, 0xae, 0x57, 0xf0, 0x17, 0x54, 0xf2, 0x39, 0x2e,
-	0x13, 0xa0, 0xb7, 0x70, 0x9c, 0x9b, 0xaf, 0x98, 0x5c, 0x50, 0x36, 0x15, 0xa5, 0xbf, 0x8f, 0x72,
-	0x95, 0xa1, 0x59, 0x2b, 0x34, 0x39, 0x71, 0x4a, 0x02, 0x3a, 0x9c, 0x2e, 0x54, 0x68, 0x1f, 0xee,
-	0x27, 0x7e, 0xb9, 0x96, 0x51, 0x10, 0xaf, 0xd3, 0x38, 0xcf, 0xe1, 0xa4, 0x5a, 0x12, 0x3a, 0x01,
-	0x73, 0x4a, 0xe6, 0xc5, 0xfd, 0xe5, 0xcb, 0xfc, 0x31, 0xdf, 0x04, 0x49, 0x46, 0xd4, 0xc5, 0x59,
-	0x58, 0x1b, 0xdf, 0x37, 0x2e, 0x0c, 0xa7, 0x0f, 0xa7, 0x35, 0xe7, 0xff, 0x5f, 0x14, 0xbf, 0xc0,
-	0x69, 0xcd, 0x49, 0x6b, 0x28, 0xfc, 0x32, 0xc5, 0xce, 0x27, 0xba, 0x62, 0x77, 0xff, 0x6c, 0x40,
-	0xa7, 0x2c, 0xd0, 0x7b, 0x60, 0xbd, 0xcf, 0xe7, 0x4b, 0x41, 0xac, 0x8d, 0xb2, 0x6c, 0x1b, 0xdb,
-	0x65, 0x6b, 0x56, 0x64, 0x7b, 0x01, 0xed, 0xe5, 0x44, 0xfe, 0x2f, 0xc2, 0x5b, 0x82, 0xd1, 0x53,
-	0x68, 0x2d, 0x46, 0xb5, 0x6d, 0xed, 0xab, 0x66, 0x09, 0x45, 0x67, 0xd0, 0xe4, 0x44, 0x64, 0x49,
-	0xae, 0xab, 0xfc, 0x28, 0x85, 0x85, 0x8e, 0xa1, 0xc1, 0xa6, 0x6a, 0x72, 0xb5, 0x70, 0x83, 0x4d,
-	0xd1, 0x37, 0xd0, 0xd4, 0x22, 0x57, 0x93, 0x68, 0x27, 0x79, 0x01, 0x74, 0xff, 0x31, 0xe0, 0x78,
-	0x40, 0x65, 0xa5, 0x55, 0xd7, 0xcb, 0x56, 0x99, 0x58, 0x1b, 0xd5, 0x56, 0x99, 0xdb, 0x5b, 0x65,
-	0x96, 0x5a, 0xf5, 0x00, 0x20, 0xaf, 0xfe, 0x65, 0x9c, 0x24, 0xb1, 0x50, 0xbd, 0x32, 0x71, 0xc9,
-	0x83, 0xbe, 0x84, 0xe3, 0x45, 0x95, 0x05, 0xc6, 0x52, 0x3a, 0xa9, 0x78, 0x8b, 0x4a, 0x9b, 0xcb,
-	0x4a, 0x5d, 0x38, 0xd2, 0x05, 0x14, 0x59, 0x87, 0x2a, 0x6b, 0xcd, 0xe7, 0x7e, 0x01, 0x9d, 0x01,
-	0x95, 0xdf, 0x7d, 0xdb, 0xe7, 0x3c, 0x98, 0x8b, 0xbc, 0xac, 0x20, 0x5f, 0xa9, 0x01, 0x68, 0x62,
-	0x6d, 0xb8, 0x4f, 0xa0, 0x3d, 0xa0, 0x72, 0x24, 0x79, 0x4c, 0xa3, 0xb2, 0xf6, 0xcc, 0x1a, 0xf9,
-	0xb6, 0x0b, 0x81, 0x9d, 0xff, 0x6d, 0xc0, 0x51, 0x3f, 0x22, 0x54, 0x8e, 0x08, 0xbf, 0x89, 0x27,
-	0x04, 0xbd, 0x86, 0x0f, 0x2a, 0x43, 0x1e, 0x7d, 0xa2, 0x9f, 0x68, 0xfd, 0x67, 0xd8, 0xf9, 0x74,
-	0x4b, 0x54, 0x4f, 0x6f, 0xf7, 0x0e, 0x0a, 0xe1, 0xfe, 0xd6, 0x21, 0xbf, 0x87, 0xfb, 0xab, 0x65,
-	0x74, 0xf7, 0x37, 0xc2, 0xbd, 0xf3, 0xc3, 0xf3, 0x9f, 0x9f, 0x45, 0xb1, 0xfc, 0x35, 0x1b, 0x7b,
-	0x13, 0x96, 0xfa, 0x51, 0xc0, 0x43, 0x42, 0x09, 0xf7, 0x29, 0x91, 0xb7, 0x8c, 0x4f, 0x1f, 0xcd,
-	0x38, 0x1b, 0x27, 0x24, 0x7d, 0x14, 0x12, 0x49, 0x26, 0x92, 0x71, 0xbf, 0xf2, 0xd7, 0x65, 0xdc,
-	0x54, 0xc2, 0x7a, 0xf2, 0x6f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x28, 0xf2, 0x96, 0x1c, 0xd4, 0x08,
-	0x00, 0x00,
+	// 2043 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0xcf, 0x6f, 0xdb, 0xc8,
+	0x15, 0x0e, 0x45, 0xfd, 0x7c, 0x72, 0x6c, 0x79, 0x12, 0x3b, 0x8c, 0x76, 0x37, 0xab, 0x72, 0x17,
+	0xad, 0xbb, 0xdd, 0xd8, 0xa9, 0xbd, 0xbb, 0xc8, 0x76, 0x83, 0xb4, 0x8a, 0x2d, 0xdb, 0x4a, 0x6d,
+	0xc9, 0x18, 0xc9, 0x5d, 0xa0, 0x28, 0x60, 0x50, 0xe4, 0x58, 0x66, 0x44, 0xcd, 0xa8, 0xe4, 0xc8,
+	0x71, 0x7a, 0xee, 0xa5, 0x40, 0x6f, 0x3d, 0xf7, 0xde, 0x63, 0xef, 0x3d, 0x15, 0xed, 0xb1, 0xe8,
+	0xb5, 0x7f, 0x43, 0xcf, 0xfd, 0x07, 0x8a, 0x99, 0x21, 0x25, 0x92, 0x92, 0xcd, 0x14, 0xdd, 0x8b,
+	0xe1, 0xf7, 0xcd, 0xf7, 0x1e, 0x67, 0xde, 0xbc, 0xf7, 0x69, 0x66, 0xa0, 0x3e, 0x19, 0x0d, 0x77,
+	0x6c, 0x36, 0x1e, 0x33, 0xba, 0x43, 0xdf, 0x4e, 0x1c, 0xf9, 0x67, 0x7b, 0xe2, 0x33, 0xce, 0x50,
+	0x5e, 0xfc, 0x5f, 0xff, 0x78, 0xc8, 0xd8, 0xd0, 0x23, 0x3b, 0x12, 0x1b, 0x4c, 0x2f, 0x77, 0xb8,
+	0x3b, 0x26, 0x01, 0xb7, 0xc6, 0x13, 0x45, 0xab, 0x3f, 0x49, 0x13, 0x9c, 0xa9, 0x6f, 0x71, 0x97,
+	0x51, 0x35, 0x6e, 0xfe, 0x4e, 0x87, 0xcd, 0x23, 0xc2, 0xbb, 0x83, 0x80, 0xf8, 0xd7, 0x72, 0x20,
+	0xc0, 0xe4, 0xd7, 0x53, 0x12, 0x70, 0xf4, 0x0c, 0x0a, 0x01, 0xb7, 0x7c, 0x6e, 0x68, 0x0d, 0x6d,
+	0xab, 0xba, 0x5b, 0xdf, 0x56, 0xa1, 0xb6, 0xa3, 0x50, 0xdb, 0xfd, 0xe8, 0x5b, 0x58, 0x11, 0xd1,
+	0xe7, 0xa0, 0x13, 0xea, 0x18, 0xb9, 0x4c, 0xbe, 0xa0, 0xa1, 0x87, 0x50, 0xf0, 0xdc, 0xb1, 0xcb,
+	0x0d, 0xbd, 0xa1, 0x6d, 0x15, 0xb0, 0x32, 0xd0, 0x67, 0x50, 0xf3, 0x49, 0xc0, 0x7d, 0xd7, 0xe6,
+	0x7d, 0xf6, 0x9a, 0x0d, 0xda, 0x07, 0x81, 0x91, 0x6f, 0xe8, 0x5b, 0x15, 0xbc, 0x80, 0xa3, 0x6d,
+	0x40, 0x73, 0xac, 0xe7, 0xdb, 0xc7, 0x2c, 0xe0, 0x81, 0x51, 0x90, 0xec, 0x25, 0x23, 0xe8, 0x19,
+	0x3c, 0x98, 0xa3, 0x07, 0x24, 0xe0, 0xca, 0xa1, 0x28, 0x1d, 0x96, 0x0d, 0xa1, 0x23, 0x58, 0xb7,
+	0x86, 0x43, 0x9f, 0x0c, 0x65, 0x6a, 0xbe, 0x75, 0xa9, 0xc3, 0xde, 0x1a, 0x25, 0xb9, 0xbe, 0xc7,
+	0x0b, 0xeb, 0x3b, 0x08, 0x53, 0x8b, 0x17, 0x7d, 0x90, 0x09, 0x2b, 0x97, 0x96, 0xeb, 0x4d, 0x7d,
+	0x12, 0x74, 0xa9, 0xf7, 0xce, 0x28, 0x37, 0xb4, 0xad, 0x32, 0x4e, 0x60, 0xe6, 0x19, 0x3c, 0x5a,
+	0xd8, 0x8a, 0x60, 0xc2, 0x68, 0x40, 0xd0, 0x97, 0xb0, 0xc2, 0x62, 0xb8, 0xa1, 0x35, 0xf4, 0xad,
+	0xea, 0xee, 0xfa, 0xb6, 0x2c, 0x88, 0x98, 0x07, 0x4e, 0xd0, 0xcc, 0xdf, 0x6b, 0xb0, 0x86, 0xa7,
+	0xf4, 0xcc, 0x67, 0x03, 0x12, 0x6d, 0x2b, 0x82, 0xbc, 0xe5, 0x0f, 0x55, 0x88, 0x0a, 0x96, 0xff,
+	0xdf, 0x96, 0x98, 0xdc, 0xed, 0x89, 0xd9, 0x83, 0x92, 0x28, 0x35, 0x36, 0x55, 0xdb, 0x77, 0x67,
+	0x3a, 0x22, 0xa6, 0xc9, 0xa0, 0x36, 0x9f, 0xcd, 0xff, 0xb5, 0x32, 0xf4, 0x29, 0xdc, 0x9f, 0x10,
+	0xea, 0xb8, 0x74, 0xb8, 0x7f, 0x45, 0xec, 0x51, 0x20, 0x8b, 0xae, 0x80, 0x93, 0xa0, 0x89, 0xa0,
+	0x76, 0x44, 0x78, 0x8f, 0x5b, 0x7c, 0x1a, 0x95, 0xb5, 0xf9, 0xe7, 0x1c, 0xac, 0xc7, 0xc0, 0x70,
+	0x1a, 0x06, 0x94, 0xae, 0x89, 0x1f, 0xb8, 0x8c, 0xca, 0x72, 0xaf, 0xe0, 0xc8, 0x44, 0x75, 0x28,
+	0x53, 0xe6, 0x90, 0x8e, 0x35, 0x26, 0xf2, 0x23, 0x15, 0x3c, 0xb3, 0x51, 0x03, 0xaa, 0x57, 0x2c,
+	0xe0, 0x1d, 0xc2, 0xdf, 0x32, 0x7f, 0x24, 0x33, 0x51, 0xc6, 0x71, 0x48, 0x94, 0xb3, 0xcd, 0xe8,
+	0xa5, 0x3b, 0x3c, 0x22, 0x94, 0xa8, 0x7c, 0x18, 0xf9, 0x86, 0xb6, 0xa5, 0xe3, 0x05, 0x1c, 0xfd,
+	0x0c, 0xee, 0x2b, 0xac, 0x39, 0x99, 0x78, 0x2e, 0x71, 0x8c, 0x42, 0x66, 0x23, 0x25, 0x1d, 0xd0,
+	0x27, 0x90, 0x7f, 0xc3, 0x06, 0xaa, 0xa2, 0xab, 0xbb, 0x6b, 0x2a, 0x89, 0xaf, 0xd9, 0x20, 0x5c,
+	0xac, 0x1c, 0x44, 0xcf, 0x00, 0xae, 0x2d, 0xcf, 0x75, 0x5a, 0xce, 0x90, 0x04, 0x61, 0x31, 0xd7,
+	0x14, 0xf5, 0x17, 0x33, 0x1c, 0xc7, 0x38, 0xe6, 0x5f, 0x75, 0xa8, 0xcc, 0xa2, 0x88, 0xbe, 0x7d,
+	0x23, 0xfa, 0x2f, 0x4c, 0x94, 0x32, 0x66, 0x65, 0x95, 0x8b, 0x95, 0x55, 0x03, 0xaa, 0x0e, 0x09,
+	0x6c, 0xdf, 0x9d, 0xc8, 0x75, 0xeb, 0x92, 0x1f, 0x87, 0xd0, 0x87, 0x50, 0x71, 0x66, 0xe5, 0xa6,
+	0xda, 0x7c, 0x0e, 0xa0, 0x1f, 0x43, 0x71, 0x42, 0x7c, 0x97, 0x45, 0x99, 0xb8, 0xa3, 0xc6, 0x42,
+	0x22, 0xfa, 0x02, 0x4a, 0x9e, 0x15, 0x70, 0x3c, 0xa5, 0x46, 0x31, 0x33, 0x7b, 0x11, 0x15, 0x6d,
+	0x42, 0xd1, 0xb2, 0xb9, 0x7b, 0x4d, 0x64, 0x3a, 0xca, 0x38, 0xb4, 0x54, 0x95, 0x11, 0xbf, 0xc3,
+	0x1c, 0xb2, 0xcf, 0xa6, 0x94, 0x1b, 0xe5, 0xa8, 0xca, 0x62, 0x20, 0xda, 0x83, 0xaa, 0x0c, 0x44,
+	0x82, 0xa9, 0xc7, 0x03, 0xa3, 0x72, 0x5b, 0x05, 0xc7, 0x59, 0x68, 0x0f, 0x8a, 0x9e, 0x35, 0x20,
+	0x5e, 0x60, 0x80, 0xe4, 0x7f, 0x90, 0xda, 0xac, 0xed, 0x13, 0x39, 0xda, 0xa2, 0xdc, 0x7f, 0x87,
+	0x43, 0x6a, 0xfd, 0x6b, 0xa8, 0xc6, 0x60, 0x54, 0x03, 0x7d, 0x44, 0xde, 0x85, 0xfb, 0x20, 0xfe,
+	0x15, 0x7b, 0x73, 0x6d, 0x79, 0xd3, 0xa8, 0x52, 0x95, 0xf1, 0x93, 0xdc, 0x73, 0xcd, 0xfc, 0xad,
+	0x06, 0x30, 0xdf, 0x5e, 0xb1, 0xe2, 0x37, 0x4a, 0x5c, 0x95, 0x0e, 0x84, 0x96, 0xa8, 0xf6, 0x20,
+	0x12, 0x52, 0xb5, 0x95, 0x33, 0x3b, 0xb9, 0x59, 0x7a, 0x7a, 0xb3, 0x16, 0x72, 0x95, 0x5f, 0x92,
+	0x2b, 0xf3, 0x06, 0xbe, 0x77, 0x44, 0x78, 0x33, 0xd4, 0x47, 0xe2, 0x2c, 0x55, 0xbb, 0x1e, 0x6c,
+	0x5a, 0x4b, 0x19, 0x86, 0x16, 0xcf, 0xd5, 0xd2, 0x28, 0xf8, 0x16, 0x57, 0xf3, 0x4f, 0x05, 0xd8,
+	0x58, 0xea, 0x21, 0x7a, 0x3f, 0x5c, 0x63, 0xd4, 0xfb, 0xa1, 0x29, 0xb2, 0x11, 0x2d, 0x30, 0xea,
+	0xfd, 0xc8, 0x46, 0x2f, 0xa0, 0xaa, 0x6a, 0xae, 0x27, 0x7f, 0x24, 0xf5, 0xcc, 0x6a, 0x8b, 0xd3,
+	0xd1, 0x73, 0xa8, 0x28, 0xb3, 0x45, 0x1d, 0x23, 0x9f, 0xe9, 0x3b, 0x27, 0xa3, 0x0e, 0x54, 0x45,
+	0x1b, 0x77, 0x47, 0x2a, 0xcb, 0x05, 0x99, 0x91, 0xcf, 0xef, 0xc8, 0xc8, 0xf6, 0xeb, 0x39, 0x5d,
+	0x95, 0x53, 0x3c, 0x00, 0xfa, 0x16, 0x56, 0x85, 0xd9, 0x61, 0x3c, 0x0a, 0xa9, 0xd4, 0x63, 0x27,
+	0x2b, 0xe4, 0xdc, 0x43, 0x45, 0x4d, 0x85, 0x11, 0x81, 0xc7, 0xc4, 0xa2, 0xdd, 0x51, 0xd4, 0xa4,
+	0x46, 0x29, 0x3b, 0xf0, 0x69, 0xc2, 0x23, 0x0c, 0x9c, 0x0c, 0x53, 0x7f, 0x09, 0xb5, 0xf4, 0x92,
+	0xb2, 0x5a, 0xa1, 0x10, 0x6b, 0x85, 0x7a, 0x13, 0x1e, 0x2c, 0x99, 0xff, 0xff, 0x14, 0xe2, 0x57,
+	0xf0, 0x60, 0xc9, 0x4c, 0x97, 0x84, 0xd8, 0x89, 0x87, 0xb8, 0x53, 0xc1, 0x62, 0xbd, 0xfa, 0x9f,
+	0x02, 0x54, 0xe3, 0x05, 0xba, 0x5c, 0x71, 0x63, 0x65, 0x9b, 0xbb, 0xbd, 0x6c, 0xf5, 0x54, 0xd9,
+	0x3e, 0x87, 0xca, 0xec, 0x8c, 0xf8, 0x3e, 0x85, 0x37, 0x23, 0xa3, 0x2f, 0xa1, 0x1c, 0x1d, 0x1e,
+	0xb3, 0xf5, 0x78, 0x46, 0x15, 0x4a, 0xe3, 0x4b, 0xcd, 0x93, 0x82, 0x5c, 0xc1, 0xa1, 0x85, 0x56,
+	0x21, 0xc7, 0x46, 0xa1, 0xde, 0xe6, 0xd8, 0x28, 0x26, 0xf6, 0xe5, 0xf7, 0x15, 0x7b, 0x03, 0x4a,
+	0x13, 0x8b, 0x5f, 0x9d, 0xf6, 0xcf, 0x8d, 0x8a, 0xdc, 0xa1, 0xc8, 0x44, 0x5f, 0xc1, 0xca, 0xe4,
+	0xca, 0x0a, 0x48, 0xdf, 0x1d, 0xbb, 0x74, 0x28, 0x34, 0x56, 0x84, 0x44, 0xaa, 0xf2, 0xce, 0x62,
+	0x23, 0x38, 0xc1, 0x43, 0x5d, 0xd8, 0xb0, 0x89, 0xcf, 0x31, 0x19, 0x5b, 0x2e, 0x75, 0xe9, 0xf0,
+	0xc4, 0xbd, 0x24, 0x22, 0x03, 0x46, 0x35, 0x6b, 0x4e, 0xcb, 0xfd, 0xd0, 0x37, 0x50, 0xb5, 0x3d,
+	0x66, 0x8f, 0xba, 0x97, 0x97, 0x01, 0xe1, 0xc6, 0x4a, 0x56, 0x98, 0x38, 0x1b, 0x3d, 0x01, 0xe0,
+	0x57, 0x3e, 0x9b, 0x0e, 0xaf, 0x26, 0x53, 0x6e, 0xdc, 0x6f, 0x68, 0x5b, 0x1a, 0x8e, 0x21, 0x62,
+	0x9f, 0x2d, 0xce, 0xc9, 0x78, 0xc2, 0x03, 0x63, 0x55, 0x26, 0x60, 0x66, 0x0b, 0x39, 0x26, 0x37,
+	0x13, 0x62, 0xf3, 0x57, 0x22, 0x20, 0x71, 0x8c, 0x35, 0x99, 0xe9, 0x24, 0x28, 0x2a, 0xcb, 0x72,
+	0x8e, 0x99, 0x6d, 0xd4, 0xe4, 0xa8, 0x32, 0x44, 0xf6, 0xc2, 0x83, 0xe9, 0xbe, 0x67, 0x05, 0x81,
+	0xb1, 0xde, 0xd0, 0xb6, 0x56, 0xa3, 0xec, 0x1d, 0xc6, 0x46, 0x70, 0x82, 0x87, 0x5e, 0xc0, 0xea,
+	0xc4, 0xa5, 0x43, 0xf1, 0x03, 0xe6, 0x06, 0xdc, 0xb5, 0x03, 0x03, 0xc9, 0xf5, 0x3e, 0x0c, 0xf3,
+	0x9e, 0x18, 0xc3, 0x29, 0xae, 0xf9, 0xf7, 0x1c, 0xac, 0x26, 0x29, 0xe2, 0x00, 0x31, 0xb1, 0xec,
+	0x11, 0xe1, 0x41, 0x8f, 0x50, 0xa5, 0xce, 0x05, 0x1c, 0x87, 0x62, 0x0c, 0x4c, 0xec, 0xeb, 0xb0,
+	0x51, 0xe3, 0x90, 0x60, 0x78, 0x2c, 0x08, 0xce, 0x88, 0x6f, 0x13, 0xaa, 0xfa, 0x41, 0xc3, 0x71,
+	0x48, 0x54, 0xde, 0xd8, 0xa5, 0x98, 0x73, 0x23, 0x9f, 0xb5, 0x3d, 0x21, 0x51, 0xb8, 0x58, 0xd7,
+	0x43, 0xe1, 0x92, 0x7d, 0x32, 0x51, 0x44, 0xf9, 0x15, 0xeb, 0x06, 0x73, 0xd5, 0x07, 0x19, 0x5f,
+	0x91, 0x44, 0x71, 0xc8, 0x1e, 0x3b, 0xe4, 0x5a, 0xf8, 0x64, 0xde, 0x39, 0x22, 0xa6, 0xf9, 0x0f,
+	0x0d, 0x56, 0xe2, 0x15, 0x8e, 0x7e, 0x04, 0xba, 0x23, 0x7f, 0x3a, 0x33, 0x22, 0x08, 0x96, 0xf8,
+	0xa4, 0xcd, 0x28, 0x25, 0x36, 0xcf, 0x56, 0xac, 0x88, 0x29, 0xbe, 0xc0, 0xbd, 0x20, 0xfb, 0x22,
+	0x20, 0x58, 0xe8, 0x29, 0xe4, 0x39, 0xbf, 0x1c, 0x64, 0xe7, 0x5a, 0xd2, 0xcc, 0x7f, 0xea, 0xb0,
+	0xda, 0xa6, 0x3c, 0x25, 0x87, 0xaf, 0x67, 0x72, 0xa8, 0x63, 0x65, 0xa4, 0xe5, 0x50, 0xbf, 0x5d,
+	0x0e, 0xf5, 0x98, 0x1c, 0x8a, 0x16, 0x73, 0xc7, 0xe4, 0xd4, 0xf5, 0x3c, 0x37, 0x08, 0x4f, 0xe6,
+	0x31, 0x04, 0x7d, 0x1f, 0x56, 0x23, 0x25, 0x0b, 0x39, 0x05, 0x59, 0x62, 0x29, 0x34, 0x54, 0xb3,
+	0xe2, 0x4c, 0xcd, 0x4c, 0x58, 0x51, 0x22, 0x15, 0x7a, 0x95, 0xa4, 0x57, 0x02, 0x43, 0x5f, 0xa7,
+	0x44, 0x4a, 0xe9, 0xde, 0x86, 0x6a, 0x96, 0x36, 0xe5, 0x77, 0xe8, 0x54, 0xbc, 0xf3, 0x2b, 0x59,
+	0x9d, 0x0f, 0xcb, 0x3a, 0x3f, 0xdd, 0xe3, 0xd5, 0xf7, 0xec, 0xf1, 0x9f, 0x2e, 0xf4, 0xb8, 0xd2,
+	0xb4, 0x47, 0xf3, 0x69, 0xdf, 0xdd, 0xe6, 0x7f, 0xd0, 0x60, 0x2d, 0xb5, 0x38, 0x79, 0xb2, 0xa4,
+	0xc1, 0xa9, 0x6b, 0xfb, 0x2c, 0x08, 0xbb, 0x7c, 0x0e, 0x88, 0x05, 0x85, 0x95, 0x16, 0x32, 0xc2,
+	0xbb, 0x5e, 0x02, 0x14, 0x31, 0xb8, 0x17, 0xc5, 0x50, 0x4f, 0x0a, 0x73, 0x40, 0xee, 0x33, 0xbf,
+	0x1c, 0x84, 0xc3, 0xea, 0x68, 0x1a, 0x43, 0xcc, 0x7f, 0x6b, 0xb0, 0xbe, 0x30, 0xf7, 0xef, 0x44,
+	0x7f, 0x4c, 0x58, 0x51, 0xa2, 0x91, 0x98, 0x5a, 0x02, 0x13, 0x1c, 0xa5, 0x12, 0x89, 0xf9, 0x25,
+	0x30, 0x19, 0xc7, 0xba, 0x99, 0xd9, 0x61, 0x1d, 0x26, 0x30, 0x91, 0xa9, 0x50, 0x06, 0x42, 0x52,
+	0x51, 0x65, 0x2a, 0x01, 0x9a, 0x9f, 0x40, 0xb5, 0x4d, 0xf9, 0x57, 0x5f, 0x34, 0x7d, 0xdf, 0x7a,
+	0x27, 0xef, 0x73, 0x96, 0xf8, 0x4f, 0x1e, 0xae, 0x75, 0xac, 0x0c, 0x73, 0x0f, 0x2a, 0x6d, 0xca,
+	0x7b, 0xdc, 0x77, 0xe9, 0x30, 0x7e, 0xae, 0xd1, 0xef, 0xb8, 0x68, 0x98, 0x7f, 0xc9, 0xc1, 0x1a,
+	0x26, 0x36, 0xf3, 0x9d, 0x43, 0xd7, 0x23, 0x6d, 0xea, 0x90, 0x9b, 0xf4, 0xcd, 0xba, 0x90, 0xb8,
+	0x59, 0x5f, 0xba, 0x1e, 0xe9, 0xb9, 0xbf, 0x21, 0x61, 0xcb, 0xce, 0x6c, 0xb1, 0x5a, 0x5f, 0x05,
+	0x62, 0xfe, 0xd8, 0x8a, 0xde, 0x88, 0x12, 0x98, 0xc8, 0xbd, 0x7c, 0x77, 0x4a, 0x34, 0x6f, 0x1c,
+	0x12, 0x35, 0x41, 0xa8, 0x13, 0x6b, 0x5c, 0x1d, 0xcf, 0x01, 0xf4, 0x43, 0x28, 0x05, 0x72, 0x7d,
+	0xa9, 0x0b, 0xf3, 0x6c, 0xdd, 0x38, 0x1a, 0x17, 0xc7, 0x38, 0x12, 0x5e, 0x97, 0x75, 0xa9, 0x5a,
+	0x92, 0x98, 0x5a, 0xaa, 0xb8, 0x59, 0x61, 0xc5, 0x43, 0xbb, 0x50, 0x0c, 0x3c, 0xd7, 0x26, 0xa2,
+	0xab, 0x75, 0x79, 0xc6, 0x5a, 0xe6, 0xd1, 0x13, 0x14, 0x1c, 0x32, 0xcd, 0x3f, 0x6a, 0xf0, 0x60,
+	0x49, 0xc8, 0xe4, 0xf1, 0x4f, 0xbf, 0xe5, 0xf8, 0xf7, 0x9e, 0x7a, 0x67, 0x40, 0x89, 0x8d, 0xe2,
+	0xf7, 0xb3, 0xc8, 0x14, 0xd9, 0x14, 0x8d, 0x4e, 0x9c, 0xe8, 0x5e, 0x21, 0x2b, 0x39, 0x06, 0x99,
+	0x14, 0x1e, 0x2e, 0x9b, 0x7f, 0x7a, 0x1f, 0xb4, 0x8c, 0x7d, 0xc8, 0xa5, 0xf7, 0x61, 0x13, 0x8a,
+	0x4c, 0x1d, 0x8f, 0xd4, 0x6c, 0x43, 0xeb, 0xb3, 0x7f, 0xe5, 0x60, 0x25, 0xae, 0x44, 0xe8, 0x23,
+	0x78, 0x7c, 0xd8, 0x6c, 0x9f, 0x9c, 0xe3, 0xd6, 0xc5, 0xfe, 0x49, 0xb3, 0xd7, 0xbb, 0x38, 0xef,
+	0xf4, 0xce, 0x5a, 0xfb, 0xed, 0xc3, 0x76, 0xeb, 0xa0, 0x76, 0x0f, 0x3d, 0x82, 0x07, 0xc9, 0xe1,
+	0x6e, 0xff, 0xb8, 0x85, 0x6b, 0x1a, 0x7a, 0x0c, 0x1b, 0xc9, 0x81, 0x7e, 0xfb, 0xb4, 0xd5, 0x3d,
+	0xef, 0xd7, 0x72, 0xe8, 0x53, 0x68, 0x24, 0x87, 0xf6, 0xbb, 0x9d, 0x4e, 0x6b, 0xbf, 0xdf, 0xee,
+	0x76, 0x2e, 0x70, 0xeb, 0xf0, 0xbc, 0xd7, 0x3a, 0xa8, 0xe9, 0xc8, 0x84, 0x27, 0x77, 0xb0, 0x7a,
+	0xad, 0x7e, 0x2d, 0xbf, 0x6c, 0x72, 0xb8, 0xd5, 0xdc, 0x3f, 0x6e, 0xbe, 0x3a, 0x69, 0xd5, 0x0a,
+	0xe8, 0x63, 0xf8, 0x20, 0x39, 0x7c, 0xd0, 0xe9, 0x5d, 0x74, 0xba, 0xfd, 0x8b, 0xc3, 0xee, 0x79,
+	0xe7, 0xa0, 0x56, 0x44, 0x1b, 0xb0, 0xbe, 0x40, 0xa8, 0x95, 0x16, 0xe1, 0xfe, 0x49, 0xaf, 0x56,
+	0x5e, 0xfc, 0xda, 0x71, 0xbf, 0x7f, 0x76, 0xd1, 0xeb, 0x37, 0xfb, 0xe7, 0xbd, 0x5a, 0x65, 0x71,
+	0x58, 0x7c, 0xe9, 0xd5, 0x49, 0x77, 0xff, 0xe7, 0xad, 0x83, 0x1a, 0xec, 0xfe, 0x2d, 0x07, 0x2b,
+	0xcd, 0x21, 0xa1, 0xbc, 0x47, 0xfc, 0x6b, 0xb1, 0x85, 0x67, 0xb0, 0x96, 0x7a, 0x7a, 0x44, 0x1f,
+	0xaa, 0x8a, 0x5d, 0xfe, 0x38, 0x5c, 0xff, 0xe8, 0x96, 0x51, 0x75, 0x83, 0x37, 0xef, 0x21, 0x07,
+	0x1e, 0xdf, 0x7a, 0xd1, 0xcf, 0x88, 0xfd, 0x83, 0xd9, 0xe8, 0xdd, 0xef, 0x04, 0xe6, 0x3d, 0xf4,
+	0x0d, 0x94, 0xa3, 0x17, 0x45, 0x14, 0xfe, 0x70, 0xa6, 0xde, 0x3b, 0xeb, 0x9b, 0x69, 0x78, 0xe6,
+	0xfc, 0x12, 0x2a, 0xb3, 0x87, 0x40, 0xb4, 0x39, 0xfb, 0x68, 0xe2, 0xb9, 0xb0, 0xfe, 0x68, 0x01,
+	0x8f, 0xfc, 0x5f, 0xbd, 0xfc, 0xe5, 0x8b, 0xa1, 0xcb, 0xaf, 0xa6, 0x83, 0x6d, 0x9b, 0x8d, 0x77,
+	0x86, 0x96, 0xef, 0x10, 0x4a, 0xfc, 0x1d, 0xaa, 0x9e, 0xfe, 0x9e, 0x4e, 0x7c, 0x36, 0xf0, 0xc8,
+	0xf8, 0xa9, 0x43, 0x38, 0xb1, 0x39, 0xf3, 0x77, 0x52, 0xaf, 0xf9, 0x83, 0xa2, 0x3c, 0xf3, 0xec,
+	0xfd, 0x77, 0x00, 0x9e, 0xaf, 0xe7, 0x6b, 0xe7, 0x17, 0x00, 0x00,
 }
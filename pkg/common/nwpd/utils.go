@@ -5,10 +5,76 @@
 package nwpd
 
 import (
+	"fmt"
 	"sort"
+	"strings"
 	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
+// PhaseNames are the names of the HTTP request phases in the order returned by PhaseTimings.Durations.
+var PhaseNames = []string{"dns", "connect", "tls", "ttfb"}
+
+// Durations returns the phase durations in the order of PhaseNames. Missing phases are nil.
+func (x *PhaseTimings) Durations() []*durationpb.Duration {
+	return []*durationpb.Duration{x.GetDns(), x.GetConnect(), x.GetTls(), x.GetTtfb()}
+}
+
+const failureClassPrefix = "FAILURE_CLASS_"
+
+// Label returns the failure class as lowercase name without prefix, e.g. `connection_refused`.
+// It is empty for an unspecified failure class.
+func (x FailureClass) Label() string {
+	if x == FailureClass_FAILURE_CLASS_UNSPECIFIED {
+		return ""
+	}
+	return strings.ToLower(strings.TrimPrefix(x.String(), failureClassPrefix))
+}
+
+// FailureLabel returns the label of the failure class of a failed check.
+// Failures persisted by older versions are not classified.
+func (x FailureClass) FailureLabel() string {
+	if x == FailureClass_FAILURE_CLASS_UNSPECIFIED {
+		return "unclassified"
+	}
+	return x.Label()
+}
+
+// FailureClassLabels returns the labels of all specified failure classes.
+func FailureClassLabels() []string {
+	var labels []string
+	for i := int32(1); i < int32(len(FailureClass_name)); i++ {
+		labels = append(labels, FailureClass(i).Label())
+	}
+	return labels
+}
+
+// ParseFailureClass parses a failure class label as returned by FailureClass.Label.
+func ParseFailureClass(label string) (FailureClass, error) {
+	value, ok := FailureClass_value[failureClassPrefix+strings.ToUpper(label)]
+	if !ok || value == int32(FailureClass_FAILURE_CLASS_UNSPECIFIED) {
+		return FailureClass_FAILURE_CLASS_UNSPECIFIED, fmt.Errorf("invalid failure class %q (allowed: %s)", label, strings.Join(FailureClassLabels(), ", "))
+	}
+	return FailureClass(value), nil
+}
+
+// ParseFailureClasses parses a list of failure class labels to a set. It returns nil for an empty list.
+func ParseFailureClasses(labels []string) (map[FailureClass]bool, error) {
+	if len(labels) == 0 {
+		return nil, nil
+	}
+	classes := map[FailureClass]bool{}
+	for _, label := range labels {
+		class, err := ParseFailureClass(label)
+		if err != nil {
+			return nil, err
+		}
+		classes[class] = true
+	}
+	return classes, nil
+}
+
 type ObservationListener interface {
 	Add(obs *Observation)
 }
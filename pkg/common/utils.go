@@ -8,6 +8,7 @@ package common
 
 import (
 	"sort"
+	"strings"
 	"time"
 )
 
@@ -73,3 +74,13 @@ func MergeMaps(map1, map2 map[string]string) map[string]string {
 	}
 	return result
 }
+
+// FormatLabels formats labels as sorted comma separated list of key=value pairs.
+func FormatLabels(labels map[string]string) string {
+	var list []string
+	for key, value := range labels {
+		list = append(list, key+"="+value)
+	}
+	sort.Strings(list)
+	return strings.Join(list, ",")
+}
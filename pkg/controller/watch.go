@@ -38,6 +38,8 @@ type nodePodController struct {
 	nodesInformer             informerscorev1.NodeInformer
 	podsInformer              informerscorev1.PodInformer
 	knownPodIPs               atomic.Value
+	kubeDNSSelector           atomic.Value
+	knownKubeDNSPodIPs        atomic.Value
 }
 
 func newNodePodController(log logrus.FieldLogger, clientset kubernetes.Interface, resyncPeriod time.Duration) (*nodePodController, error) {
@@ -74,15 +76,31 @@ func (c *nodePodController) ListAgentPods() ([]*corev1.Pod, error) {
 	pods, err := c.podsInformer.Lister().List(labels.SelectorFromSet(map[string]string{common.LabelKeyK8sApp: common.NameDaemonSetAgentPodNet}))
 
 	// remember known pods
+	c.knownPodIPs.Store(runningPodIPs(pods))
+
+	return pods, err
+}
+
+// ListKubeDNSPods lists the pods selected by the kube-dns service and watches them for changes.
+func (c *nodePodController) ListKubeDNSPods(selector map[string]string) ([]*corev1.Pod, error) {
+	if len(selector) == 0 {
+		return nil, nil
+	}
+	sel := labels.SelectorFromSet(selector)
+	c.kubeDNSSelector.Store(sel)
+	pods, err := c.podsInformer.Lister().List(sel)
+	c.knownKubeDNSPodIPs.Store(runningPodIPs(pods))
+	return pods, err
+}
+
+func runningPodIPs(pods []*corev1.Pod) map[string]string {
 	podIPs := map[string]string{}
 	for _, pod := range pods {
 		if pod.Status.Phase == corev1.PodRunning {
 			podIPs[pod.Name] = pod.Status.PodIP
 		}
 	}
-	c.knownPodIPs.Store(podIPs)
-
-	return pods, err
+	return podIPs
 }
 
 func (c *nodePodController) Start(stopCh chan struct{}) error {
@@ -113,8 +131,12 @@ func (c *nodePodController) OnAdd(obj interface{}, _ bool) {
 func (c *nodePodController) OnUpdate(_, newObj interface{}) {
 	if c.isRelevant(newObj) {
 		if newPod, ok := newObj.(*corev1.Pod); ok {
+			known := &c.knownPodIPs
+			if c.isKubeDNSPod(newPod) {
+				known = &c.knownKubeDNSPodIPs
+			}
 			if newPod.Status.Phase == corev1.PodRunning {
-				podIPs := c.knownPodIPs.Load().(map[string]string)
+				podIPs, _ := known.Load().(map[string]string)
 				if podIPs[newPod.Name] != newPod.Status.PodIP {
 					// either new, yet unknown running agent pod or in very rare edge cases the PodIP has changed (e.g. after node reboot)
 					c.hasUpdates.Store(true)
@@ -139,11 +161,16 @@ func (c *nodePodController) isRelevant(obj interface{}) bool {
 	}
 	if pod, ok := obj.(*corev1.Pod); ok {
 		labels := pod.GetLabels()
-		return labels != nil && labels[common.LabelKeyK8sApp] == common.NameDaemonSetAgentPodNet
+		return (labels != nil && labels[common.LabelKeyK8sApp] == common.NameDaemonSetAgentPodNet) || c.isKubeDNSPod(pod)
 	}
 	return false
 }
 
+func (c *nodePodController) isKubeDNSPod(pod *corev1.Pod) bool {
+	sel, ok := c.kubeDNSSelector.Load().(labels.Selector)
+	return ok && sel.Matches(labels.Set(pod.GetLabels()))
+}
+
 type watch struct {
 	log       logrus.FieldLogger
 	clientSet *kubernetes.Clientset
@@ -258,6 +285,23 @@ func (w *watch) Start(ctx context.Context) error {
 			w.log.Errorf("building cluster config failed: %s", err)
 			continue
 		}
+		dnsSvc, err := w.clientSet.CoreV1().Services(common.NamespaceKubeSystem).Get(ctx, common.NameKubeDNSService, metav1.GetOptions{})
+		if err != nil {
+			if !errors.IsNotFound(err) {
+				w.log.Errorf("loading service %s/%s failed: %s", common.NamespaceKubeSystem, common.NameKubeDNSService, err)
+				continue
+			}
+			// clusters without kube-dns service have no DNS endpoints
+			dnsSvc = nil
+		}
+		if dnsSvc != nil {
+			dnsPods, err := controller.ListKubeDNSPods(dnsSvc.Spec.Selector)
+			if err != nil {
+				w.log.Errorf("listing kube-dns pods failed: %s", err)
+				continue
+			}
+			deploy.AddKubeDNSEndpoints(w.log, cfg, dnsSvc, dnsPods)
+		}
 		cfgBytes, err := yaml.Marshal(cfg)
 		if err != nil {
 			w.log.Errorf("marshal configmap %s/%s failed: %s", common.NamespaceKubeSystem, common.NameClusterConfigMap, err)
@@ -15,6 +15,7 @@ import (
 	"github.com/spf13/pflag"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -31,6 +32,15 @@ import (
 //go:embed DEFAULT_REPOSITORY
 var defaultRepository string
 
+// defaultMaxClockOffset is the default limit of the median clock offset of a node to its peers.
+const (
+	defaultMaxClockOffset = 1 * time.Second
+	// periodThroughput is the base period of the throughput jobs, which is scaled by the number of nodes.
+	periodThroughput = "5m"
+	// timeoutNetworkPolicyCheck is the timeout of the negative checks to the network policy canary, which are expected to time out.
+	timeoutNetworkPolicyCheck = 3 * time.Second
+)
+
 // AgentDeployConfig contains configuration for deploying the nwpd agent daemonset.
 type AgentDeployConfig struct {
 	// Image is the image of the network problem detector agent to deploy.
@@ -41,6 +51,10 @@ type AgentDeployConfig struct {
 	DefaultSeccompProfileEnabled bool
 	// PingEnabled if ping checks are enabled (needs NET_ADMIN capabilities).
 	PingEnabled bool
+	// ThroughputEnabled if throughput checks between the agents are enabled.
+	ThroughputEnabled bool
+	// NetworkPolicyCheckEnabled if negative checks are enabled, which expect a canary isolated by a network policy to be unreachable.
+	NetworkPolicyCheckEnabled bool
 	// IgnoreAPIServerEndpoint if the check of the API server endpoint should be ignored.
 	IgnoreAPIServerEndpoint bool
 	// PriorityClassName is the priority class name used for the daemon sets.
@@ -60,6 +74,8 @@ type AgentDeployConfig struct {
 	DisableAutomountServiceAccountTokenForAgents bool
 	// MaxPeerNodes if != 0 restricts number of peer nodes used as destinations for checks (nodes are selected randomly, but stable in this case).
 	MaxPeerNodes int
+	// PathMTUFloor if > 0 overwrites the minimal path MTU considered as ok by the path MTU checks.
+	PathMTUFloor int
 
 	IPFamilies string
 }
@@ -86,6 +102,9 @@ func NetworkProblemDetectorAgent(config *AgentDeployConfig) ([]Object, error) {
 		}
 		objects = append(objects, ds)
 	}
+	if config.NetworkPolicyCheckEnabled {
+		objects = append(objects, config.buildNetworkPolicyCanary()...)
+	}
 
 	return objects, nil
 }
@@ -99,12 +118,15 @@ func (ac *AgentDeployConfig) AddOptionFlags(flags *pflag.FlagSet) {
 	flags.DurationVar(&ac.DefaultPeriod, "default-period", 5*time.Second, "default period for jobs")
 	flags.BoolVar(&ac.DefaultSeccompProfileEnabled, "default-seccomp-profile", false, "if seccomp profile should be defaulted to RuntimeDefault for network-problem-detector pods")
 	flags.BoolVar(&ac.PingEnabled, "enable-ping", false, "if ICMP pings should be used in addition to TCP connection checks")
+	flags.BoolVar(&ac.ThroughputEnabled, "enable-throughput", false, "if the throughput between the agents should be measured by transferring bytes rarely")
+	flags.BoolVar(&ac.NetworkPolicyCheckEnabled, "enable-network-policy-check", false, "if a canary isolated by a network policy should be deployed and checked to be unreachable from the pod network")
 	flags.BoolVar(&ac.K8sExporterEnabled, "enable-k8s-exporter", false, "if node conditions and events should be updated/created")
 	flags.DurationVar(&ac.K8sExporterHeartbeat, "k8s-exporter-heartbeat", 3*time.Minute, "period for updating the node conditions by the K8s exporter")
 	flags.Float64Var(&ac.K8sExporterMinFailingPeerNodeShare, "k8s-exporter-min-failing-peer-node-share", 0.2, "if > 0, report node conditions only if checks for minimum share of destination peer nodes are failing. Valid range: [0.0,1.0]")
 	flags.BoolVar(&ac.IgnoreAPIServerEndpoint, "ignore-gardener-kube-api-server", false, "if true, does not try to lookup kube api-server of Gardener control plane")
 	flags.StringVar(&ac.PriorityClassName, "priority-class", "", "priority class name")
 	flags.IntVar(&ac.MaxPeerNodes, "max-peer-nodes", 0, "if != 0 restricts number of peer nodes used as check destinations")
+	flags.IntVar(&ac.PathMTUFloor, "path-mtu-floor", 0, "if > 0, minimal path MTU considered as ok by the path MTU checks (default of the checks: 1280)")
 }
 
 func (ac *AgentDeployConfig) buildService(hostnetwork bool) (*corev1.Service, error) {
@@ -252,6 +274,22 @@ func (ac *AgentDeployConfig) buildDaemonSet(serviceAccountName string, hostNetwo
 									},
 								},
 							},
+							{
+								Name: common.EnvPodIPs,
+								ValueFrom: &corev1.EnvVarSource{
+									FieldRef: &corev1.ObjectFieldSelector{
+										FieldPath: "status.podIPs",
+									},
+								},
+							},
+							{
+								Name: common.EnvPodName,
+								ValueFrom: &corev1.EnvVarSource{
+									FieldRef: &corev1.ObjectFieldSelector{
+										FieldPath: "metadata.name",
+									},
+								},
+							},
 						},
 						LivenessProbe: &corev1.Probe{
 							ProbeHandler: corev1.ProbeHandler{
@@ -266,6 +304,11 @@ func (ac *AgentDeployConfig) buildDaemonSet(serviceAccountName string, hostNetwo
 								ContainerPort: portHTTP,
 								Protocol:      "TCP",
 							},
+							{
+								Name:          "udp-echo",
+								ContainerPort: portHTTP,
+								Protocol:      "UDP",
+							},
 						},
 						Resources: corev1.ResourceRequirements{
 							Requests: corev1.ResourceList{
@@ -496,6 +539,12 @@ func (ac *AgentDeployConfig) buildControllerDeployment() (*appsv1.Deployment, *r
 				Resources:     []string{"configmaps"},
 				ResourceNames: []string{common.NameGardenerShootInfo},
 			},
+			{
+				APIGroups:     []string{""},
+				Verbs:         []string{"get"},
+				Resources:     []string{"services"},
+				ResourceNames: []string{common.NameKubeDNSService},
+			},
 		},
 	}
 	roleBinding := &rbacv1.RoleBinding{
@@ -600,13 +649,19 @@ func (ac *AgentDeployConfig) createClusterRuleAndServiceAccount(serviceAccountNa
 
 func (ac *AgentDeployConfig) BuildAgentConfig() (*config.AgentConfig, error) {
 	periodXL := fmt.Sprintf("%ds", imin(60, imax(1, int(ac.DefaultPeriod/time.Second))*2))
+	var mtuFloorArgs []string
+	if ac.PathMTUFloor > 0 {
+		mtuFloorArgs = []string{"--mtu-floor", fmt.Sprintf("%d", ac.PathMTUFloor)}
+	}
 	cfg := config.AgentConfig{
 		OutputDir:       common.PathOutputDir,
 		RetentionHours:  24,
 		LogObservations: false,
+		MaxClockOffset:  &metav1.Duration{Duration: defaultMaxClockOffset},
 		HostNetwork: &config.NetworkConfig{
 			DataFilePrefix: common.NameDaemonSetAgentHostNet,
 			HTTPPort:       common.HostNetPodHTTPPort,
+			UDPEchoPort:    common.HostNetPodHTTPPort,
 			DefaultPeriod:  metav1.Duration{Duration: ac.DefaultPeriod},
 			Jobs: []config.Job{
 				{
@@ -621,6 +676,22 @@ func (ac *AgentDeployConfig) BuildAgentConfig() (*config.AgentConfig, error) {
 					JobID: "tcp-n2n-ipv6",
 					Args:  []string{"checkTCPPort", "--node-port-ipv6", fmt.Sprintf("%d", common.HostNetPodHTTPPort)},
 				},
+				{
+					JobID: "udp-n2n",
+					Args:  []string{"checkUDPPort", "--node-port", fmt.Sprintf("%d", common.HostNetPodHTTPPort)},
+				},
+				{
+					JobID: "mtu-n2n",
+					Args:  append([]string{"checkPathMTU", "--node-port", fmt.Sprintf("%d", common.HostNetPodHTTPPort), "--period", periodXL}, mtuFloorArgs...),
+				},
+				{
+					JobID: "identity-n2n",
+					Args:  []string{"checkPeerIdentity", "--node-port", fmt.Sprintf("%d", common.HostNetPodHTTPPort), "--period", periodXL},
+				},
+				{
+					JobID: "clock-n2n",
+					Args:  []string{"checkClockSkew", "--node-port", fmt.Sprintf("%d", common.HostNetPodHTTPPort), "--period", periodXL},
+				},
 				{
 					JobID: "tcp-n2p",
 					Args:  []string{"checkTCPPort", "--endpoints-of-pod-ds"},
@@ -639,6 +710,7 @@ func (ac *AgentDeployConfig) BuildAgentConfig() (*config.AgentConfig, error) {
 			DataFilePrefix: common.NameDaemonSetAgentPodNet,
 			DefaultPeriod:  metav1.Duration{Duration: ac.DefaultPeriod},
 			HTTPPort:       common.PodNetPodHTTPPort,
+			UDPEchoPort:    common.PodNetPodHTTPPort,
 			Jobs: []config.Job{
 				{
 					JobID: "tcp-p2api-int",
@@ -660,6 +732,18 @@ func (ac *AgentDeployConfig) BuildAgentConfig() (*config.AgentConfig, error) {
 					JobID: "tcp-p2p",
 					Args:  []string{"checkTCPPort", "--endpoints-of-pod-ds"},
 				},
+				{
+					JobID: "udp-p2p",
+					Args:  []string{"checkUDPPort", "--endpoints-of-pod-ds"},
+				},
+				{
+					JobID: "mtu-p2p",
+					Args:  append([]string{"checkPathMTU", "--endpoints-of-pod-ds", "--period", periodXL}, mtuFloorArgs...),
+				},
+				{
+					JobID: "identity-p2p",
+					Args:  []string{"checkPeerIdentity", "--endpoints-of-pod-ds", "--check-source-ip", "--period", periodXL},
+				},
 				{
 					JobID: "tcp-p2p-ipv6",
 					Args:  []string{"checkTCPPort", "--endpoints-of-pod-ds-ipv6"},
@@ -668,6 +752,14 @@ func (ac *AgentDeployConfig) BuildAgentConfig() (*config.AgentConfig, error) {
 					JobID: "nslookup-p",
 					Args:  []string{"nslookup", "--names", "europe-docker.pkg.dev.", "--name-internal-kube-apiserver", "--scale-period"},
 				},
+				{
+					JobID: "dns-p2kube-dns-svc",
+					Args:  []string{"checkDNS", "--servers-kube-dns-service", "--name-internal-kube-apiserver", "--scale-period"},
+				},
+				{
+					JobID: "dns-p2kube-dns-pods",
+					Args:  []string{"checkDNS", "--servers-kube-dns-pods", "--name-internal-kube-apiserver", "--scale-period"},
+				},
 			},
 		},
 	}
@@ -727,11 +819,151 @@ func (ac *AgentDeployConfig) BuildAgentConfig() (*config.AgentConfig, error) {
 			})
 	}
 
+	if ac.ThroughputEnabled {
+		cfg.HostNetwork.Jobs = append(cfg.HostNetwork.Jobs,
+			config.Job{
+				JobID: "throughput-n2n",
+				Args:  []string{"checkThroughput", "--node-port", fmt.Sprintf("%d", common.HostNetPodHTTPPort), "--scale-period", "--period", periodThroughput},
+			})
+		cfg.PodNetwork.Jobs = append(cfg.PodNetwork.Jobs,
+			config.Job{
+				JobID: "throughput-p2p",
+				Args:  []string{"checkThroughput", "--endpoints-of-pod-ds", "--scale-period", "--period", periodThroughput},
+			})
+	}
+
+	if ac.NetworkPolicyCheckEnabled {
+		canaryTargets := &config.JobTargets{
+			Endpoints: []string{fmt.Sprintf("%s::%d", common.DomainNameNetworkPolicyCanaryService, common.NetworkPolicyCanaryPort)},
+		}
+		canaryLabels := map[string]string{"check": "network-policy"}
+		cfg.PodNetwork.Jobs = append(cfg.PodNetwork.Jobs,
+			config.Job{
+				JobID: "policy-tcp-p2canary",
+				Spec: &config.JobSpec{
+					Type:    "checkTCPPort",
+					Targets: canaryTargets,
+					Timeout: &metav1.Duration{Duration: timeoutNetworkPolicyCheck},
+					Labels:  canaryLabels,
+				},
+				Expect: config.ExpectBlocked,
+			},
+			config.Job{
+				JobID: "policy-udp-p2canary",
+				Spec: &config.JobSpec{
+					Type:    "checkUDPPort",
+					Targets: canaryTargets,
+					Timeout: &metav1.Duration{Duration: timeoutNetworkPolicyCheck},
+					Labels:  canaryLabels,
+				},
+				Expect: config.ExpectBlocked,
+			},
+			config.Job{
+				JobID: "policy-http-p2canary",
+				Spec: &config.JobSpec{
+					Type:    "checkHTTP",
+					Targets: canaryTargets,
+					Params:  map[string]any{"scheme": "http"},
+					Timeout: &metav1.Duration{Duration: timeoutNetworkPolicyCheck},
+					Labels:  canaryLabels,
+				},
+				Expect: config.ExpectBlocked,
+			})
+	}
+
 	cfg.MaxPeerNodes = ac.MaxPeerNodes
 
 	return &cfg, nil
 }
 
+// buildNetworkPolicyCanary returns the canary deployment and service, which are isolated by a network policy denying all ingress traffic.
+func (ac *AgentDeployConfig) buildNetworkPolicyCanary() []Object {
+	var (
+		requestCPU, _    = resource.ParseQuantity("5m")
+		requestMemory, _ = resource.ParseQuantity("16Mi")
+		limitMemory, _   = resource.ParseQuantity("64Mi")
+	)
+
+	name := common.NameNetworkPolicyCanary
+	labels := ac.getLabels(name)
+	port := int32(common.NetworkPolicyCanaryPort)
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: common.NamespaceKubeSystem,
+		},
+		Spec: appsv1.DeploymentSpec{
+			RevisionHistoryLimit: ptr.To[int32](5),
+			Selector:             &metav1.LabelSelector{MatchLabels: labels},
+			Replicas:             ptr.To[int32](1),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					PriorityClassName:             ac.PriorityClassName,
+					TerminationGracePeriodSeconds: ptr.To[int64](0),
+					AutomountServiceAccountToken:  ptr.To(false),
+					Containers: []corev1.Container{{
+						Name:            name,
+						Image:           ac.Image,
+						ImagePullPolicy: imagePullPolicyByImage(ac.Image),
+						Command:         []string{"/nwpdcli", "run-canary", "--port", fmt.Sprintf("%d", port)},
+						Ports: []corev1.ContainerPort{
+							{Name: "tcp", ContainerPort: port, Protocol: corev1.ProtocolTCP},
+							{Name: "udp", ContainerPort: port, Protocol: corev1.ProtocolUDP},
+						},
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    requestCPU,
+								corev1.ResourceMemory: requestMemory,
+							},
+							Limits: corev1.ResourceList{
+								corev1.ResourceMemory: limitMemory,
+							},
+						},
+						SecurityContext: &corev1.SecurityContext{
+							AllowPrivilegeEscalation: ptr.To(false),
+							RunAsUser:                ptr.To[int64](65534),
+							RunAsGroup:               ptr.To[int64](65534),
+						},
+					}},
+				},
+			},
+		},
+	}
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: common.NamespaceKubeSystem,
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{Name: "tcp", Protocol: corev1.ProtocolTCP, Port: port, TargetPort: intstr.FromString("tcp")},
+				{Name: "udp", Protocol: corev1.ProtocolUDP, Port: port, TargetPort: intstr.FromString("udp")},
+			},
+			Selector: labels,
+			Type:     corev1.ServiceTypeClusterIP,
+		},
+	}
+
+	policy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: common.NamespaceKubeSystem,
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: labels},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			// no ingress rules: all ingress traffic is denied
+		},
+	}
+
+	return []Object{deployment, svc, policy}
+}
+
 func BuildAgentConfigMap(agentConfig *config.AgentConfig) (*corev1.ConfigMap, error) {
 	cfgBytes, err := yaml.Marshal(agentConfig)
 	if err != nil {
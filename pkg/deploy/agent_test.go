@@ -7,12 +7,16 @@ package deploy_test
 import (
 	"time"
 
+	"github.com/gardener/network-problem-detector/pkg/agent/runners"
+	"github.com/gardener/network-problem-detector/pkg/common"
+	"github.com/gardener/network-problem-detector/pkg/common/config"
 	"github.com/gardener/network-problem-detector/pkg/deploy"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 )
 
 var _ = Describe("Add default seccomp profile when enabled", func() {
@@ -60,3 +64,135 @@ var _ = Describe("Add default seccomp profile when enabled", func() {
 		Expect(ds.Spec.Template.Spec.SecurityContext.SeccompProfile.Type).To(Equal(corev1.SeccompProfileTypeRuntimeDefault))
 	})
 })
+
+var _ = Describe("BuildAgentConfig", func() {
+	It("should contain UDP, path MTU and DNS jobs and echo ports", func() {
+		deployConfig := &deploy.AgentDeployConfig{
+			Image:         "image:tag",
+			DefaultPeriod: 16 * time.Second,
+		}
+		cfg, err := deployConfig.BuildAgentConfig()
+		Expect(err).To(BeNil())
+		Expect(cfg.HostNetwork.UDPEchoPort).To(Equal(common.HostNetPodHTTPPort))
+		Expect(cfg.PodNetwork.UDPEchoPort).To(Equal(common.PodNetPodHTTPPort))
+
+		jobArgs := func(jobs []config.Job, jobID string) []string {
+			for _, job := range jobs {
+				if job.JobID == jobID {
+					return job.Args
+				}
+			}
+			return nil
+		}
+		Expect(jobArgs(cfg.HostNetwork.Jobs, "udp-n2n")).To(Equal([]string{"checkUDPPort", "--node-port", "12996"}))
+		Expect(jobArgs(cfg.PodNetwork.Jobs, "udp-p2p")).To(Equal([]string{"checkUDPPort", "--endpoints-of-pod-ds"}))
+		Expect(jobArgs(cfg.HostNetwork.Jobs, "mtu-n2n")).To(Equal([]string{"checkPathMTU", "--node-port", "12996", "--period", "32s"}))
+		Expect(jobArgs(cfg.PodNetwork.Jobs, "mtu-p2p")).To(Equal([]string{"checkPathMTU", "--endpoints-of-pod-ds", "--period", "32s"}))
+		Expect(jobArgs(cfg.HostNetwork.Jobs, "identity-n2n")).To(Equal([]string{"checkPeerIdentity", "--node-port", "12996", "--period", "32s"}))
+		Expect(jobArgs(cfg.HostNetwork.Jobs, "clock-n2n")).To(Equal([]string{"checkClockSkew", "--node-port", "12996", "--period", "32s"}))
+		Expect(cfg.MaxClockOffset.Duration).To(Equal(1 * time.Second))
+		Expect(jobArgs(cfg.PodNetwork.Jobs, "identity-p2p")).To(Equal([]string{"checkPeerIdentity", "--endpoints-of-pod-ds", "--check-source-ip", "--period", "32s"}))
+		Expect(jobArgs(cfg.PodNetwork.Jobs, "dns-p2kube-dns-svc")).To(Equal([]string{"checkDNS", "--servers-kube-dns-service", "--name-internal-kube-apiserver", "--scale-period"}))
+		Expect(jobArgs(cfg.PodNetwork.Jobs, "dns-p2kube-dns-pods")).To(Equal([]string{"checkDNS", "--servers-kube-dns-pods", "--name-internal-kube-apiserver", "--scale-period"}))
+	})
+
+	It("should overwrite the floor of the path MTU jobs", func() {
+		deployConfig := &deploy.AgentDeployConfig{
+			Image:         "image:tag",
+			DefaultPeriod: 16 * time.Second,
+			PathMTUFloor:  1400,
+		}
+		cfg, err := deployConfig.BuildAgentConfig()
+		Expect(err).To(BeNil())
+		for _, job := range append(cfg.HostNetwork.Jobs, cfg.PodNetwork.Jobs...) {
+			if job.JobID == "mtu-n2n" || job.JobID == "mtu-p2p" {
+				Expect(job.Args[len(job.Args)-2:]).To(Equal([]string{"--mtu-floor", "1400"}))
+				Expect(runners.ValidateJob(job)).To(Succeed())
+			}
+		}
+	})
+
+	It("should only contain valid jobs", func() {
+		deployConfig := &deploy.AgentDeployConfig{
+			Image:                     "image:tag",
+			DefaultPeriod:             16 * time.Second,
+			PingEnabled:               true,
+			ThroughputEnabled:         true,
+			NetworkPolicyCheckEnabled: true,
+		}
+		cfg, err := deployConfig.BuildAgentConfig()
+		Expect(err).To(BeNil())
+		for _, job := range append(cfg.HostNetwork.Jobs, cfg.PodNetwork.Jobs...) {
+			Expect(runners.ValidateJob(job)).To(Succeed())
+		}
+	})
+
+	It("should contain throughput jobs only if enabled", func() {
+		deployConfig := &deploy.AgentDeployConfig{
+			Image:         "image:tag",
+			DefaultPeriod: 16 * time.Second,
+		}
+		hasJob := func(jobs []config.Job, jobID string) bool {
+			for _, job := range jobs {
+				if job.JobID == jobID {
+					return true
+				}
+			}
+			return false
+		}
+		cfg, err := deployConfig.BuildAgentConfig()
+		Expect(err).To(BeNil())
+		Expect(hasJob(cfg.HostNetwork.Jobs, "throughput-n2n")).To(BeFalse())
+		Expect(hasJob(cfg.PodNetwork.Jobs, "throughput-p2p")).To(BeFalse())
+
+		deployConfig.ThroughputEnabled = true
+		cfg, err = deployConfig.BuildAgentConfig()
+		Expect(err).To(BeNil())
+		Expect(hasJob(cfg.HostNetwork.Jobs, "throughput-n2n")).To(BeTrue())
+		Expect(hasJob(cfg.PodNetwork.Jobs, "throughput-p2p")).To(BeTrue())
+	})
+	It("should contain negative network policy jobs and canary only if enabled", func() {
+		deployConfig := &deploy.AgentDeployConfig{
+			Image:         "image:tag",
+			DefaultPeriod: 16 * time.Second,
+		}
+		negativeJobs := func(jobs []config.Job) []string {
+			var jobIDs []string
+			for _, job := range jobs {
+				if job.ExpectsBlocked() {
+					jobIDs = append(jobIDs, job.JobID)
+				}
+			}
+			return jobIDs
+		}
+		hasNetworkPolicy := func(objs []deploy.Object) bool {
+			for _, obj := range objs {
+				if _, ok := obj.(*networkingv1.NetworkPolicy); ok {
+					return true
+				}
+			}
+			return false
+		}
+		cfg, err := deployConfig.BuildAgentConfig()
+		Expect(err).To(BeNil())
+		Expect(negativeJobs(cfg.PodNetwork.Jobs)).To(BeEmpty())
+		objs, err := deploy.NetworkProblemDetectorAgent(deployConfig)
+		Expect(err).To(BeNil())
+		Expect(hasNetworkPolicy(objs)).To(BeFalse())
+
+		deployConfig.NetworkPolicyCheckEnabled = true
+		cfg, err = deployConfig.BuildAgentConfig()
+		Expect(err).To(BeNil())
+		Expect(negativeJobs(cfg.HostNetwork.Jobs)).To(BeEmpty())
+		Expect(negativeJobs(cfg.PodNetwork.Jobs)).To(ConsistOf("policy-tcp-p2canary", "policy-udp-p2canary", "policy-http-p2canary"))
+		for _, job := range cfg.PodNetwork.Jobs {
+			args, err := runners.JobArgs(job)
+			Expect(err).To(BeNil(), job.JobID)
+			_, err = runners.Parse(config.ClusterConfig{}, runners.RunnerConfig{Job: job}, args, &config.SampleConfig{})
+			Expect(err).To(BeNil(), job.JobID)
+		}
+		objs, err = deploy.NetworkProblemDetectorAgent(deployConfig)
+		Expect(err).To(BeNil())
+		Expect(hasNetworkPolicy(objs)).To(BeTrue())
+	})
+})
@@ -15,6 +15,7 @@ import (
 
 	"github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 func arePodsOfIPFamily(agentPods []*corev1.Pod, ipFamily string) bool {
@@ -86,6 +87,7 @@ func BuildClusterConfig(
 			hostname = n.Name
 		}
 		clusterConfig.Nodes = append(clusterConfig.Nodes, config.Node{
+			Name:          n.Name,
 			Hostname:      hostname,
 			InternalIPs:   ips,
 			InternalIPsV6: ipsV6,
@@ -143,6 +145,61 @@ func BuildClusterConfig(
 	return clusterConfig, nil
 }
 
+// AddKubeDNSEndpoints adds the endpoints of the kube-dns service and its pods to the cluster config.
+func AddKubeDNSEndpoints(log logrus.FieldLogger, clusterConfig *config.ClusterConfig, dnsService *corev1.Service, dnsPods []*corev1.Pod) {
+	var dnsPort *corev1.ServicePort
+	for i, port := range dnsService.Spec.Ports {
+		if port.Protocol == corev1.ProtocolUDP || port.Protocol == "" {
+			dnsPort = &dnsService.Spec.Ports[i]
+			break
+		}
+	}
+	if dnsPort == nil {
+		log.Infof("ignore service %s/%s without UDP port", dnsService.Namespace, dnsService.Name)
+		return
+	}
+	if ip := net.ParseIP(dnsService.Spec.ClusterIP); ip != nil {
+		clusterConfig.KubeDNSService = &config.Endpoint{
+			Hostname: common.DomainNameKubeDNSService,
+			IP:       dnsService.Spec.ClusterIP,
+			Port:     int(dnsPort.Port),
+		}
+	}
+
+	clusterConfig.KubeDNSPods = nil
+	for _, p := range dnsPods {
+		if p.Status.Phase != corev1.PodRunning || p.Status.PodIP == "" {
+			continue
+		}
+		clusterConfig.KubeDNSPods = append(clusterConfig.KubeDNSPods, config.PodEndpoint{
+			Nodename: p.Spec.NodeName,
+			Podname:  p.Name,
+			PodIP:    p.Status.PodIP,
+			Port:     podTargetPort(p, dnsPort),
+		})
+	}
+	sort.Slice(clusterConfig.KubeDNSPods, func(i, j int) bool {
+		return strings.Compare(clusterConfig.KubeDNSPods[i].Podname, clusterConfig.KubeDNSPods[j].Podname) < 0
+	})
+}
+
+// podTargetPort resolves the target port of the service port for the given pod.
+func podTargetPort(pod *corev1.Pod, servicePort *corev1.ServicePort) int32 {
+	switch {
+	case servicePort.TargetPort.Type == intstr.Int && servicePort.TargetPort.IntVal != 0:
+		return servicePort.TargetPort.IntVal
+	case servicePort.TargetPort.Type == intstr.String && servicePort.TargetPort.StrVal != "":
+		for _, c := range pod.Spec.Containers {
+			for _, port := range c.Ports {
+				if port.Name == servicePort.TargetPort.StrVal {
+					return port.ContainerPort
+				}
+			}
+		}
+	}
+	return servicePort.Port
+}
+
 func GetAPIServerEndpointFromShootInfo(shootInfo *corev1.ConfigMap) (*config.Endpoint, error) {
 	domain, ok := shootInfo.Data["domain"]
 	if !ok {
@@ -11,7 +11,10 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
+	"github.com/gardener/network-problem-detector/pkg/common"
 	"github.com/gardener/network-problem-detector/pkg/common/config"
 	"github.com/gardener/network-problem-detector/pkg/deploy"
 )
@@ -271,3 +274,49 @@ var _ = Describe("BuildClusterConfig", func() {
 		Expect(clusterConfig.PodEndpoints[0].PodIP).To(Equal("10.0.0.1"))
 	})
 })
+
+var _ = Describe("AddKubeDNSEndpoints", func() {
+	It("should add kube-dns service and pod endpoints", func() {
+		dnsService := &corev1.Service{
+			Spec: corev1.ServiceSpec{
+				ClusterIP: "100.64.0.10",
+				Ports: []corev1.ServicePort{
+					{Name: "dns", Protocol: corev1.ProtocolUDP, Port: 53, TargetPort: intstr.FromString("dns")},
+					{Name: "dns-tcp", Protocol: corev1.ProtocolTCP, Port: 53, TargetPort: intstr.FromString("dns-tcp")},
+				},
+			},
+		}
+		dnsPod := func(name, ip string, phase corev1.PodPhase) *corev1.Pod {
+			return &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec: corev1.PodSpec{
+					NodeName: "node1",
+					Containers: []corev1.Container{{
+						Ports: []corev1.ContainerPort{
+							{Name: "dns", ContainerPort: 8053, Protocol: corev1.ProtocolUDP},
+							{Name: "dns-tcp", ContainerPort: 8053, Protocol: corev1.ProtocolTCP},
+						},
+					}},
+				},
+				Status: corev1.PodStatus{Phase: phase, PodIP: ip},
+			}
+		}
+		dnsPods := []*corev1.Pod{
+			dnsPod("coredns-2", "10.0.0.22", corev1.PodRunning),
+			dnsPod("coredns-1", "10.0.0.21", corev1.PodRunning),
+			dnsPod("coredns-3", "", corev1.PodPending),
+		}
+
+		clusterConfig := &config.ClusterConfig{}
+		deploy.AddKubeDNSEndpoints(logrus.New(), clusterConfig, dnsService, dnsPods)
+		Expect(clusterConfig.KubeDNSService).To(Equal(&config.Endpoint{
+			Hostname: common.DomainNameKubeDNSService,
+			IP:       "100.64.0.10",
+			Port:     53,
+		}))
+		Expect(clusterConfig.KubeDNSPods).To(Equal([]config.PodEndpoint{
+			{Nodename: "node1", Podname: "coredns-1", PodIP: "10.0.0.21", Port: 8053},
+			{Nodename: "node1", Podname: "coredns-2", PodIP: "10.0.0.22", Port: 8053},
+		}))
+	})
+})
@@ -15,6 +15,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 
 	"github.com/gardener/network-problem-detector/pkg/common"
 	"github.com/gardener/network-problem-detector/pkg/common/config"
@@ -99,7 +100,35 @@ func (dc *deployCommand) deployAgentAllDaemonsets(_ *cobra.Command, _ []string)
 	if err != nil {
 		return err
 	}
-	return dc.deployAgent(log, true, dc.buildAgentConfigMap, dc.buildClusterConfigMap)
+	err = dc.deployAgent(log, true, dc.buildAgentConfigMap, dc.buildClusterConfigMap)
+	if err != nil {
+		return err
+	}
+	return dc.deployNetworkPolicyCanary(log)
+}
+
+func (dc *deployCommand) deployNetworkPolicyCanary(log logrus.FieldLogger) error {
+	ac := dc.agentDeployConfig
+	if !ac.NetworkPolicyCheckEnabled && !dc.delete {
+		return nil
+	}
+
+	ctx := context.Background()
+	for _, obj := range ac.buildNetworkPolicyCanary() {
+		var err error
+		if !dc.delete {
+			_, err = genericCreateOrUpdate(ctx, dc.Clientset, obj)
+		} else {
+			err = genericDeleteWithLog(ctx, log, dc.Clientset, obj)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if !dc.delete {
+		log.Infof("deployed network policy canary %s/%s", common.NamespaceKubeSystem, common.NameNetworkPolicyCanary)
+	}
+	return nil
 }
 
 func (dc *deployCommand) deployAgentControllerDeployment(_ *cobra.Command, _ []string) error {
@@ -292,6 +321,18 @@ func (dc *deployCommand) buildClusterConfigMap(log logrus.FieldLogger) (*corev1.
 	if err != nil {
 		return nil, err
 	}
+	dnsService, err := dc.Clientset.CoreV1().Services(common.NamespaceKubeSystem).Get(ctx, common.NameKubeDNSService, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var dnsPods []*corev1.Pod
+	if len(dnsService.Spec.Selector) > 0 {
+		dnsPods, err = dc.pods(labels.SelectorFromSet(dnsService.Spec.Selector).String())
+		if err != nil {
+			return nil, err
+		}
+	}
+	AddKubeDNSEndpoints(log, clusterConfig, dnsService, dnsPods)
 	return BuildClusterConfigMap(clusterConfig)
 }
 
@@ -313,9 +354,13 @@ func (dc *deployCommand) nodes() ([]*corev1.Node, error) {
 }
 
 func (dc *deployCommand) agentPods() ([]*corev1.Pod, error) {
+	return dc.pods(fmt.Sprintf("%s=%s", common.LabelKeyK8sApp, common.NameDaemonSetAgentPodNet))
+}
+
+func (dc *deployCommand) pods(labelSelector string) ([]*corev1.Pod, error) {
 	ctx := context.Background()
 	podList, err := dc.Clientset.CoreV1().Pods(common.NamespaceKubeSystem).List(ctx, metav1.ListOptions{
-		LabelSelector: fmt.Sprintf("%s=%s", common.LabelKeyK8sApp, common.NameDaemonSetAgentPodNet),
+		LabelSelector: labelSelector,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("error listing pods: %w", err)
@@ -11,6 +11,7 @@ import (
 	"github.com/sirupsen/logrus"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -57,6 +58,8 @@ func genericCreateOrUpdate(ctx context.Context, clientset *kubernetes.Clientset,
 		return createOrUpdate(ctx, "role", clientset.RbacV1().Roles(object.GetNamespace()), v)
 	case *rbacv1.RoleBinding:
 		return createOrUpdate(ctx, "rolebinding", clientset.RbacV1().RoleBindings(object.GetNamespace()), v)
+	case *networkingv1.NetworkPolicy:
+		return createOrUpdate(ctx, "networkpolicy", clientset.NetworkingV1().NetworkPolicies(object.GetNamespace()), v)
 	default:
 		return nil, fmt.Errorf("unsupported type: %T", v)
 	}
@@ -102,6 +105,8 @@ func genericDelete(ctx context.Context, clientset *kubernetes.Clientset, object
 		itf = clientset.RbacV1().Roles(object.GetNamespace())
 	case *rbacv1.RoleBinding:
 		itf = clientset.RbacV1().RoleBindings(object.GetNamespace())
+	case *networkingv1.NetworkPolicy:
+		itf = clientset.NetworkingV1().NetworkPolicies(object.GetNamespace())
 	default:
 		return fmt.Errorf("unsupported type: %T", v)
 	}
@@ -130,6 +135,8 @@ func typename(object Object) (string, bool) {
 		return "role", true
 	case *rbacv1.RoleBinding:
 		return "role", true
+	case *networkingv1.NetworkPolicy:
+		return "networkpolicy", true
 	default:
 		return fmt.Sprintf("unsupported type: %T", v), false
 	}
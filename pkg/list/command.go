@@ -5,15 +5,9 @@
 package list
 
 import (
-	"bytes"
 	"context"
 	"fmt"
-	"net"
-	"net/http"
-	"os"
-	"os/exec"
 	"strings"
-	"syscall"
 	"time"
 
 	"github.com/gardener/network-problem-detector/pkg/common"
@@ -35,6 +29,7 @@ type listCommand struct {
 	destHosts  []string
 	failedOnly bool
 	window     time.Duration
+	follow     bool
 }
 
 func CreateListCmd() *cobra.Command {
@@ -54,6 +49,7 @@ func CreateListCmd() *cobra.Command {
 	cmd.Flags().StringArrayVar(&lc.destHosts, "dest", nil, "destination host(s) to filter")
 	cmd.Flags().BoolVar(&lc.failedOnly, "failed-only", false, "only failures")
 	cmd.Flags().DurationVar(&lc.window, "window", 1*time.Minute, "aggregation window (only for aggregated observations)")
+	cmd.Flags().BoolVarP(&lc.follow, "follow", "f", false, "stream new observations after listing the stored ones (only for observations)")
 	return cmd
 }
 
@@ -73,42 +69,17 @@ func (lc *listCommand) list(_ *cobra.Command, args []string) error {
 	default:
 		return fmt.Errorf("invalid kind: %s (allowed 'observation', 'obs', 'aggregated', 'aggr')", args[0])
 	}
-
-	podname := args[1]
-	port := 18007
-	for !lc.checkPortAvailable(port) {
-		port++
-	}
-	targetPort := lc.targetPort
-	if targetPort == 0 {
-		if strings.HasPrefix(podname, common.NameDaemonSetAgentHostNet) {
-			targetPort = common.HostNetPodHTTPPort
-		} else {
-			targetPort = common.PodNetPodHTTPPort
-		}
+	if aggr && lc.follow {
+		return fmt.Errorf("option --follow is only supported for observations")
 	}
 
-	kubeconfigOpt := ""
-	if lc.kubeconfig != "" {
-		kubeconfigOpt = " --kubeconfig=" + lc.kubeconfig
-	}
-
-	log.Infof("Loading observations from pod %s", podname)
-	cmdline := fmt.Sprintf("kubectl %s -n kube-system  port-forward %s %d:%d", kubeconfigOpt, podname, port, targetPort)
-	var stderr bytes.Buffer
-	cmd := exec.Command("sh", "-c", cmdline)              //  #nosec G204 -- only used in interactive shell
-	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true} // create process group for child processes
-	cmd.Stderr = &stderr
-	cmd.Env = os.Environ()
-	err := cmd.Start()
+	log.Infof("Loading observations from pod %s", args[1])
+	conn, err := connectAgent(lc.kubeconfig, args[1], lc.targetPort)
 	if err != nil {
 		return err
 	}
-	defer func() {
-		_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
-	}()
+	defer conn.stop()
 
-	client := nwpd.NewAgentServiceProtobufClient(fmt.Sprintf("http://localhost:%d", port), &http.Client{})
 	request := &nwpd.GetObservationsRequest{
 		Start:               timestamppb.New(time.Now().Add(-lc.since)),
 		Limit:               int32(lc.limit), // #nosec G115 - limit fits in int32
@@ -119,17 +90,16 @@ func (lc *listCommand) list(_ *cobra.Command, args []string) error {
 		AggregationWindow:   durationpb.New(lc.window),
 	}
 
-	for i := 0; i < 20; i++ {
-		if !lc.checkPortAvailable(port) {
-			break
-		}
-		time.Sleep(100 * time.Millisecond)
-	}
-
 	if aggr {
-		return lc.listAggregatedObservations(log, client, request)
+		return lc.listAggregatedObservations(log, conn.client, request)
+	}
+	if err := lc.listObservations(log, conn.client, request); err != nil {
+		return err
+	}
+	if lc.follow {
+		return lc.followObservations(log, conn.baseURL)
 	}
-	return lc.listObservations(log, client, request)
+	return nil
 }
 
 func (lc *listCommand) listObservations(log logrus.FieldLogger, client nwpd.AgentService, request *nwpd.GetObservationsRequest) error {
@@ -139,22 +109,29 @@ func (lc *listCommand) listObservations(log logrus.FieldLogger, client nwpd.Agen
 		return err
 	}
 	for _, obs := range response.Observations {
-		dur := ""
-		if obs.Duration != nil {
-			dur = fmt.Sprintf(" duration=%dms", obs.Duration.AsDuration().Milliseconds())
-		}
-		status := "ok"
-		if !obs.Ok {
-			status = "failed"
-		}
-		fmt.Printf("%s src=%s dest=%s jobid=%s%s status=%s\n", obs.Timestamp.AsTime().UTC().Format("2006-01-02T15:04:05.000Z"),
-			obs.SrcHost, obs.DestHost, obs.JobID, dur, status)
+		fmt.Println(formatObservation(obs))
 	}
 	log.Infof("%d observations", len(response.Observations))
 
 	return nil
 }
 
+func formatObservation(obs *nwpd.Observation) string {
+	dur := ""
+	if obs.Duration != nil {
+		dur = fmt.Sprintf(" duration=%dms", obs.Duration.AsDuration().Milliseconds())
+	}
+	status := "ok"
+	if !obs.Ok {
+		status = "failed"
+		if class := obs.FailureClass.Label(); class != "" {
+			status += " class=" + class
+		}
+	}
+	return fmt.Sprintf("%s src=%s dest=%s jobid=%s%s status=%s", obs.Timestamp.AsTime().UTC().Format("2006-01-02T15:04:05.000Z"),
+		obs.SrcHost, obs.DestHost, obs.JobID, dur, status)
+}
+
 func (lc *listCommand) listAggregatedObservations(log logrus.FieldLogger, client nwpd.AgentService, request *nwpd.GetObservationsRequest) error {
 	ctx := context.Background()
 	response, err := client.GetAggregatedObservations(ctx, request)
@@ -185,12 +162,3 @@ func (lc *listCommand) listAggregatedObservations(log logrus.FieldLogger, client
 
 	return nil
 }
-
-func (lc *listCommand) checkPortAvailable(port int) bool {
-	ln, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
-	if err != nil {
-		return false
-	}
-	_ = ln.Close()
-	return true
-}
@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package list
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/gardener/network-problem-detector/pkg/common"
+	"github.com/gardener/network-problem-detector/pkg/common/nwpd"
+)
+
+// agentConnection is a connection to the HTTP server of an agent using 'kubectl port-forward'.
+type agentConnection struct {
+	// baseURL is the local URL of the HTTP server of the agent.
+	baseURL string
+	// client is the client of the agent service.
+	client nwpd.AgentService
+	// stop stops the port forwarding.
+	stop func()
+}
+
+// connectAgent connects to the HTTP server of the agent pod using 'kubectl port-forward'.
+func connectAgent(kubeconfig, podname string, targetPort int) (*agentConnection, error) {
+	port := 18007
+	for !checkPortAvailable(port) {
+		port++
+	}
+	if targetPort == 0 {
+		if strings.HasPrefix(podname, common.NameDaemonSetAgentHostNet) {
+			targetPort = common.HostNetPodHTTPPort
+		} else {
+			targetPort = common.PodNetPodHTTPPort
+		}
+	}
+
+	kubeconfigOpt := ""
+	if kubeconfig != "" {
+		kubeconfigOpt = " --kubeconfig=" + kubeconfig
+	}
+
+	cmdline := fmt.Sprintf("kubectl %s -n kube-system  port-forward %s %d:%d", kubeconfigOpt, podname, port, targetPort)
+	var stderr bytes.Buffer
+	cmd := exec.Command("sh", "-c", cmdline)              //  #nosec G204 -- only used in interactive shell
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true} // create process group for child processes
+	cmd.Stderr = &stderr
+	cmd.Env = os.Environ()
+	err := cmd.Start()
+	if err != nil {
+		return nil, err
+	}
+	baseURL := fmt.Sprintf("http://localhost:%d", port)
+	conn := &agentConnection{
+		baseURL: baseURL,
+		client:  nwpd.NewAgentServiceProtobufClient(baseURL, &http.Client{}),
+		stop: func() {
+			_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+		},
+	}
+
+	for i := 0; i < 20; i++ {
+		if !checkPortAvailable(port) {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return conn, nil
+}
+
+func checkPortAvailable(port int) bool {
+	ln, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return false
+	}
+	_ = ln.Close()
+	return true
+}
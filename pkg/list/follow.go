@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package list
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/gardener/network-problem-detector/pkg/common"
+	"github.com/gardener/network-problem-detector/pkg/common/nwpd"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// followObservations prints the observations streamed by the agent until interrupted.
+func (lc *listCommand) followObservations(log logrus.FieldLogger, baseURL string) error {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	query := url.Values{}
+	query["restrictToJobIDs"] = lc.jobIDs
+	query["restrictToSrcHosts"] = lc.srcHosts
+	query["restrictToDestHosts"] = lc.destHosts
+	if lc.failedOnly {
+		query.Set("failuresOnly", "true")
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+common.PathStreamObservations+"?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return fmt.Errorf("streaming observations failed with status %s: %s", response.Status, strings.TrimSpace(string(body)))
+	}
+
+	log.Infof("following observations, press Ctrl-C to stop")
+	count := 0
+	err = readServerSentEvents(response.Body, func(event, data string) error {
+		switch event {
+		case "dropped":
+			log.Warnf("%s observations dropped in total by the agent because of slow streaming", data)
+		case "":
+			obs := &nwpd.Observation{}
+			if err := protojson.Unmarshal([]byte(data), obs); err != nil {
+				return fmt.Errorf("invalid observation %q: %w", data, err)
+			}
+			count++
+			fmt.Println(formatObservation(obs))
+		}
+		return nil
+	})
+	if ctx.Err() != nil {
+		log.Infof("%d observations streamed", count)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("stream closed by agent after %d observations", count)
+}
+
+// readServerSentEvents reads server-sent events and calls the handler for each event with data.
+// The event name is empty for events without name. Comments are ignored.
+func readServerSentEvents(r io.Reader, handle func(event, data string) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	var event string
+	var data []string
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if len(data) > 0 {
+				if err := handle(event, strings.Join(data, "\n")); err != nil {
+					return err
+				}
+			}
+			event = ""
+			data = nil
+		case strings.HasPrefix(line, ":"):
+			// comment
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			data = append(data, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+	return scanner.Err()
+}
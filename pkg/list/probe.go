@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package list
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gardener/network-problem-detector/pkg/common/nwpd"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+type probeCommand struct {
+	kubeconfig string
+	targetPort int
+	destHosts  []string
+	timeout    time.Duration
+}
+
+func CreateProbeCmd() *cobra.Command {
+	pc := &probeCommand{}
+	cmd := &cobra.Command{
+		Use:   "probe <podname> -- <runner args>",
+		Short: "runs checks on demand on an agent",
+		Long: `runs the checks of the runner args (same syntax as the args of a job) once on an agent using 'kubectl port-forward' and HTTP.
+The results are tagged as ad-hoc and don't influence the node conditions.`,
+		Example: `nwpdcli probe network-problem-detector-host-abcde -- checkTCPPort --node-port 10250
+nwpdcli probe network-problem-detector-pod-abcde --dest api.example.com -- checkHTTPSGet --endpoint-external-kube-apiserver`,
+		RunE: pc.probe,
+	}
+	cmd.Flags().StringVar(&pc.kubeconfig, "kubeconfig", "", "kubeconfig for shoot cluster, uses KUBECONFIG if not specified.")
+	cmd.Flags().IntVar(&pc.targetPort, "targetPort", 0, "target pod port")
+	cmd.Flags().StringArrayVar(&pc.destHosts, "dest", nil, "destination host(s) to restrict the checks to")
+	cmd.Flags().DurationVar(&pc.timeout, "timeout", 10*time.Second, "overall timeout of the probe (max 1m)")
+	return cmd
+}
+
+func (pc *probeCommand) probe(cmd *cobra.Command, args []string) error {
+	log := logrus.WithField("cmd", "probe")
+
+	if cmd.ArgsLenAtDash() != 1 || len(args) < 2 {
+		return fmt.Errorf("expected arguments: <podname> -- <runner args>")
+	}
+	podname := args[0]
+
+	log.Infof("Running probe on pod %s", podname)
+	conn, err := connectAgent(pc.kubeconfig, podname, pc.targetPort)
+	if err != nil {
+		return err
+	}
+	defer conn.stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), pc.timeout+10*time.Second)
+	defer cancel()
+	response, err := conn.client.RunProbe(ctx, &nwpd.RunProbeRequest{
+		Args:                args[1:],
+		RestrictToDestHosts: pc.destHosts,
+		Timeout:             durationpb.New(pc.timeout),
+	})
+	if err != nil {
+		return err
+	}
+	for _, obs := range response.Observations {
+		fmt.Printf("%s result=%q\n", formatObservation(obs), obs.Result)
+	}
+	log.Infof("%d observations", len(response.Observations))
+	if response.PendingChecks > 0 {
+		log.Warnf("%d checks not finished before timeout of %s", response.PendingChecks, pc.timeout)
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package list
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gardener/network-problem-detector/pkg/common"
+	"github.com/gardener/network-problem-detector/pkg/common/nwpd"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+type statusCommand struct {
+	kubeconfig string
+	targetPort int
+	verbose    bool
+}
+
+func CreateStatusCmd() *cobra.Command {
+	sc := &statusCommand{}
+	cmd := &cobra.Command{
+		Use:   "status <podname>",
+		Short: "shows the jobs and runner state of an agent",
+		Long:  `shows the version, configuration generation, jobs with targets and last results, and the aggregator state of an agent using 'kubectl port-forward' and HTTP`,
+		RunE:  sc.status,
+	}
+	cmd.Flags().StringVar(&sc.kubeconfig, "kubeconfig", "", "kubeconfig for shoot cluster, uses KUBECONFIG if not specified.")
+	cmd.Flags().IntVar(&sc.targetPort, "targetPort", 0, "target pod port")
+	cmd.Flags().BoolVarP(&sc.verbose, "verbose", "v", false, "show last results of all targets, not only the failed ones")
+	return cmd
+}
+
+func (sc *statusCommand) status(_ *cobra.Command, args []string) error {
+	log := logrus.WithField("cmd", "status")
+
+	if len(args) != 1 {
+		return fmt.Errorf("missing pod name: %s", strings.Join(args, " "))
+	}
+
+	log.Infof("Loading status from pod %s", args[0])
+	conn, err := connectAgent(sc.kubeconfig, args[0], sc.targetPort)
+	if err != nil {
+		return err
+	}
+	defer conn.stop()
+
+	response, err := conn.client.GetStatus(context.Background(), &nwpd.GetStatusRequest{})
+	if err != nil {
+		return err
+	}
+	printStatus(os.Stdout, response, time.Now(), sc.verbose)
+	return nil
+}
+
+func printStatus(out io.Writer, status *nwpd.GetStatusResponse, now time.Time, verbose bool) {
+	network := "pod network"
+	if status.HostNetwork {
+		network = "host network"
+	}
+	fmt.Fprintf(out, "agent version %s on node %s (%s)\n", status.Version, status.NodeName, network)
+	applied := "never"
+	if status.ConfigApplied != nil {
+		applied = formatTime(status.ConfigApplied.AsTime(), now)
+	}
+	fmt.Fprintf(out, "config generation %d, applied %s\n", status.ConfigGeneration, applied)
+	if edges := status.ValidEdges; edges != nil {
+		fmt.Fprintf(out, "aggregator valid edges: %d job IDs, %d source hosts, %d destination hosts, %d peer nodes\n",
+			len(edges.JobIDs), len(edges.SrcHosts), len(edges.DestHosts), edges.PeerNodeCount)
+	}
+
+	for _, job := range status.Jobs {
+		fmt.Fprintf(out, "\njob %s: %s\n", job.JobID, strings.Join(job.Args, " "))
+		fmt.Fprintf(out, "  runner: %s, period %s, %d peer nodes\n", job.Description, job.Period.AsDuration(), job.PeerNodeCount)
+		if len(job.Labels) > 0 {
+			fmt.Fprintf(out, "  labels: %s\n", common.FormatLabels(job.Labels))
+		}
+		lastRun := "never"
+		if job.LastRun != nil {
+			lastRun = formatTime(job.LastRun.AsTime(), now)
+		}
+		if job.Active {
+			lastRun += ", active"
+		}
+		fmt.Fprintf(out, "  last run: %s\n", lastRun)
+
+		okCount := 0
+		for _, obs := range job.LastResults {
+			if obs.Ok {
+				okCount++
+			}
+		}
+		fmt.Fprintf(out, "  targets: %d, last results: %d ok, %d failed, %d without result\n", len(job.DestHosts), okCount,
+			len(job.LastResults)-okCount, len(job.DestHosts)-len(job.LastResults))
+		for _, obs := range job.LastResults {
+			if obs.Ok && !verbose {
+				continue
+			}
+			status := "ok"
+			if !obs.Ok {
+				status = "failed"
+			}
+			fmt.Fprintf(out, "    %s: %s %s: %s\n", obs.DestHost, status, formatTime(obs.Timestamp.AsTime(), now), obs.Result)
+		}
+	}
+}
+
+func formatTime(t, now time.Time) string {
+	return fmt.Sprintf("%s (%s ago)", common.FormatAsUTC(t), now.Sub(t).Round(time.Second))
+}
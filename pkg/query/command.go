@@ -6,6 +6,7 @@ package query
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/gardener/network-problem-detector/pkg/common/nwpd"
 
 	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 type queryCommand struct {
@@ -23,6 +25,15 @@ type queryCommand struct {
 	minutes    int
 	failedOnly bool
 	exactMatch bool
+	classes    []string
+	groupBy    bool
+
+	classFilter map[nwpd.FailureClass]bool
+}
+
+type classGroupKey struct {
+	jobID string
+	class string
 }
 
 func CreateQueryCmd() *cobra.Command {
@@ -40,6 +51,8 @@ func CreateQueryCmd() *cobra.Command {
 	cmd.Flags().BoolVar(&qc.failedOnly, "failed-only", false, "if only failed checks should be printed.")
 	cmd.Flags().BoolVar(&qc.exactMatch, "match-exact", false, "if filter expressions must match full names.")
 	cmd.Flags().IntVar(&qc.minutes, "minutes", 0, "restrict to given last minutes.")
+	cmd.Flags().StringSliceVar(&qc.classes, "class", nil, fmt.Sprintf("filter failed checks by failure class (one of %s).", strings.Join(nwpd.FailureClassLabels(), ", ")))
+	cmd.Flags().BoolVar(&qc.groupBy, "group-by-class", false, "print the number of failed checks per job ID and failure class instead of the observations.")
 
 	return cmd
 }
@@ -49,6 +62,10 @@ func (qc *queryCommand) query(_ *cobra.Command, _ []string) error {
 	if err != nil {
 		return err
 	}
+	qc.classFilter, err = nwpd.ParseFailureClasses(qc.classes)
+	if err != nil {
+		return err
+	}
 
 	var (
 		endMillis   = time.Now().UnixMilli()
@@ -57,29 +74,42 @@ func (qc *queryCommand) query(_ *cobra.Command, _ []string) error {
 	if qc.minutes > 0 {
 		startMillis = endMillis - int64(qc.minutes*60000)
 	}
+	match := strings.Contains
+	if qc.exactMatch {
+		match = func(s, t string) bool { return s == t }
+	}
+	matchEdge := func(jobID, srcHost, destHost string) bool {
+		return (qc.src == "" || match(srcHost, qc.src)) &&
+			(qc.dest == "" || match(destHost, qc.dest)) &&
+			(qc.jobID == "" || match(jobID, qc.jobID))
+	}
+	filter := db.RecordFilter{
+		Start:        time.UnixMilli(startMillis),
+		End:          time.UnixMilli(endMillis),
+		Edge:         matchEdge,
+		FailuresOnly: qc.failedOnly || qc.groupBy || qc.classFilter != nil,
+	}
 	count := 0
+	groups := map[classGroupKey]int{}
 	for _, filename := range filenames {
-		if err := db.IterateRecordFile(filename, func(obs *nwpd.Observation) error {
+		if err := db.IterateRecordFileFiltered(filename, filter, func(obs *nwpd.Observation) error {
 			timeMillis := obs.Timestamp.AsTime().UnixMilli()
 
 			if timeMillis < startMillis || timeMillis > endMillis {
 				return nil
 			}
 
-			if qc.failedOnly && obs.Ok {
+			if (qc.failedOnly || qc.groupBy) && obs.Ok {
 				return nil
 			}
-			match := strings.Contains
-			if qc.exactMatch {
-				match = func(s, t string) bool { return s == t }
-			}
-			if qc.src != "" && !match(obs.SrcHost, qc.src) {
+			if qc.classFilter != nil && (obs.Ok || !qc.classFilter[obs.FailureClass]) {
 				return nil
 			}
-			if qc.dest != "" && !match(obs.DestHost, qc.dest) {
+			if !matchEdge(obs.JobID, obs.SrcHost, obs.DestHost) {
 				return nil
 			}
-			if qc.jobID != "" && !match(obs.JobID, qc.jobID) {
+			if qc.groupBy {
+				groups[classGroupKey{jobID: obs.JobID, class: obs.FailureClass.FailureLabel()}]++
 				return nil
 			}
 			if count == 0 {
@@ -93,12 +123,42 @@ func (qc *queryCommand) query(_ *cobra.Command, _ []string) error {
 			if obs.Duration != nil {
 				dur = fmt.Sprintf(`,"duration": "%dms"`, obs.Duration.AsDuration().Milliseconds())
 			}
-			fmt.Printf("{%q: %q, %q: %q, %q: %q, %q: %q%s, %q: %t}", "time", t, "src", obs.SrcHost, "dest", obs.DestHost, "jobID", obs.JobID, dur, "ok", obs.Ok)
+			phases := ""
+			if obs.PhaseTimings != nil {
+				var items []string
+				for i, d := range obs.PhaseTimings.Durations() {
+					if d != nil {
+						items = append(items, fmt.Sprintf("%q: \"%.3fms\"", nwpd.PhaseNames[i], float64(d.AsDuration().Microseconds())/1000))
+					}
+				}
+				phases = fmt.Sprintf(`, "phases": {%s}`, strings.Join(items, ", "))
+			}
+			pingStats := ""
+			if s := obs.PingStatistics; s != nil {
+				pingStats = fmt.Sprintf(`, "ping": {"sent": %d, "received": %d, "loss": "%.1f%%"`, s.PacketsSent, s.PacketsRecv, s.LossPercent)
+				if s.AvgRtt != nil {
+					pingStats += fmt.Sprintf(`, "rtt": "%.3f/%.3f/%.3f/%.3fms"`, rttMillis(s.MinRtt), rttMillis(s.AvgRtt), rttMillis(s.MaxRtt), rttMillis(s.MdevRtt))
+				}
+				pingStats += "}"
+			}
+			attempts := ""
+			if obs.Attempts > 1 {
+				attempts = fmt.Sprintf(`, "attempts": %d`, obs.Attempts)
+			}
+			class := ""
+			if !obs.Ok {
+				class = fmt.Sprintf(`, "class": %q`, obs.FailureClass.FailureLabel())
+			}
+			fmt.Printf("{%q: %q, %q: %q, %q: %q, %q: %q%s%s%s, %q: %t%s%s}", "time", t, "src", obs.SrcHost, "dest", obs.DestHost, "jobID", obs.JobID, dur, phases, pingStats, "ok", obs.Ok, class, attempts)
 			return nil
 		}); err != nil {
 			return err
 		}
 	}
+	if qc.groupBy {
+		printClassGroups(groups)
+		return nil
+	}
 	if count > 0 {
 		fmt.Printf("]\n")
 	} else {
@@ -106,3 +166,26 @@ func (qc *queryCommand) query(_ *cobra.Command, _ []string) error {
 	}
 	return nil
 }
+
+func printClassGroups(groups map[classGroupKey]int) {
+	keys := make([]classGroupKey, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].jobID != keys[j].jobID {
+			return keys[i].jobID < keys[j].jobID
+		}
+		return keys[i].class < keys[j].class
+	})
+	items := make([]string, 0, len(keys))
+	for _, key := range keys {
+		items = append(items, fmt.Sprintf("{%q: %q, %q: %q, %q: %d}", "jobID", key.jobID, "class", key.class, "count", groups[key]))
+	}
+	fmt.Printf("[%s]\n", strings.Join(items, ",\n"))
+}
+
+// rttMillis returns the round trip time in milliseconds (the min/avg/max/mdev format of ping).
+func rttMillis(d *durationpb.Duration) float64 {
+	return float64(d.AsDuration().Microseconds()) / 1000
+}